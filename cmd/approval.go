@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/astrostl/slack-butler/pkg/slack"
+)
+
+// userIDPattern matches Slack user IDs, e.g. U012AB3CD or W012AB3CD for
+// Enterprise Grid.
+var userIDPattern = regexp.MustCompile(`^[UW][A-Z0-9]+$`)
+
+// approvalSettings configure the approval step of channels archive: with an
+// admin channel set, channels are only archived once an approver reacted to
+// the approval request for them.
+type approvalSettings struct {
+	adminChannel string
+	approvers    []string
+	reaction     string
+}
+
+// currentApprovalSettings returns the settings given with
+// --request-approval, --approvers and --approval-reaction.
+func currentApprovalSettings() approvalSettings {
+	settings := approvalSettings{
+		adminChannel: strings.TrimPrefix(strings.TrimSpace(requestApproval), "#"),
+		reaction:     strings.Trim(strings.TrimSpace(approvalReaction), ":"),
+	}
+	for _, approver := range strings.Split(approvers, ",") {
+		if approver = strings.TrimSpace(approver); approver != "" {
+			settings.approvers = append(settings.approvers, approver)
+		}
+	}
+	return settings
+}
+
+func (s approvalSettings) enabled() bool {
+	return s.adminChannel != ""
+}
+
+func (s approvalSettings) validate() error {
+	if !s.enabled() {
+		return nil
+	}
+	if len(s.approvers) == 0 {
+		return fmt.Errorf("--approvers is required with --request-approval")
+	}
+	for _, approver := range s.approvers {
+		if !userIDPattern.MatchString(approver) {
+			return fmt.Errorf("invalid approver '%s': give Slack user IDs, e.g. U012AB3CD", approver)
+		}
+	}
+	if s.reaction == "" {
+		return fmt.Errorf("--approval-reaction must not be empty")
+	}
+	return nil
+}
+
+// approvalGate holds the channels a run may archive once approvals are
+// checked, and what is left to request.
+type approvalGate struct {
+	approval  *slack.ArchiveApproval
+	approvals *slack.ArchiveApprovals
+	archive   []slack.Channel
+}
+
+// gateArchivalOnApproval narrows toArchive to the channels an approver
+// approved when --request-approval is set, and reports the rest. Without
+// it, or in warn-only mode, every channel may be archived.
+func gateArchivalOnApproval(ctx context.Context, client *slack.Client, settings approvalSettings, toArchive []slack.Channel, userMap map[string]string, warnOnlyMode bool) (*approvalGate, error) {
+	if !settings.enabled() || warnOnlyMode {
+		return &approvalGate{archive: toArchive}, nil
+	}
+	if err := client.RequireScopes(ctx, "reactions:read"); err != nil {
+		return nil, err
+	}
+	for _, approver := range settings.approvers {
+		if _, ok := userMap[approver]; !ok {
+			return nil, fmt.Errorf("approver %s is not a member of this workspace", approver)
+		}
+	}
+	admin, err := client.FindChannelsByName(ctx, []string{settings.adminChannel})
+	if err != nil {
+		return nil, fmt.Errorf("approval channel: %w", err)
+	}
+	if admin[0].IsArchived {
+		return nil, fmt.Errorf("approval channel #%s is archived", admin[0].Name)
+	}
+
+	approval := &slack.ArchiveApproval{AdminChannel: admin[0], Approvers: settings.approvers, Reaction: settings.reaction}
+	approvals, err := client.CheckArchiveApprovals(ctx, *approval, toArchive)
+	if err != nil {
+		return nil, err
+	}
+	gate := &approvalGate{approval: approval, approvals: approvals, archive: approvals.Approved}
+	gate.display(userMap)
+	return gate, nil
+}
+
+// gatePlanOnApproval drops the archive actions of plan that no approver
+// approved when --request-approval is set, so applying a plan made without
+// it can't archive unapproved channels. It returns the gated plan and the
+// names of the channels dropped.
+func gatePlanOnApproval(ctx context.Context, client *slack.Client, settings approvalSettings, plan *archivePlan) (*archivePlan, []string, error) {
+	_, toArchive := plan.channels()
+	if !settings.enabled() || plan.WarnOnly || len(toArchive) == 0 {
+		return plan, nil, nil
+	}
+	userMap, err := client.GetUserMap(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	gate, err := gateArchivalOnApproval(ctx, client, settings, toArchive, userMap, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	approved := make(map[string]bool, len(gate.archive))
+	for _, channel := range gate.archive {
+		approved[channel.ID] = true
+	}
+	gated := *plan
+	gated.Actions = make([]plannedAction, 0, len(plan.Actions))
+	var unapproved []string
+	for _, action := range plan.Actions {
+		if action.Action == planActionArchive && !approved[action.ChannelID] {
+			unapproved = append(unapproved, action.Channel)
+			continue
+		}
+		gated.Actions = append(gated.Actions, action)
+	}
+	return &gated, unapproved, nil
+}
+
+// display reports the approval state of the channels due for archival.
+func (g *approvalGate) display(userMap map[string]string) {
+	fmt.Printf("🗳️  Archival needs approval in #%s (:%s: from %s):\n", g.approval.AdminChannel.Name, g.approval.Reaction, strings.Join(approverNames(g.approval.Approvers, userMap), ", "))
+	approved := make([]string, len(g.approvals.Approved))
+	for i, channel := range g.approvals.Approved {
+		approved[i] = fmt.Sprintf("#%s (by %s)", channel.Name, approverNames([]string{channel.ApprovedBy}, userMap)[0])
+	}
+	if len(approved) > 0 {
+		fmt.Printf("  Approved: %d (%s)\n", len(approved), strings.Join(approved, ", "))
+	} else {
+		fmt.Printf("  Approved: 0\n")
+	}
+	displaySummaryLine("Awaiting approval", extractChannelNames(g.approvals.Pending))
+	displaySummaryLine("Not yet requested", extractChannelNames(g.approvals.Unrequested))
+	fmt.Println()
+}
+
+// request posts an approval request for the channels not yet requested.
+func (g *approvalGate) request(ctx context.Context, client *slack.Client, isDryRun bool) error {
+	if g.approval == nil || len(g.approvals.Unrequested) == 0 {
+		return nil
+	}
+	names := strings.Join(addHashPrefix(extractChannelNames(g.approvals.Unrequested)), ", ")
+	if isDryRun {
+		fmt.Printf("Would request approval in #%s to archive %d channels: %s\n\n", g.approval.AdminChannel.Name, len(g.approvals.Unrequested), names)
+		return nil
+	}
+	if err := client.RequestArchiveApproval(ctx, *g.approval, g.approvals.Unrequested); err != nil {
+		return fmt.Errorf("failed to request archive approval: %w", err)
+	}
+	fmt.Printf("📨 Requested approval in #%s to archive %d channels: %s\n\n", g.approval.AdminChannel.Name, len(g.approvals.Unrequested), names)
+	return nil
+}
+
+// approverNames returns the names of approvers, falling back to their IDs.
+func approverNames(ids []string, userMap map[string]string) []string {
+	names := make([]string, len(ids))
+	for i, id := range ids {
+		names[i] = id
+		if name := userMap[id]; name != "" {
+			names[i] = name
+		}
+	}
+	return names
+}
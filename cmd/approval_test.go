@@ -0,0 +1,166 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/astrostl/slack-butler/pkg/slack"
+
+	slackapi "github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApprovalSettings(t *testing.T) {
+	assert.NoError(t, approvalSettings{}.validate())
+	assert.False(t, approvalSettings{}.enabled())
+
+	settings := approvalSettings{adminChannel: "admins", approvers: []string{"U100", "W200"}, reaction: "white_check_mark"}
+	assert.True(t, settings.enabled())
+	assert.NoError(t, settings.validate())
+
+	assert.ErrorContains(t, approvalSettings{adminChannel: "admins", reaction: "ok"}.validate(), "--approvers is required")
+	assert.ErrorContains(t, approvalSettings{adminChannel: "admins", approvers: []string{"alice"}, reaction: "ok"}.validate(), "invalid approver 'alice'")
+	assert.ErrorContains(t, approvalSettings{adminChannel: "admins", approvers: []string{"U100"}}.validate(), "--approval-reaction")
+
+	original := []string{requestApproval, approvers, approvalReaction}
+	defer func() { requestApproval, approvers, approvalReaction = original[0], original[1], original[2] }()
+	requestApproval, approvers, approvalReaction = " #admins", "U100, W200,", ":+1:"
+	assert.Equal(t, approvalSettings{adminChannel: "admins", approvers: []string{"U100", "W200"}, reaction: "+1"}, currentApprovalSettings())
+}
+
+func TestArchiveRunWithApproval(t *testing.T) {
+	capture := func(t *testing.T, run func() error) (string, error) {
+		r, w, err := os.Pipe()
+		require.NoError(t, err)
+		oldStdout := os.Stdout
+		os.Stdout = w
+		runErr := run()
+		require.NoError(t, w.Close())
+		os.Stdout = oldStdout
+		output, err := io.ReadAll(r)
+		require.NoError(t, err)
+		return string(output), runErr
+	}
+	run := func(t *testing.T, isDryRun bool, adminHistory []slack.MockHistoryMessage) (*slack.MockSlackAPI, string, error) {
+		mockAPI := slack.NewMockSlackAPI()
+		setupCommitModeTest(mockAPI)
+		mockAPI.AddUser("U100", "alice", "Alice Admin")
+		mockAPI.AddChannel("C9", "admins", time.Now().Add(-time.Hour), "Admins")
+		mockAPI.SetChannelHistory("C9", adminHistory)
+		client, err := slack.NewClientWithAPI(mockAPI)
+		require.NoError(t, err)
+		output, err := capture(t, func() error {
+			return runArchiveWithClient(context.Background(), client, 30, 7, isDryRun, "admins", "", 30.0/86400, 7.0/86400, false, 10, 0.9, false, 0)
+		})
+		return mockAPI, output, err
+	}
+
+	original := []string{requestApproval, approvers, approvalReaction}
+	defer func() { requestApproval, approvers, approvalReaction = original[0], original[1], original[2] }()
+	requestApproval, approvers, approvalReaction = "#admins", "U100", slack.DefaultApprovalReaction
+
+	t.Run("Unrequested channels are requested, not archived", func(t *testing.T) {
+		mockAPI, output, err := run(t, false, nil)
+		require.NoError(t, err)
+		assert.Empty(t, mockAPI.ArchivedChannels)
+		assert.Contains(t, output, "Not yet requested: 1 (#archive-channel)")
+		assert.Contains(t, output, "Requested approval in #admins to archive 1 channels: #archive-channel")
+
+		// The request and its one item.
+		var adminPosts int
+		for _, msg := range mockAPI.PostedMessages {
+			if msg.ChannelID == "C9" {
+				adminPosts++
+			}
+		}
+		assert.Equal(t, 2, adminPosts)
+	})
+
+	t.Run("Dry run only shows the request", func(t *testing.T) {
+		mockAPI, output, err := run(t, true, nil)
+		require.NoError(t, err)
+		assert.Empty(t, mockAPI.PostedMessages)
+		assert.Contains(t, output, "Would request approval in #admins to archive 1 channels: #archive-channel")
+	})
+
+	requestTS := fmt.Sprintf("%.6f", float64(time.Now().Add(-5*time.Second).Unix()))
+	itemTS := fmt.Sprintf("%.6f", float64(time.Now().Add(-4*time.Second).Unix()))
+	request := func(reactions ...slackapi.ItemReaction) []slack.MockHistoryMessage {
+		return []slack.MockHistoryMessage{{
+			Timestamp: requestTS,
+			User:      "U0000000",
+			Text:      slack.FormatApprovalRequest(slack.ArchiveApproval{Approvers: []string{"U100"}, Reaction: slack.DefaultApprovalReaction}, 1),
+			Replies: []slack.MockHistoryMessage{{
+				Timestamp: itemTS,
+				User:      "U0000000",
+				Text:      slack.FormatApprovalRequestItem(slack.Channel{ID: "C2", Name: "archive-channel"}),
+				Reactions: reactions,
+			}},
+		}}
+	}
+
+	t.Run("Pending channels are left alone", func(t *testing.T) {
+		mockAPI, output, err := run(t, false, request())
+		require.NoError(t, err)
+		assert.Empty(t, mockAPI.ArchivedChannels)
+		assert.Contains(t, output, "Awaiting approval: 1 (#archive-channel)")
+		assert.NotContains(t, output, "Requested approval")
+	})
+
+	t.Run("Approved channels are archived", func(t *testing.T) {
+		mockAPI, output, err := run(t, false, request(slackapi.ItemReaction{Name: slack.DefaultApprovalReaction, Count: 1, Users: []string{"U100"}}))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"C2"}, mockAPI.ArchivedChannels)
+		assert.Contains(t, output, "Approved: 1 (#archive-channel (by Alice Admin))")
+	})
+
+	t.Run("Applying a plan only archives approved channels", func(t *testing.T) {
+		for name, tc := range map[string]struct {
+			adminHistory []slack.MockHistoryMessage
+			archived     []string
+		}{
+			"pending":  {adminHistory: request()},
+			"approved": {adminHistory: request(slackapi.ItemReaction{Name: slack.DefaultApprovalReaction, Count: 1, Users: []string{"U100"}}), archived: []string{"C2"}},
+		} {
+			mockAPI := slack.NewMockSlackAPI()
+			setupCommitModeTest(mockAPI)
+			mockAPI.AddUser("U100", "alice", "Alice Admin")
+			mockAPI.AddChannel("C9", "admins", time.Now().Add(-time.Hour), "Admins")
+			mockAPI.SetChannelHistory("C9", tc.adminHistory)
+			client, err := slack.NewClientWithAPI(mockAPI)
+			require.NoError(t, err)
+
+			// A plan made without --request-approval
+			path := filepath.Join(t.TempDir(), "plan.json")
+			requestApproval, planOut = "", path
+			_, err = capture(t, func() error {
+				return runArchiveWithClient(context.Background(), client, 30, 7, true, "admins", "", 30.0/86400, 7.0/86400, false, 10, 0.9, false, 0)
+			})
+			requestApproval, planOut = "#admins", ""
+			require.NoError(t, err, name)
+			plan, err := loadArchivePlan(path)
+			require.NoError(t, err, name)
+
+			output, err := capture(t, func() error { return runApplyPlanWithClient(context.Background(), client, plan, "", false) })
+			require.NoError(t, err, name)
+			assert.ElementsMatch(t, tc.archived, mockAPI.ArchivedChannels, name)
+			if tc.archived == nil {
+				assert.Contains(t, output, "Skipped (not approved): 1 (#archive-channel)", name)
+			}
+		}
+	})
+
+	t.Run("Unknown approvers are rejected", func(t *testing.T) {
+		approvers = "U999"
+		defer func() { approvers = "U100" }()
+		mockAPI, _, err := run(t, false, nil)
+		assert.ErrorContains(t, err, "approver U999 is not a member")
+		assert.Empty(t, mockAPI.ArchivedChannels)
+	})
+}
@@ -1,7 +1,10 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 	"testing"
 	"time"
@@ -228,7 +231,7 @@ func TestRunArchiveWithClient(t *testing.T) {
 			// Convert seconds to days for the new API
 			testWarnDays := float64(tt.warnSeconds) / (24 * 60 * 60)
 			testArchiveDays := float64(tt.archiveSeconds) / (24 * 60 * 60)
-			err = runArchiveWithClient(client, tt.warnSeconds, tt.archiveSeconds, tt.isPreviewMode, "", "", testWarnDays, testArchiveDays, false, 10, 0.9, false, 0)
+			err = runArchiveWithClient(context.Background(), client, tt.warnSeconds, tt.archiveSeconds, tt.isPreviewMode, "", "", testWarnDays, testArchiveDays, false, 10, 0.9, false, 0)
 			validateTestResults(t, tt, err, mockAPI)
 		})
 	}
@@ -330,7 +333,7 @@ func TestInactiveChannelDetectionLogic(t *testing.T) {
 			}
 
 			// Run analysis
-			toWarn, toArchive, err := client.GetInactiveChannels(tt.warnSeconds, tt.archiveSeconds)
+			toWarn, toArchive, err := client.GetInactiveChannels(context.Background(), tt.warnSeconds, tt.archiveSeconds)
 			if err != nil {
 				t.Fatalf("GetInactiveChannels failed: %v", err)
 			}
@@ -386,7 +389,7 @@ func TestChannelExclusions(t *testing.T) {
 		t.Fatalf("Failed to create client: %v", err)
 	}
 
-	toWarn, toArchive, err := client.GetInactiveChannels(30, 7)
+	toWarn, toArchive, err := client.GetInactiveChannels(context.Background(), 30, 7)
 	if err != nil {
 		t.Fatalf("GetInactiveChannels failed: %v", err)
 	}
@@ -517,7 +520,7 @@ func TestArchiveGetInactiveChannelsErrorPaths(t *testing.T) {
 		excludeChannels := []string{}
 		excludePrefixes := []string{}
 
-		_, _, _, err = getInactiveChannelsWithErrorHandling(client, 30, 7, userMap, excludeChannels, excludePrefixes, false, false, 0)
+		_, _, _, err = getInactiveChannelsWithErrorHandling(context.Background(), client, 30, 7, userMap, excludeChannels, excludePrefixes, false, false, 0)
 
 		if err == nil {
 			t.Error("Expected error for rate limit scenario")
@@ -540,7 +543,7 @@ func TestArchiveGetInactiveChannelsErrorPaths(t *testing.T) {
 		excludeChannels := []string{}
 		excludePrefixes := []string{}
 
-		_, _, _, err = getInactiveChannelsWithErrorHandling(client, 30, 7, userMap, excludeChannels, excludePrefixes, false, false, 0)
+		_, _, _, err = getInactiveChannelsWithErrorHandling(context.Background(), client, 30, 7, userMap, excludeChannels, excludePrefixes, false, false, 0)
 
 		if err == nil {
 			t.Error("Expected error for generic API error scenario")
@@ -576,7 +579,7 @@ func testDefaultChannelCheckWithUserInfo(t *testing.T) {
 	mockAPI.AddChannel("C002", "random", time.Now().Add(-30*24*time.Hour), "Random chat")
 
 	// Should run without error
-	err = runDefaultChannelCheckWithClient(client, 10, 1.0)
+	err = runDefaultChannelCheckWithClient(context.Background(), client, 10, 1.0)
 	if err != nil {
 		t.Errorf("Expected no error, got: %v", err)
 	}
@@ -593,7 +596,7 @@ func testDefaultChannelCheckNoChannels(t *testing.T) {
 	mockAPI.AddUser("U001", "alice", "Alice Smith")
 
 	// Should run without error
-	err = runDefaultChannelCheckWithClient(client, 10, 0.9)
+	err = runDefaultChannelCheckWithClient(context.Background(), client, 10, 0.9)
 	if err != nil {
 		t.Errorf("Expected no error, got: %v", err)
 	}
@@ -610,7 +613,7 @@ func testDefaultChannelCheckAPIError(t *testing.T) {
 	mockAPI.SetGetUsersError("API error")
 
 	// Should return error
-	err = runDefaultChannelCheckWithClient(client, 10, 0.9)
+	err = runDefaultChannelCheckWithClient(context.Background(), client, 10, 0.9)
 	if err == nil {
 		t.Error("Expected error, got nil")
 	} else if !strings.Contains(err.Error(), "failed to detect default channels") {
@@ -637,7 +640,7 @@ func testDefaultChannelCheckEmptyRealName(t *testing.T) {
 	mockAPI.AddChannel("C001", "general", time.Now().Add(-30*24*time.Hour), "General")
 
 	// Should run without error
-	err = runDefaultChannelCheckWithClient(client, 10, 1.0)
+	err = runDefaultChannelCheckWithClient(context.Background(), client, 10, 1.0)
 	if err != nil {
 		t.Errorf("Expected no error, got: %v", err)
 	}
@@ -659,7 +662,7 @@ func testDefaultChannelCheckSampleSizes(t *testing.T) {
 
 	// Test with different sample sizes
 	for _, sampleSize := range []int{5, 10, 20} {
-		err = runDefaultChannelCheckWithClient(client, sampleSize, 0.8)
+		err = runDefaultChannelCheckWithClient(context.Background(), client, sampleSize, 0.8)
 		if err != nil {
 			t.Errorf("Expected no error with sample size %d, got: %v", sampleSize, err)
 		}
@@ -682,9 +685,79 @@ func testDefaultChannelCheckThresholds(t *testing.T) {
 
 	// Test with different thresholds
 	for _, threshold := range []float64{0.8, 0.9, 0.95, 1.0} {
-		err = runDefaultChannelCheckWithClient(client, 10, threshold)
+		err = runDefaultChannelCheckWithClient(context.Background(), client, 10, threshold)
 		if err != nil {
 			t.Errorf("Expected no error with threshold %.2f, got: %v", threshold, err)
 		}
 	}
 }
+
+// cancelOnPostAPI cancels the run's context after the first message is posted,
+// simulating Ctrl-C partway through a live archive run.
+type cancelOnPostAPI struct {
+	*slack.MockSlackAPI
+	cancel context.CancelFunc
+}
+
+func (a *cancelOnPostAPI) PostMessage(ctx context.Context, channelID string, options ...slackSDK.MsgOption) (string, string, error) {
+	channel, timestamp, err := a.MockSlackAPI.PostMessage(ctx, channelID, options...)
+	a.cancel()
+	return channel, timestamp, err
+}
+
+func TestArchiveInterrupted(t *testing.T) {
+	t.Run("Interrupted during warnings prints partial results", func(t *testing.T) {
+		mockAPI := slack.NewMockSlackAPI()
+		for i := 1; i <= 3; i++ {
+			id := fmt.Sprintf("C%d", i)
+			mockAPI.AddChannel(id, fmt.Sprintf("stale-%d", i), time.Now().Add(-2*time.Hour), "Stale channel")
+			mockAPI.AddMessageToHistory(id, "old message", "U1234567", fmt.Sprintf("%.6f", float64(time.Now().Add(-35*time.Second).Unix())))
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		client, err := slack.NewClientWithAPI(&cancelOnPostAPI{MockSlackAPI: mockAPI, cancel: cancel})
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+
+		oldStdout := os.Stdout
+		r, w, _ := os.Pipe() //nolint:errcheck
+		os.Stdout = w
+
+		err = runArchiveWithClient(ctx, client, 30, 7, false, "", "", 30.0/86400, 7.0/86400, false, 10, 0.9, false, 0)
+
+		w.Close() //nolint:errcheck
+		os.Stdout = oldStdout
+		output, _ := io.ReadAll(r) //nolint:errcheck
+
+		if err == nil || !strings.Contains(err.Error(), "archive interrupted") {
+			t.Errorf("Expected archive interrupted error, got: %v", err)
+		}
+		if len(mockAPI.GetPostedMessages()) != 1 {
+			t.Errorf("Expected exactly 1 warning before interruption, got %d", len(mockAPI.GetPostedMessages()))
+		}
+		if !strings.Contains(string(output), "Interrupted. Partial results") {
+			t.Errorf("Expected partial results summary, got: %s", output)
+		}
+		if !strings.Contains(string(output), "Warned: 1") || !strings.Contains(string(output), "Not processed: 2") {
+			t.Errorf("Expected 1 warned and 2 not processed, got: %s", output)
+		}
+	})
+
+	t.Run("Interrupted during analysis reports no changes", func(t *testing.T) {
+		mockAPI := slack.NewMockSlackAPI()
+		mockAPI.AddChannel("C1", "stale", time.Now().Add(-2*time.Hour), "Stale channel")
+		client, err := slack.NewClientWithAPI(mockAPI)
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, _, _, err = getInactiveChannelsWithErrorHandling(ctx, client, 30, 7, map[string]string{}, nil, nil, false, false, 0)
+		if err == nil || !strings.Contains(err.Error(), "archive interrupted") {
+			t.Errorf("Expected archive interrupted error, got: %v", err)
+		}
+	})
+}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -48,6 +50,8 @@ The bot will automatically join public channels to send warning messages. Requir
 
 Use --commit to actually warn and archive channels (default is dry run mode).
 
+Use --plan-out plan.json on a dry run to save the planned actions for review, then --apply plan.json --commit to carry out exactly that plan. Channels that changed since the plan was written are skipped.
+
 NOTE: Archive timing is configured in days with decimal precision for flexible control (e.g., 0.0003 days = ~26 seconds).`,
 	SilenceUsage: true, // Don't show usage on errors
 	RunE:         runArchive,
@@ -84,11 +88,29 @@ var (
 	rewarnDays               float64
 	discussionChannel        string
 	includeExtShared         bool
+	includePrivate           bool
+	concurrency              int
+	policyFile               string
+	protectedNames           string
+	excludePatterns          []string
+	excludeFile              string
+	keepReaction             string
+	skipThreads              bool
+	planOut                  string
+	applyPlan                string
+	maxWarn                  int
+	maxArchive               int
+	maxPercent               float64
+	force                    bool
+	requestApproval          string
+	approvers                string
+	approvalReaction         string
+	exportDir                string
 )
 
 // displayWorkspaceInfo gets and displays workspace information.
-func displayWorkspaceInfo(client *slack.Client) error {
-	authInfo, err := client.TestAuth()
+func displayWorkspaceInfo(ctx context.Context, client *slack.Client) error {
+	authInfo, err := client.TestAuth(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get workspace info: %w", err)
 	}
@@ -105,12 +127,15 @@ func init() {
 	detectCmd.Flags().StringVar(&since, "since", "8", "Number of days to look back (e.g., 1, 7, 30)")
 	detectCmd.Flags().StringVar(&announceTo, "announce-to", "", "Channel to announce new channels to (e.g., #general). Required when using --commit")
 	detectCmd.Flags().BoolVar(&commit, "commit", false, "Actually post messages (default is dry run mode)")
+	detectCmd.Flags().BoolVar(&includePrivate, "include-private", false, "Also report new private channels the bot has been invited to (requires groups:read; private channels are never announced)")
 
 	archiveCmd.Flags().Float64Var(&warnDays, "warn-days", 45.0, "Number of days of inactivity before warning (supports decimal precision, e.g., 0.0003)")
 	archiveCmd.Flags().Float64Var(&archiveDays, "archive-days", 30.0, "Number of days after warning (with no new activity) before archiving (supports decimal precision, e.g., 0.0003)")
 	archiveCmd.Flags().BoolVar(&commit, "commit", false, "Actually warn and archive channels (default is dry run mode)")
 	archiveCmd.Flags().StringVar(&excludeChannels, "exclude-channels", "", "Comma-separated list of channel names to exclude (with or without # prefix, e.g., 'general,random,#important')")
 	archiveCmd.Flags().StringVar(&excludePrefixes, "exclude-prefixes", "", "Comma-separated list of channel prefixes to exclude (with or without # prefix, e.g., 'prod-,#temp-,admin')")
+	archiveCmd.Flags().StringArrayVar(&excludePatterns, "exclude-pattern", nil, "Channel name glob (e.g. 'tmp-*') or /regex/ to exclude; repeat for several patterns")
+	archiveCmd.Flags().StringVar(&excludeFile, "exclude-file", "", "File listing channels to exclude, one name, glob or /regex/ per line with optional expires=YYYY-MM-DD and # reason (can also be set via SLACK_EXCLUDE_FILE env var)")
 	archiveCmd.Flags().BoolVar(&includeDefaultChannels, "include-default-channels", false, "Include auto-detected default channels in archival consideration (default: false, meaning default channels are protected)")
 	archiveCmd.Flags().IntVar(&defaultChannelSampleSize, "default-channel-sample-size", 10, "Number of recent users to sample for default channel detection (higher = more accurate but slower)")
 	archiveCmd.Flags().Float64Var(&defaultChannelThreshold, "default-channel-threshold", 0.9, "Membership threshold for default channel detection (0.0-1.0, e.g., 0.9 = 90% of users must share the channel)")
@@ -119,6 +144,25 @@ func init() {
 	archiveCmd.Flags().Float64Var(&rewarnDays, "rewarn-days", 0, "Re-warn channels whose last warning is older than this many days (0 = disabled, no rewarning)")
 	archiveCmd.Flags().StringVar(&discussionChannel, "discussion-channel", slack.DefaultDiscussionChannel, "Channel referenced in warning/archival messages for discussing admin intervention (with or without # prefix). Automatically excluded from archival.")
 	archiveCmd.Flags().BoolVar(&includeExtShared, "include-ext-shared", false, "Include externally shared (Slack Connect) channels in archival consideration (default: false, meaning ext-shared channels are protected)")
+	archiveCmd.Flags().BoolVar(&includePrivate, "include-private", false, "Include private channels the bot has been invited to in archival consideration (requires groups:read, groups:history, groups:write)")
+	archiveCmd.Flags().StringVar(&protectedNames, "protected-names", strings.Join(slack.DefaultProtectedNames, ","), "Comma-separated channel names never archived: exact names, globs (e.g. '*general*') or /regex/; 'none' protects no names and may not be empty (can also be set via SLACK_PROTECTED_NAMES env var)")
+	archiveCmd.Flags().StringVar(&keepReaction, "keep-reaction", slack.DefaultKeepReaction, "Emoji members react to a warning with to vote to keep the channel, restarting its grace period; 'none' ignores reactions (can also be set via SLACK_KEEP_REACTION env var)")
+	archiveCmd.Flags().BoolVar(&skipThreads, "skip-threads", false, "Only count top-level messages as activity, not thread replies (faster, but channels whose discussion happens in threads may be warned; can also be set via SLACK_SKIP_THREADS env var)")
+	archiveCmd.Flags().StringVar(&policyFile, "policy", "", "Policy file (YAML, TOML or JSON) with per-channel rules overriding --warn-days/--archive-days or protecting channels (can also be set via SLACK_POLICY env var)")
+	archiveCmd.Flags().IntVar(&concurrency, "concurrency", slack.DefaultConcurrency, "Number of channels to join and analyze in parallel (all workers share the Slack rate limits)")
+	archiveCmd.Flags().StringVar(&planOut, "plan-out", "", "Write the warnings and archivals this dry run would make, with the evidence for each, to a JSON plan file for review")
+	archiveCmd.Flags().StringVar(&applyPlan, "apply", "", "Carry out a plan file written by --plan-out, skipping channels that changed since (use with --commit to actually act)")
+	archiveCmd.Flags().IntVar(&maxWarn, "max-warn", 0, "Abort a --commit run before changing anything if it would warn more than this many channels (0 = no limit)")
+	archiveCmd.Flags().IntVar(&maxArchive, "max-archive", 0, "Abort a --commit run before changing anything if it would archive more than this many channels (0 = no limit)")
+	archiveCmd.Flags().Float64Var(&maxPercent, "max-percent", 0, "Abort a --commit run before changing anything if it would warn or archive more than this percentage of the channels analyzed (0 = no limit)")
+	archiveCmd.Flags().BoolVar(&force, "force", false, "Proceed with a --commit run that exceeds --max-warn, --max-archive or --max-percent")
+	archiveCmd.Flags().StringVar(&requestApproval, "request-approval", "", "Only archive channels an approver approved: post the channels due for archival to this admin channel (e.g. #admins) and archive those whose item, or the whole post, got the approval reaction on a later run")
+	archiveCmd.Flags().StringVar(&approvers, "approvers", "", "Comma-separated Slack user IDs whose reactions approve archival (required with --request-approval)")
+	archiveCmd.Flags().StringVar(&approvalReaction, "approval-reaction", slack.DefaultApprovalReaction, "Emoji approvers react to an approval request with to approve archiving")
+	archiveCmd.Flags().StringVar(&exportDir, "export-dir", "", "Before archiving a channel, write a JSON and a Markdown transcript of its full history, thread replies included, to this directory; channels that can't be exported aren't archived (can also be set via SLACK_EXPORT_DIR env var)")
+	archiveCmd.MarkFlagsMutuallyExclusive("plan-out", "apply")
+	archiveCmd.MarkFlagsMutuallyExclusive("plan-out", "commit")
+	archiveCmd.MarkFlagsMutuallyExclusive("apply", "default-channel-check")
 
 	highlightCmd.Flags().IntVar(&count, "count", 3, "Number of random channels to highlight (e.g., 1, 3, 5)")
 	highlightCmd.Flags().StringVar(&announceTo, "announce-to", "", "Channel to announce highlights to (e.g., #general). Required when using --commit")
@@ -126,9 +170,9 @@ func init() {
 }
 
 func runDetect(cmd *cobra.Command, args []string) error {
-	token := viper.GetString("token")
-	if token == "" {
-		return fmt.Errorf("slack token is required. Set SLACK_TOKEN environment variable or use --token flag")
+	token, err := requireToken()
+	if err != nil {
+		return err
 	}
 
 	// announce-to is mandatory when committing changes
@@ -146,22 +190,31 @@ func runDetect(cmd *cobra.Command, args []string) error {
 	}
 
 	duration := time.Duration(days*24) * time.Hour
-	cutoffTime := time.Now().Add(-duration)
 
-	client, err := slack.NewClient(token)
+	client, err := newSlackClient(token)
 	if err != nil {
 		return fmt.Errorf("failed to create Slack client: %w", err)
 	}
+	defer closeSlackClient(client)
+	ctx := commandContext(cmd)
+	cutoffTime := client.Now().Add(-duration)
+	client.SetIncludePrivate(resolveBoolConfig(cmd, "include-private", "include_private", includePrivate))
+	if err := requireCommandScopes(ctx, client, "channels detect", commit, client.IncludePrivate()); err != nil {
+		return err
+	}
+	if err := enableAuditLog(ctx, cmd, client, commit); err != nil {
+		return err
+	}
 
 	// Validate that the announce-to channel exists (if specified)
 	if announceTo != "" {
-		_, err = client.ResolveChannelNameToID(announceTo)
+		_, err = client.ResolveChannelNameToID(ctx, announceTo)
 		if err != nil {
 			return fmt.Errorf("announce-to channel '%s' not found: %w", announceTo, err)
 		}
 	}
 
-	return runDetectWithClient(client, cutoffTime, announceTo, !commit)
+	return runDetectWithClient(ctx, client, cutoffTime, announceTo, !commit)
 }
 
 func displayNewChannels(newChannels []slack.Channel) {
@@ -172,6 +225,27 @@ func displayNewChannels(newChannels []slack.Channel) {
 	fmt.Printf("New channels found (%d): %s\n\n", len(newChannels), strings.Join(channelList, ", "))
 }
 
+// splitPrivateChannels separates private channels, which must never be
+// announced publicly, from public ones.
+func splitPrivateChannels(channels []slack.Channel) (public, private []slack.Channel) {
+	for _, channel := range channels {
+		if channel.IsPrivate {
+			private = append(private, channel)
+		} else {
+			public = append(public, channel)
+		}
+	}
+	return public, private
+}
+
+// displayPrivateNewChannels reports new private channels without announcing them.
+func displayPrivateNewChannels(privateChannels []slack.Channel) {
+	if len(privateChannels) == 0 {
+		return
+	}
+	fmt.Printf("🔒 New private channels found (%d, never announced): %s\n\n", len(privateChannels), strings.Join(addHashPrefix(extractChannelNames(privateChannels)), ", "))
+}
+
 func extractChannelNames(channels []slack.Channel) []string {
 	channelNames := make([]string, len(channels))
 	for i, channel := range channels {
@@ -180,37 +254,44 @@ func extractChannelNames(channels []slack.Channel) []string {
 	return channelNames
 }
 
-func runDetectWithClient(client *slack.Client, cutoffTime time.Time, announceChannel string, isDryRun bool) error {
+func runDetectWithClient(ctx context.Context, client *slack.Client, cutoffTime time.Time, announceChannel string, isDryRun bool) error {
 	// Get and display workspace info
-	if err := displayWorkspaceInfo(client); err != nil {
+	if err := displayWorkspaceInfo(ctx, client); err != nil {
 		return err
 	}
 
-	newChannels, allChannels, err := client.GetNewChannelsWithAllChannels(cutoffTime)
+	newChannels, allChannels, err := client.GetNewChannelsWithAllChannels(ctx, cutoffTime)
 	if err != nil {
 		return fmt.Errorf("failed to get new channels: %w", err)
 	}
 
 	if len(newChannels) == 0 {
-		fmt.Printf("No new channels found in the last %s.\n", formatTimeRange(cutoffTime))
+		fmt.Printf("No new channels found in the last %s.\n", formatTimeRange(cutoffTime, client.Now()))
+		return nil
+	}
+
+	newChannels, privateChannels := splitPrivateChannels(newChannels)
+	displayPrivateNewChannels(privateChannels)
+	if len(newChannels) == 0 {
+		fmt.Printf("No new public channels to announce.\n")
 		return nil
 	}
 
 	displayNewChannels(newChannels)
 
 	if announceChannel != "" {
-		return handleAnnouncement(client, newChannels, allChannels, cutoffTime, announceChannel, isDryRun)
+		return handleAnnouncement(ctx, client, newChannels, allChannels, cutoffTime, announceChannel, isDryRun)
 	}
 
-	return handleDryRunWithoutChannel(client, newChannels, cutoffTime, isDryRun)
+	return handleDryRunWithoutChannel(ctx, client, newChannels, cutoffTime, isDryRun)
 }
 
-func handleAnnouncement(client *slack.Client, newChannels []slack.Channel, allChannels []slackapi.Channel, cutoffTime time.Time, announceChannel string, isDryRun bool) error {
+func handleAnnouncement(ctx context.Context, client *slack.Client, newChannels []slack.Channel, allChannels []slackapi.Channel, cutoffTime time.Time, announceChannel string, isDryRun bool) error {
 	message := client.FormatNewChannelAnnouncement(newChannels, cutoffTime)
 	channelNames := extractChannelNames(newChannels)
 
 	fmt.Printf("Checking for duplicate announcements in %s...\n\n", announceChannel)
-	isDuplicate, skippedChannels, err := client.CheckForDuplicateAnnouncementWithDetailsAndChannels(announceChannel, message, channelNames, cutoffTime, allChannels)
+	isDuplicate, skippedChannels, err := client.CheckForDuplicateAnnouncementWithDetailsAndChannels(ctx, announceChannel, message, channelNames, cutoffTime, allChannels)
 	if err != nil {
 		logger.WithFields(logger.LogFields{
 			"channel": announceChannel,
@@ -244,7 +325,7 @@ func handleAnnouncement(client *slack.Client, newChannels []slack.Channel, allCh
 		finalMessage = message
 	}
 
-	return postOrPreviewAnnouncement(client, announceChannel, finalMessage, channelsToAnnounce, cutoffTime, isDryRun)
+	return postOrPreviewAnnouncement(ctx, client, announceChannel, finalMessage, channelsToAnnounce, cutoffTime, isDryRun)
 }
 
 func filterSkippedChannels(channelNames []string, skippedChannels []string) []string {
@@ -285,16 +366,16 @@ func displayAnnouncingChannels(channelNames []string, skippedCount int) {
 	fmt.Printf("Announcing channels: %s (skipped %d already announced)\n", strings.Join(announcingList, ", "), skippedCount)
 }
 
-func postOrPreviewAnnouncement(client *slack.Client, announceChannel, finalMessage string, channelsToAnnounce []slack.Channel, cutoffTime time.Time, isDryRun bool) error {
+func postOrPreviewAnnouncement(ctx context.Context, client *slack.Client, announceChannel, finalMessage string, channelsToAnnounce []slack.Channel, cutoffTime time.Time, isDryRun bool) error {
 	if isDryRun {
-		dryRunMessage := client.FormatNewChannelAnnouncementDryRun(channelsToAnnounce, cutoffTime)
+		dryRunMessage := client.FormatNewChannelAnnouncementDryRun(ctx, channelsToAnnounce, cutoffTime)
 		fmt.Printf("\n--- DRY RUN ---\n")
 		fmt.Printf("Would announce to channel: %s\n", announceChannel)
 		fmt.Printf("Message content:\n%s\n", dryRunMessage)
 		fmt.Printf("--- END DRY RUN ---\n")
 		fmt.Printf("\nTo actually post this announcement, add --commit to your command\n")
 	} else {
-		if err := client.PostMessage(announceChannel, finalMessage); err != nil {
+		if err := client.PostMessage(ctx, announceChannel, finalMessage); err != nil {
 			logger.WithFields(logger.LogFields{
 				"channel": announceChannel,
 				"error":   err.Error(),
@@ -306,9 +387,9 @@ func postOrPreviewAnnouncement(client *slack.Client, announceChannel, finalMessa
 	return nil
 }
 
-func handleDryRunWithoutChannel(client *slack.Client, newChannels []slack.Channel, cutoffTime time.Time, isDryRun bool) error {
+func handleDryRunWithoutChannel(ctx context.Context, client *slack.Client, newChannels []slack.Channel, cutoffTime time.Time, isDryRun bool) error {
 	if isDryRun {
-		message := client.FormatNewChannelAnnouncementDryRun(newChannels, cutoffTime)
+		message := client.FormatNewChannelAnnouncementDryRun(ctx, newChannels, cutoffTime)
 		fmt.Printf("\n--- DRY RUN ---\n")
 		fmt.Printf("Announcement message dry run (use --announce-to to specify target):\n%s\n", message)
 		fmt.Printf("--- END DRY RUN ---\n")
@@ -318,22 +399,38 @@ func handleDryRunWithoutChannel(client *slack.Client, newChannels []slack.Channe
 }
 
 func runArchive(cmd *cobra.Command, args []string) error {
-	token := viper.GetString("token")
-	if token == "" {
-		return fmt.Errorf("slack token is required. Set SLACK_TOKEN environment variable or use --token flag")
+	token, err := requireToken()
+	if err != nil {
+		return err
 	}
 
-	// In warn-only mode, archive-days doesn't matter, so skip its validation
-	if err := validateArchiveDays(warnDays, archiveDays, warnOnly); err != nil {
+	if err := validateArchiveFlags(); err != nil {
 		return err
 	}
 
-	// Validate rewarn-days if set
-	if rewarnDays < 0 {
-		return fmt.Errorf("rewarn-days must be non-negative, got %g", rewarnDays)
+	includeDefaultsValue, sampleSizeValue, thresholdValue, discussionChannelValue, includeExtSharedValue, err := resolveArchiveConfig(cmd)
+	if err != nil {
+		return err
 	}
 
-	includeDefaultsValue, sampleSizeValue, thresholdValue, discussionChannelValue, includeExtSharedValue, err := resolveArchiveConfig(cmd)
+	concurrencyValue := resolvePositiveIntConfig(cmd, "concurrency", "concurrency", concurrency)
+	if concurrencyValue < 1 {
+		return fmt.Errorf("concurrency must be at least 1, got %d", concurrencyValue)
+	}
+
+	var policy *slack.ArchivePolicy
+	if path := resolveStringConfig(cmd, "policy", "policy", policyFile); path != "" {
+		if policy, err = loadArchivePolicy(path); err != nil {
+			return err
+		}
+	}
+
+	protected, err := parseProtectedNames(resolveStringConfig(cmd, "protected-names", "protected_names", protectedNames))
+	if err != nil {
+		return err
+	}
+
+	exclusions, err := buildExclusions(excludePatterns, resolveStringConfig(cmd, "exclude-file", "exclude_file", excludeFile))
 	if err != nil {
 		return err
 	}
@@ -343,19 +440,57 @@ func runArchive(cmd *cobra.Command, args []string) error {
 	archiveSeconds := int(archiveDays * 24 * 60 * 60)
 	rewarnSeconds := int(rewarnDays * 24 * 60 * 60)
 
-	client, err := slack.NewClient(token)
+	client, err := newSlackClient(token)
 	if err != nil {
 		return fmt.Errorf("failed to create Slack client: %w", err)
 	}
+	defer closeSlackClient(client)
+	ctx := commandContext(cmd)
 	client.SetDiscussionChannel(discussionChannelValue)
 	client.SetIncludeExtShared(includeExtSharedValue)
+	client.SetIncludePrivate(resolveBoolConfig(cmd, "include-private", "include_private", includePrivate))
+	client.SetConcurrency(concurrencyValue)
+	client.SetArchivePolicy(policy)
+	client.SetProtectedNames(protected)
+	client.SetExclusions(exclusions)
+	client.SetKeepReaction(parseKeepReaction(resolveStringConfig(cmd, "keep-reaction", "keep_reaction", keepReaction)))
+	client.SetSkipThreads(resolveBoolConfig(cmd, "skip-threads", "skip_threads", skipThreads))
+	client.SetExportDir(resolveStringConfig(cmd, "export-dir", "export_dir", exportDir))
+	if err := enableAuditLog(ctx, cmd, client, commit); err != nil {
+		return err
+	}
+
+	if applyPlan != "" {
+		return runApplyPlan(ctx, client, applyPlan, !commit)
+	}
 
 	// If --default-channel-check flag is set, run diagnostic mode
 	if defaultChannelCheck {
-		return runDefaultChannelCheckWithClient(client, sampleSizeValue, thresholdValue)
+		return runDefaultChannelCheckWithClient(ctx, client, sampleSizeValue, thresholdValue)
+	}
+	if err := requireCommandScopes(ctx, client, "channels archive", commit, client.IncludePrivate()); err != nil {
+		return err
+	}
+
+	return runArchiveWithClient(ctx, client, warnSeconds, archiveSeconds, !commit, excludeChannels, excludePrefixes, warnDays, archiveDays, includeDefaultsValue, sampleSizeValue, thresholdValue, warnOnly, rewarnSeconds)
+}
+
+// validateArchiveFlags validates the archive thresholds and safety limits.
+func validateArchiveFlags() error {
+	// In warn-only mode, archive-days doesn't matter, so skip its validation
+	if err := validateArchiveDays(warnDays, archiveDays, warnOnly); err != nil {
+		return err
+	}
+
+	// Validate rewarn-days if set
+	if rewarnDays < 0 {
+		return fmt.Errorf("rewarn-days must be non-negative, got %g", rewarnDays)
 	}
 
-	return runArchiveWithClient(client, warnSeconds, archiveSeconds, !commit, excludeChannels, excludePrefixes, warnDays, archiveDays, includeDefaultsValue, sampleSizeValue, thresholdValue, warnOnly, rewarnSeconds)
+	if err := currentBlastRadiusLimits().validate(); err != nil {
+		return err
+	}
+	return currentApprovalSettings().validate()
 }
 
 // validateArchiveDays validates warn and archive days are positive.
@@ -389,9 +524,9 @@ func resolveArchiveConfig(cmd *cobra.Command) (includeDefaults bool, sampleSize
 	return includeDefaults, sampleSize, threshold, discussionChannelValue, includeExtSharedValue, nil
 }
 
-// resolveBoolConfig returns flagValue when the flag was explicitly set or cmd is nil; otherwise falls back to the env-bound viper key.
+// resolveBoolConfig returns flagValue when the flag was explicitly set or cmd is nil; otherwise uses the env-bound viper key when set, else flagValue (which holds any config file value).
 func resolveBoolConfig(cmd *cobra.Command, flagName, viperKey string, flagValue bool) bool {
-	if cmd == nil || cmd.Flags().Changed(flagName) {
+	if cmd == nil || cmd.Flags().Changed(flagName) || !viper.IsSet(viperKey) {
 		return flagValue
 	}
 	return viper.GetBool(viperKey)
@@ -419,6 +554,17 @@ func resolvePositiveFloatConfig(cmd *cobra.Command, flagName, viperKey string, f
 	return flagValue
 }
 
+// resolveStringConfig prefers an explicitly-set flag (or flagValue when cmd is nil); otherwise uses the env-bound string when non-empty, else flagValue.
+func resolveStringConfig(cmd *cobra.Command, flagName, viperKey, flagValue string) string {
+	if cmd == nil || cmd.Flags().Changed(flagName) {
+		return flagValue
+	}
+	if env := viper.GetString(viperKey); env != "" {
+		return env
+	}
+	return flagValue
+}
+
 // resolveDiscussionChannelConfig resolves the discussion channel from flag/env, normalizes it, and applies the default.
 func resolveDiscussionChannelConfig(cmd *cobra.Command) string {
 	value := discussionChannel
@@ -434,14 +580,14 @@ func resolveDiscussionChannelConfig(cmd *cobra.Command) string {
 	return value
 }
 
-func runArchiveWithClient(client *slack.Client, warnSeconds, archiveSeconds int, isDryRun bool, excludeChannels, excludePrefixes string, warnDays, archiveDays float64, includeDefaults bool, sampleSize int, threshold float64, warnOnlyMode bool, rewarnSeconds int) error {
+func runArchiveWithClient(ctx context.Context, client *slack.Client, warnSeconds, archiveSeconds int, isDryRun bool, excludeChannels, excludePrefixes string, warnDays, archiveDays float64, includeDefaults bool, sampleSize int, threshold float64, warnOnlyMode bool, rewarnSeconds int) error {
 	// Validate client
 	if client == nil {
 		return fmt.Errorf("client cannot be nil")
 	}
 
 	// Get and display workspace info
-	if err := displayWorkspaceInfo(client); err != nil {
+	if err := displayWorkspaceInfo(ctx, client); err != nil {
 		return err
 	}
 
@@ -480,13 +626,16 @@ func runArchiveWithClient(client *slack.Client, warnSeconds, archiveSeconds int,
 
 	fmt.Printf("🔍 Analyzing inactive channels...\n\n")
 
+	displayArchivePolicy(client.ArchivePolicy(), warnDays, archiveDays, warnOnlyMode)
 	displayExtSharedProtectionStatus(client.IncludeExtShared())
+	displayPrivateChannelStatus(client.IncludePrivate())
+	displayThreadActivityStatus(client.SkipThreads())
 
 	// Detect default channels unless explicitly included
-	defaultChannels := detectAndDisplayDefaultChannels(client, includeDefaults, sampleSize, threshold)
+	defaultChannels := detectAndDisplayDefaultChannels(ctx, client, includeDefaults, sampleSize, threshold)
 
 	// Get user map for name resolution
-	userMap, err := getUserMapWithErrorHandling(client, isDebug)
+	userMap, err := getUserMapWithErrorHandling(ctx, client, isDebug)
 	if err != nil {
 		return err
 	}
@@ -501,14 +650,39 @@ func runArchiveWithClient(client *slack.Client, warnSeconds, archiveSeconds int,
 	discussionName := client.DiscussionChannel()
 	excludeChannelsList = mergeChannelLists(excludeChannelsList, []string{discussionName})
 
-	displayExclusionInfo(excludeChannelsList, excludePrefixesList, defaultChannels, discussionName)
+	activePatterns, expiredPatterns := activeExclusions(client.Exclusions(), client.Now())
+	displayExclusionInfo(excludeChannelsList, excludePrefixesList, defaultChannels, discussionName, client.ProtectedNames().Patterns(), activePatterns)
+	displayExpiredExclusions(expiredPatterns)
 
 	// Analyze inactive channels
-	toWarn, toArchive, totalChannels, err := getInactiveChannelsWithErrorHandling(client, warnSeconds, archiveSeconds, userMap, excludeChannelsList, excludePrefixesList, isDebug, warnOnlyMode, rewarnSeconds)
+	toWarn, toArchive, totalChannels, err := getInactiveChannelsWithErrorHandling(ctx, client, warnSeconds, archiveSeconds, userMap, excludeChannelsList, excludePrefixesList, isDebug, warnOnlyMode, rewarnSeconds)
 	if err != nil {
 		return err
 	}
 
+	// Only approved channels are archived when archival needs approval
+	gate, err := gateArchivalOnApproval(ctx, client, currentApprovalSettings(), toArchive, userMap, warnOnlyMode)
+	if err != nil {
+		return err
+	}
+	toArchive = gate.archive
+
+	if err := processArchiveFindings(ctx, client, toWarn, toArchive, warnSeconds, archiveSeconds, isDryRun, totalChannels, warnOnlyMode); err != nil {
+		return err
+	}
+	if err := gate.request(ctx, client, isDryRun); err != nil {
+		return err
+	}
+	if planOut != "" && isDryRun {
+		return writePlanFromAnalysis(ctx, client, planOut, toWarn, toArchive, warnSeconds, archiveSeconds, totalChannels, warnOnlyMode)
+	}
+	return nil
+}
+
+// processArchiveFindings reports the analysis results and then warns and
+// archives channels. If the run is interrupted it prints a summary of what
+// was already done and returns an error.
+func processArchiveFindings(ctx context.Context, client *slack.Client, toWarn, toArchive []slack.Channel, warnSeconds, archiveSeconds int, isDryRun bool, totalChannels int, warnOnlyMode bool) error {
 	// Report findings
 	if warnOnlyMode {
 		fmt.Printf("Inactive Channel Analysis Results (warn-only mode):\n")
@@ -518,17 +692,40 @@ func runArchiveWithClient(client *slack.Client, warnSeconds, archiveSeconds int,
 		fmt.Printf("Inactive Channel Analysis Results:\n")
 		fmt.Printf("  Channels to warn: %d\n", len(toWarn))
 		fmt.Printf("  Channels to archive: %d\n", len(toArchive))
+		if kept := keptByVote(toWarn); len(kept) > 0 {
+			fmt.Printf("  Kept by member vote (warned again): %d (%s)\n", len(kept), strings.Join(addHashPrefix(kept), ", "))
+		}
 		fmt.Println()
 	}
 
+	// Abort before any warning or archival if the run is bigger than allowed
+	plannedArchive := toArchive
+	if warnOnlyMode {
+		plannedArchive = nil
+	}
+	if err := checkBlastRadius(currentBlastRadiusLimits(), toWarn, plannedArchive, totalChannels, isDryRun, client.Now()); err != nil {
+		return err
+	}
+
+	results := &archiveRunResults{}
+
 	// Process warnings
 	if len(toWarn) > 0 {
-		processWarnings(client, toWarn, warnSeconds, archiveSeconds, isDryRun, totalChannels, warnOnlyMode)
+		processWarnings(ctx, client, toWarn, warnSeconds, archiveSeconds, isDryRun, totalChannels, warnOnlyMode, results)
 	}
 
-	// Process archival (skip in warn-only mode)
+	// Process archival (skip in warn-only mode, and once interrupted)
 	if !warnOnlyMode && len(toArchive) > 0 {
-		processArchival(client, toArchive, warnSeconds, archiveSeconds, isDryRun, totalChannels)
+		if ctx.Err() != nil {
+			results.markNotProcessed(toArchive)
+		} else {
+			processArchival(ctx, client, toArchive, warnSeconds, archiveSeconds, isDryRun, totalChannels, results)
+		}
+	}
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		displayInterruptedSummary(results)
+		return fmt.Errorf("archive interrupted: %w", ctxErr)
 	}
 
 	if len(toWarn) == 0 && len(toArchive) == 0 {
@@ -538,23 +735,67 @@ func runArchiveWithClient(client *slack.Client, warnSeconds, archiveSeconds int,
 	return nil
 }
 
+// keptByVote returns the names of the channels whose members voted to keep
+// them.
+func keptByVote(channels []slack.Channel) []string {
+	var kept []string
+	for _, channel := range channels {
+		if len(channel.KeepVoters) > 0 {
+			kept = append(kept, channel.Name)
+		}
+	}
+	return kept
+}
+
+// archiveRunResults records what a live archive run actually did, so an
+// interrupted run can report which channels were already warned or archived.
+type archiveRunResults struct {
+	warned       []string
+	archived     []string
+	failed       []string
+	notProcessed []string
+}
+
+// markNotProcessed records channels that were never attempted.
+func (r *archiveRunResults) markNotProcessed(channels []slack.Channel) {
+	r.notProcessed = append(r.notProcessed, extractChannelNames(channels)...)
+}
+
+// displayInterruptedSummary prints the partial results of an interrupted archive run.
+func displayInterruptedSummary(results *archiveRunResults) {
+	fmt.Printf("\n⚠️  Interrupted. Partial results:\n")
+	displaySummaryLine("Warned", results.warned)
+	displaySummaryLine("Archived", results.archived)
+	displaySummaryLine("Failed", results.failed)
+	displaySummaryLine("Not processed", results.notProcessed)
+	fmt.Println()
+}
+
+func displaySummaryLine(label string, names []string) {
+	if len(names) == 0 {
+		fmt.Printf("  %s: 0\n", label)
+		return
+	}
+	fmt.Printf("  %s: %d (%s)\n", label, len(names), strings.Join(addHashPrefix(names), ", "))
+}
+
 // getUserMapWithErrorHandling gets user map with proper error handling and logging.
-func getUserMapWithErrorHandling(client *slack.Client, isDebug bool) (map[string]string, error) {
+func getUserMapWithErrorHandling(ctx context.Context, client *slack.Client, isDebug bool) (map[string]string, error) {
 	if isDebug {
 		fmt.Printf("📞 API Call 1: Getting user list for name resolution...\n")
 	}
-	userMap, err := client.GetUserMap()
+	userMap, err := client.GetUserMap(ctx)
 	if err != nil {
-		if strings.Contains(err.Error(), "rate_limited") || strings.Contains(err.Error(), "rate limit") {
+		if errors.Is(err, slack.ErrRateLimited) {
 			fmt.Printf("⚠️  Slack API rate limit exceeded on user list.\n")
 			fmt.Printf("   The system should have done backoff.\n")
-			return nil, fmt.Errorf("rate limited by Slack API")
+			return nil, fmt.Errorf("rate limited by Slack API: %w", err)
 		}
-		if strings.Contains(err.Error(), "missing_scope") || strings.Contains(err.Error(), "users:read") {
+		if errors.Is(err, slack.ErrMissingScope) {
 			fmt.Printf("❌ Missing required OAuth scope 'users:read'\n")
 			fmt.Printf("   This scope is needed to resolve user names for message authors.\n")
 			fmt.Printf("   Add 'users:read' scope in your Slack app settings at https://api.slack.com/apps\n")
-			return nil, fmt.Errorf("missing required OAuth scope 'users:read'")
+			return nil, err
 		}
 		return nil, fmt.Errorf("failed to get users: %w", err)
 	}
@@ -610,9 +851,59 @@ func separateManualExclusions(excludeChannelsList, defaultChannels []string) []s
 	return manualExclusions
 }
 
+// parseProtectedNames parses the comma-separated --protected-names value;
+// "none" protects no channel names. An empty value is an error rather than
+// a silent way to disable protection.
+func parseProtectedNames(value string) (*slack.ProtectedNames, error) {
+	if strings.EqualFold(strings.TrimSpace(value), "none") {
+		return slack.NewProtectedNames(nil)
+	}
+	patterns := splitNamePatterns(value)
+	if last := len(patterns) - 1; isOpenRegex(patterns[last]) {
+		return nil, fmt.Errorf("invalid --protected-names: unterminated regex %s", strings.TrimSpace(patterns[last]))
+	}
+	names, err := slack.NewProtectedNames(patterns)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --protected-names: %w", err)
+	}
+	if len(names.Patterns()) == 0 {
+		return nil, fmt.Errorf("invalid --protected-names: no names given, use 'none' to protect no names")
+	}
+	return names, nil
+}
+
+// splitNamePatterns splits a comma-separated list of names, globs and
+// /regex/ patterns, keeping commas inside a /regex/ such as /^ops-\d{2,3}$/.
+func splitNamePatterns(value string) []string {
+	var patterns []string
+	for _, part := range strings.Split(value, ",") {
+		if last := len(patterns) - 1; last >= 0 && isOpenRegex(patterns[last]) {
+			patterns[last] += "," + part
+			continue
+		}
+		patterns = append(patterns, part)
+	}
+	return patterns
+}
+
+// isOpenRegex reports whether pattern starts a /regex/ that isn't closed yet.
+func isOpenRegex(pattern string) bool {
+	pattern = strings.TrimSpace(pattern)
+	return strings.HasPrefix(pattern, "/") && (len(pattern) == 1 || !strings.HasSuffix(pattern, "/"))
+}
+
+// parseKeepReaction parses the --keep-reaction value; "none" ignores
+// reactions.
+func parseKeepReaction(value string) string {
+	if strings.EqualFold(strings.TrimSpace(value), "none") {
+		return ""
+	}
+	return value
+}
+
 // displayExclusionInfo shows configured exclusions to the user.
-func displayExclusionInfo(excludeChannelsList, excludePrefixesList, defaultChannels []string, discussionChannelName string) {
-	if len(excludeChannelsList) == 0 && len(excludePrefixesList) == 0 {
+func displayExclusionInfo(excludeChannelsList, excludePrefixesList, defaultChannels []string, discussionChannelName string, protectedNames []string, exclusions []slack.Exclusion) {
+	if len(excludeChannelsList) == 0 && len(excludePrefixesList) == 0 && len(protectedNames) == 0 && len(exclusions) == 0 {
 		return
 	}
 
@@ -638,6 +929,18 @@ func displayExclusionInfo(excludeChannelsList, excludePrefixesList, defaultChann
 		fmt.Printf("   Excluded prefixes: %s\n", strings.Join(excludePrefixesList, ", "))
 	}
 
+	if len(exclusions) > 0 {
+		patterns := make([]string, len(exclusions))
+		for i, exclusion := range exclusions {
+			patterns[i] = formatExclusion(exclusion)
+		}
+		fmt.Printf("   Excluded patterns: %s\n", strings.Join(patterns, ", "))
+	}
+
+	if len(protectedNames) > 0 {
+		fmt.Printf("   Protected names (--protected-names): %s\n", strings.Join(protectedNames, ", "))
+	}
+
 	fmt.Println()
 }
 
@@ -673,17 +976,21 @@ func addHashPrefix(channels []string) []string {
 }
 
 // getInactiveChannelsWithErrorHandling analyzes inactive channels with proper error handling.
-func getInactiveChannelsWithErrorHandling(client *slack.Client, warnSeconds, archiveSeconds int, userMap map[string]string, excludeChannelsList, excludePrefixesList []string, isDebug bool, warnOnlyMode bool, rewarnSeconds int) ([]slack.Channel, []slack.Channel, int, error) {
-	toWarn, toArchive, totalChannels, err := client.GetInactiveChannelsWithDetailsAndExclusions(warnSeconds, archiveSeconds, userMap, excludeChannelsList, excludePrefixesList, isDebug, warnOnlyMode, rewarnSeconds)
+func getInactiveChannelsWithErrorHandling(ctx context.Context, client *slack.Client, warnSeconds, archiveSeconds int, userMap map[string]string, excludeChannelsList, excludePrefixesList []string, isDebug bool, warnOnlyMode bool, rewarnSeconds int) ([]slack.Channel, []slack.Channel, int, error) {
+	toWarn, toArchive, totalChannels, err := client.GetInactiveChannelsWithDetailsAndExclusions(ctx, warnSeconds, archiveSeconds, userMap, excludeChannelsList, excludePrefixesList, isDebug, warnOnlyMode, rewarnSeconds)
 	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			fmt.Printf("\n⚠️  Interrupted during analysis. No channels were warned or archived.\n")
+			return nil, nil, 0, fmt.Errorf("archive interrupted: %w", ctxErr)
+		}
 		// Check if this is a rate limit error and provide helpful guidance
-		if strings.Contains(err.Error(), "rate_limited") || strings.Contains(err.Error(), "rate limit") {
+		if errors.Is(err, slack.ErrRateLimited) {
 			fmt.Printf("⚠️  Slack API rate limit exceeded.\n")
 			fmt.Printf("   The analysis was stopped to respect API limits.\n")
 			fmt.Printf("   Please wait a few minutes before running the command again.\n")
 			fmt.Printf("   \n")
 			fmt.Printf("   Tip: Consider running with longer time periods (e.g. --warn-days=30) to reduce API calls.\n")
-			return nil, nil, 0, fmt.Errorf("rate limited by Slack API")
+			return nil, nil, 0, fmt.Errorf("rate limited by Slack API: %w", err)
 		}
 		return nil, nil, 0, fmt.Errorf("failed to analyze inactive channels: %w", err)
 	}
@@ -701,8 +1008,23 @@ func displayExtSharedProtectionStatus(includeExtShared bool) {
 	fmt.Printf("   Use --include-ext-shared to override this protection.\n\n")
 }
 
+// displayPrivateChannelStatus reports when private channels are included.
+func displayPrivateChannelStatus(includePrivate bool) {
+	if !includePrivate {
+		return
+	}
+	fmt.Printf("🔒 Private channels the bot has been invited to are INCLUDED (--include-private flag set)\n\n")
+}
+
+func displayThreadActivityStatus(skipThreads bool) {
+	if !skipThreads {
+		return
+	}
+	fmt.Printf("🧵 Thread replies are IGNORED, only top-level messages count as activity (--skip-threads flag set)\n\n")
+}
+
 // detectAndDisplayDefaultChannels detects default channels and displays results to user.
-func detectAndDisplayDefaultChannels(client *slack.Client, includeDefaults bool, sampleSize int, threshold float64) []string {
+func detectAndDisplayDefaultChannels(ctx context.Context, client *slack.Client, includeDefaults bool, sampleSize int, threshold float64) []string {
 	if includeDefaults {
 		fmt.Printf("⚠️  Default channel protection DISABLED (--include-default-channels flag set)\n")
 		fmt.Printf("   Auto-detected default channels will NOT be protected from archival.\n\n")
@@ -710,7 +1032,7 @@ func detectAndDisplayDefaultChannels(client *slack.Client, includeDefaults bool,
 	}
 
 	fmt.Printf("🔍 Detecting default channels (sampling %d recent users, %.0f%% threshold)...\n", sampleSize, threshold*100)
-	detectedDefaults, err := client.GetDefaultChannels(sampleSize, threshold)
+	detectedDefaults, err := client.GetDefaultChannels(ctx, sampleSize, threshold)
 	if err != nil {
 		logger.WithField("error", err.Error()).Warn("Failed to detect default channels, continuing without automatic exclusions")
 		fmt.Printf("⚠️  Warning: Could not detect default channels: %v\n", err)
@@ -729,23 +1051,31 @@ func detectAndDisplayDefaultChannels(client *slack.Client, includeDefaults bool,
 	return detectedDefaults
 }
 
-// displayChannelDetails shows channel information with last message details.
-func displayChannelDetails(channels []slack.Channel, title string) {
+// displayChannelDetails shows channel information with last message details,
+// counting days of inactivity up to now.
+func displayChannelDetails(channels []slack.Channel, title string, now time.Time) {
 	fmt.Printf("%s:\n", title)
 	for _, channel := range channels {
 		// Calculate days of inactivity
-		daysSinceActive := int(time.Since(channel.LastActivity).Hours() / 24)
+		daysSinceActive := int(now.Sub(channel.LastActivity).Hours() / 24)
 		daysText := "days"
 		if daysSinceActive == 1 {
 			daysText = "day"
 		}
 
-		fmt.Printf("  #%s (inactive since: %s, %d %s ago, members: %d)\n",
+		privateText := ""
+		if channel.IsPrivate {
+			privateText = " [private]"
+		}
+
+		fmt.Printf("  #%s%s (inactive since: %s, %d %s ago, members: %d)%s\n",
 			channel.Name,
+			privateText,
 			channel.LastActivity.Format("2006-01-02 15:04:05"),
 			daysSinceActive,
 			daysText,
-			channel.MemberCount)
+			channel.MemberCount,
+			formatPolicyMatch(channel))
 
 		// Show last message details if available
 		if channel.LastMessage != nil {
@@ -767,18 +1097,22 @@ func displayChannelDetails(channels []slack.Channel, title string) {
 
 			fmt.Printf("    └─ Last message by: %s%s | \"%s\"\n", authorName, botIndicator, messageText)
 		}
+
+		if len(channel.KeepVoters) > 0 {
+			fmt.Printf("    └─ Voted to keep by: %s (grace period restarts)\n", strings.Join(channel.KeepVoters, ", "))
+		}
 	}
 	fmt.Println()
 }
 
 // processWarnings handles warning channels in both dry-run and real modes.
-func processWarnings(client *slack.Client, toWarn []slack.Channel, warnSeconds, archiveSeconds int, isDryRun bool, totalChannels int, warnOnlyMode bool) {
-	displayChannelDetails(toWarn, "Channels to warn about inactivity")
+func processWarnings(ctx context.Context, client *slack.Client, toWarn []slack.Channel, warnSeconds, archiveSeconds int, isDryRun bool, totalChannels int, warnOnlyMode bool, results *archiveRunResults) {
+	displayChannelDetails(toWarn, "Channels to warn about inactivity", client.Now())
 
 	if isDryRun {
 		processWarningsDryRun(client, toWarn, warnSeconds, archiveSeconds, totalChannels, warnOnlyMode)
 	} else {
-		processWarningsReal(client, toWarn, warnSeconds, archiveSeconds, warnOnlyMode)
+		processWarningsReal(ctx, client, toWarn, warnSeconds, archiveSeconds, warnOnlyMode, results)
 	}
 }
 
@@ -803,28 +1137,25 @@ func processWarningsDryRun(client *slack.Client, toWarn []slack.Channel, warnSec
 	fmt.Printf("--- END DRY RUN ---\n\n")
 }
 
-// processWarningsReal handles the actual warning sending.
-func processWarningsReal(client *slack.Client, toWarn []slack.Channel, warnSeconds, archiveSeconds int, warnOnlyMode bool) {
+// processWarningsReal handles the actual warning sending. It stops early,
+// leaving the remaining channels untouched, once ctx is cancelled.
+func processWarningsReal(ctx context.Context, client *slack.Client, toWarn []slack.Channel, warnSeconds, archiveSeconds int, warnOnlyMode bool, results *archiveRunResults) {
 	fmt.Printf("Sending warnings to %d channels (joining channels as needed)...\n", len(toWarn))
 
 	// Look up the configured discussion channel ID once for all warnings to reduce API calls
-	discussionName := client.DiscussionChannel()
-	discussionChannelID, err := client.ResolveChannelNameToID(discussionName)
-	if err != nil {
-		logger.WithFields(logger.LogFields{
-			"discussion_channel": discussionName,
-			"error":              err.Error(),
-		}).Debug("Could not find discussion channel for linking")
-		discussionChannelID = "" // Fall back to plain text mention
-	}
+	discussionChannelID := resolveDiscussionChannelID(ctx, client)
 
 	warningsSent := 0
-	for _, channel := range toWarn {
+	for i, channel := range toWarn {
+		if ctx.Err() != nil {
+			results.markNotProcessed(toWarn[i:])
+			break
+		}
 		var warnErr error
 		if warnOnlyMode {
-			warnErr = client.WarnInactiveChannelWarnOnly(channel, warnSeconds, archiveSeconds, discussionChannelID)
+			warnErr = client.WarnInactiveChannelWarnOnly(ctx, channel, warnSeconds, archiveSeconds, discussionChannelID)
 		} else {
-			warnErr = client.WarnInactiveChannel(channel, warnSeconds, archiveSeconds, discussionChannelID)
+			warnErr = client.WarnInactiveChannel(ctx, channel, warnSeconds, archiveSeconds, discussionChannelID)
 		}
 		if warnErr != nil {
 			logger.WithFields(logger.LogFields{
@@ -832,8 +1163,10 @@ func processWarningsReal(client *slack.Client, toWarn []slack.Channel, warnSecon
 				"error":   warnErr.Error(),
 			}).Error("Failed to send warning")
 			fmt.Printf("  Failed to warn #%s: %s\n", channel.Name, warnErr.Error())
+			results.failed = append(results.failed, channel.Name)
 		} else {
 			warningsSent++
+			results.warned = append(results.warned, channel.Name)
 			logger.WithField("channel", channel.Name).Info("Warning sent successfully")
 			fmt.Printf("  ✓ Warned #%s\n", channel.Name)
 		}
@@ -841,9 +1174,38 @@ func processWarningsReal(client *slack.Client, toWarn []slack.Channel, warnSecon
 	fmt.Printf("Warnings sent: %d/%d\n\n", warningsSent, len(toWarn))
 }
 
+// resolveDiscussionChannelID returns the ID of the configured discussion
+// channel for linking in warnings, or "" to fall back to a plain mention.
+func resolveDiscussionChannelID(ctx context.Context, client *slack.Client) string {
+	discussionName := client.DiscussionChannel()
+	discussionChannelID, err := client.ResolveChannelNameToID(ctx, discussionName)
+	if err != nil {
+		logger.WithFields(logger.LogFields{
+			"discussion_channel": discussionName,
+			"error":              err.Error(),
+		}).Debug("Could not find discussion channel for linking")
+		return ""
+	}
+	return discussionChannelID
+}
+
+// displayExportDir notes where the history of archiveCount channels is
+// exported to before they are archived, when --export-dir is set.
+func displayExportDir(client *slack.Client, archiveCount int, isDryRun bool) {
+	if client.ExportDir() == "" || archiveCount == 0 {
+		return
+	}
+	if isDryRun {
+		fmt.Printf("Would export the history of %d channels to %s before archiving them\n", archiveCount, client.ExportDir())
+		return
+	}
+	fmt.Printf("Exporting the history of each channel to %s before archiving it\n", client.ExportDir())
+}
+
 // processArchival handles archiving channels in both dry-run and real modes.
-func processArchival(client *slack.Client, toArchive []slack.Channel, warnSeconds, archiveSeconds int, isDryRun bool, totalChannels int) {
-	displayChannelDetails(toArchive, "Channels to archive (grace period expired)")
+// Live archival stops early once ctx is cancelled.
+func processArchival(ctx context.Context, client *slack.Client, toArchive []slack.Channel, warnSeconds, archiveSeconds int, isDryRun bool, totalChannels int, results *archiveRunResults) {
+	displayChannelDetails(toArchive, "Channels to archive (grace period expired)", client.Now())
 
 	if isDryRun {
 		fmt.Printf("--- DRY RUN ---\n")
@@ -853,19 +1215,27 @@ func processArchival(client *slack.Client, toArchive []slack.Channel, warnSecond
 			exampleArchivalMessage := client.FormatChannelArchivalMessage(toArchive[0], warnSeconds, archiveSeconds, "")
 			fmt.Printf("%s\n", exampleArchivalMessage)
 		}
+		displayExportDir(client, len(toArchive), isDryRun)
 		fmt.Printf("--- END DRY RUN ---\n\n")
 	} else {
 		fmt.Printf("Archiving %d channels...\n", len(toArchive))
+		displayExportDir(client, len(toArchive), isDryRun)
 		archived := 0
-		for _, channel := range toArchive {
-			if err := client.ArchiveChannelWithThresholds(channel, warnSeconds, archiveSeconds); err != nil {
+		for i, channel := range toArchive {
+			if ctx.Err() != nil {
+				results.markNotProcessed(toArchive[i:])
+				break
+			}
+			if err := client.ArchiveChannelWithThresholds(ctx, channel, warnSeconds, archiveSeconds); err != nil {
 				logger.WithFields(logger.LogFields{
 					"channel": channel.Name,
 					"error":   err.Error(),
 				}).Error("Failed to archive channel")
 				fmt.Printf("  Failed to archive #%s: %s\n", channel.Name, err.Error())
+				results.failed = append(results.failed, channel.Name)
 			} else {
 				archived++
+				results.archived = append(results.archived, channel.Name)
 				logger.WithField("channel", channel.Name).Info("Channel archived successfully")
 				fmt.Printf("  ✓ Archived #%s\n", channel.Name)
 			}
@@ -875,9 +1245,9 @@ func processArchival(client *slack.Client, toArchive []slack.Channel, warnSecond
 }
 
 func runHighlight(cmd *cobra.Command, args []string) error {
-	token := viper.GetString("token")
-	if token == "" {
-		return fmt.Errorf("slack token is required. Set SLACK_TOKEN environment variable or use --token flag")
+	token, err := requireToken()
+	if err != nil {
+		return err
 	}
 
 	// announce-to is mandatory when committing changes
@@ -889,29 +1259,37 @@ func runHighlight(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("count must be positive, got %d", count)
 	}
 
-	client, err := slack.NewClient(token)
+	client, err := newSlackClient(token)
 	if err != nil {
 		return fmt.Errorf("failed to create Slack client: %w", err)
 	}
+	defer closeSlackClient(client)
+	ctx := commandContext(cmd)
+	if err := requireCommandScopes(ctx, client, "channels highlight", commit, false); err != nil {
+		return err
+	}
+	if err := enableAuditLog(ctx, cmd, client, commit); err != nil {
+		return err
+	}
 
 	// Validate that the announce-to channel exists (if specified)
 	if announceTo != "" {
-		_, err = client.ResolveChannelNameToID(announceTo)
+		_, err = client.ResolveChannelNameToID(ctx, announceTo)
 		if err != nil {
 			return fmt.Errorf("announce-to channel '%s' not found: %w", announceTo, err)
 		}
 	}
 
-	return runHighlightWithClient(client, count, announceTo, !commit)
+	return runHighlightWithClient(ctx, client, count, announceTo, !commit)
 }
 
-func runHighlightWithClient(client *slack.Client, highlightCount int, announceChannel string, isDryRun bool) error {
+func runHighlightWithClient(ctx context.Context, client *slack.Client, highlightCount int, announceChannel string, isDryRun bool) error {
 	// Get and display workspace info
-	if err := displayWorkspaceInfo(client); err != nil {
+	if err := displayWorkspaceInfo(ctx, client); err != nil {
 		return err
 	}
 
-	randomChannels, err := client.GetRandomChannels(highlightCount)
+	randomChannels, err := client.GetRandomChannels(ctx, highlightCount)
 	if err != nil {
 		return fmt.Errorf("failed to get random channels: %w", err)
 	}
@@ -929,13 +1307,13 @@ func runHighlightWithClient(client *slack.Client, highlightCount int, announceCh
 	fmt.Printf("%s\n\n", strings.Join(channelNames, ", "))
 
 	if announceChannel != "" {
-		return handleHighlightAnnouncement(client, randomChannels, announceChannel, isDryRun)
+		return handleHighlightAnnouncement(ctx, client, randomChannels, announceChannel, isDryRun)
 	}
 
 	return handleHighlightDryRunWithoutChannel(client, randomChannels, isDryRun)
 }
 
-func handleHighlightAnnouncement(client *slack.Client, channels []slack.Channel, announceChannel string, isDryRun bool) error {
+func handleHighlightAnnouncement(ctx context.Context, client *slack.Client, channels []slack.Channel, announceChannel string, isDryRun bool) error {
 	message := client.FormatChannelHighlightAnnouncement(channels)
 
 	if isDryRun {
@@ -946,7 +1324,7 @@ func handleHighlightAnnouncement(client *slack.Client, channels []slack.Channel,
 		fmt.Printf("--- END DRY RUN ---\n")
 		fmt.Printf("\nTo actually post this highlight, add --commit to your command\n")
 	} else {
-		if err := client.PostMessage(announceChannel, message); err != nil {
+		if err := client.PostMessage(ctx, announceChannel, message); err != nil {
 			logger.WithFields(logger.LogFields{
 				"channel": announceChannel,
 				"error":   err.Error(),
@@ -1025,9 +1403,10 @@ func formatDuration(d time.Duration) string {
 	return strings.Join(parts, "")
 }
 
-// formatTimeRange formats a cutoff time into a human-readable time range description.
-func formatTimeRange(cutoffTime time.Time) string {
-	duration := time.Since(cutoffTime)
+// formatTimeRange formats a cutoff time into a human-readable time range
+// description as of now.
+func formatTimeRange(cutoffTime, now time.Time) string {
+	duration := now.Sub(cutoffTime)
 
 	days := duration.Hours() / 24
 	if days >= 1 {
@@ -1062,9 +1441,9 @@ func formatTimeRange(cutoffTime time.Time) string {
 	return "minute"
 }
 
-func runDefaultChannelCheckWithClient(client *slack.Client, sampleSize int, threshold float64) error {
+func runDefaultChannelCheckWithClient(ctx context.Context, client *slack.Client, sampleSize int, threshold float64) error {
 	// Get and display workspace info
-	if err := displayWorkspaceInfo(client); err != nil {
+	if err := displayWorkspaceInfo(ctx, client); err != nil {
 		return err
 	}
 
@@ -1075,7 +1454,7 @@ func runDefaultChannelCheckWithClient(client *slack.Client, sampleSize int, thre
 
 	// Detect default channels with user details
 	fmt.Printf("🔍 Detecting default channels...\n\n")
-	result, err := client.GetDefaultChannelsWithUsers(sampleSize, threshold)
+	result, err := client.GetDefaultChannelsWithUsers(ctx, sampleSize, threshold)
 	if err != nil {
 		return fmt.Errorf("failed to detect default channels: %w", err)
 	}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -209,7 +210,7 @@ func TestRunDetectWithClientLogic(t *testing.T) {
 		require.NoError(t, err)
 
 		cutoffTime := time.Now().Add(-24 * time.Hour)
-		err = runDetectWithClient(client, cutoffTime, "", false)
+		err = runDetectWithClient(context.Background(), client, cutoffTime, "", false)
 		assert.NoError(t, err)
 	})
 
@@ -222,7 +223,7 @@ func TestRunDetectWithClientLogic(t *testing.T) {
 		require.NoError(t, err)
 
 		cutoffTime := time.Now().Add(-2 * time.Hour)
-		err = runDetectWithClient(client, cutoffTime, "", false)
+		err = runDetectWithClient(context.Background(), client, cutoffTime, "", false)
 		assert.NoError(t, err)
 	})
 
@@ -237,7 +238,7 @@ func TestRunDetectWithClientLogic(t *testing.T) {
 		require.NoError(t, err)
 
 		cutoffTime := time.Now().Add(-2 * time.Hour)
-		err = runDetectWithClient(client, cutoffTime, "#general", false)
+		err = runDetectWithClient(context.Background(), client, cutoffTime, "#general", false)
 		assert.NoError(t, err)
 
 		// Verify message was posted
@@ -246,6 +247,33 @@ func TestRunDetectWithClientLogic(t *testing.T) {
 		assert.Equal(t, "CGENERAL", messages[0].ChannelID)
 	})
 
+	t.Run("Private channels are reported but never announced", func(t *testing.T) {
+		mockAPI := slack.NewMockSlackAPI()
+		testTime := time.Now().Add(-1 * time.Hour)
+		mockAPI.AddPrivateChannel("G123", "secret-project", testTime, "Private purpose", true)
+		mockAPI.AddChannel("CGENERAL", "general", time.Now().Add(-24*time.Hour), "General discussion")
+
+		client, err := slack.NewClientWithAPI(mockAPI)
+		require.NoError(t, err)
+		client.SetIncludePrivate(true)
+
+		cutoffTime := time.Now().Add(-2 * time.Hour)
+		oldStdout := os.Stdout
+		r, w, _ := os.Pipe() //nolint:errcheck
+		os.Stdout = w
+
+		err = runDetectWithClient(context.Background(), client, cutoffTime, "#general", false)
+
+		w.Close() //nolint:errcheck
+		os.Stdout = oldStdout
+		output, _ := io.ReadAll(r) //nolint:errcheck
+
+		assert.NoError(t, err)
+		assert.Contains(t, string(output), "#secret-project")
+		assert.Contains(t, string(output), "never announced")
+		assert.Empty(t, mockAPI.GetPostedMessages())
+	})
+
 	t.Run("Announcement posting error", func(t *testing.T) {
 		mockAPI := slack.NewMockSlackAPI()
 		testTime := time.Now().Add(-1 * time.Hour)
@@ -260,7 +288,7 @@ func TestRunDetectWithClientLogic(t *testing.T) {
 		require.NoError(t, err)
 
 		cutoffTime := time.Now().Add(-2 * time.Hour)
-		err = runDetectWithClient(client, cutoffTime, "#nonexistent", false)
+		err = runDetectWithClient(context.Background(), client, cutoffTime, "#nonexistent", false)
 
 		// Should return error about failed announcement
 		assert.Error(t, err)
@@ -277,7 +305,7 @@ func TestRunDetectWithClientLogic(t *testing.T) {
 		require.NoError(t, err)
 
 		cutoffTime := time.Now().Add(-2 * time.Hour)
-		err = runDetectWithClient(client, cutoffTime, "", false)
+		err = runDetectWithClient(context.Background(), client, cutoffTime, "", false)
 
 		// Should return error about failed to get new channels
 		assert.Error(t, err)
@@ -297,7 +325,7 @@ func TestDryRunModeFunctionality(t *testing.T) {
 		require.NoError(t, err)
 
 		cutoffTime := time.Now().Add(-2 * time.Hour)
-		err = runDetectWithClient(client, cutoffTime, "#general", true) // dry run mode = true
+		err = runDetectWithClient(context.Background(), client, cutoffTime, "#general", true) // dry run mode = true
 		assert.NoError(t, err)
 
 		// Verify NO message was posted in dry run mode
@@ -316,7 +344,7 @@ func TestDryRunModeFunctionality(t *testing.T) {
 		require.NoError(t, err)
 
 		cutoffTime := time.Now().Add(-2 * time.Hour)
-		err = runDetectWithClient(client, cutoffTime, "#general", false) // commit mode = false
+		err = runDetectWithClient(context.Background(), client, cutoffTime, "#general", false) // commit mode = false
 		assert.NoError(t, err)
 
 		// Verify message WAS posted in commit mode
@@ -334,7 +362,7 @@ func TestDryRunModeFunctionality(t *testing.T) {
 		require.NoError(t, err)
 
 		cutoffTime := time.Now().Add(-2 * time.Hour)
-		err = runDetectWithClient(client, cutoffTime, "", true) // dry run mode = true, no announcement channel
+		err = runDetectWithClient(context.Background(), client, cutoffTime, "", true) // dry run mode = true, no announcement channel
 		assert.NoError(t, err)
 
 		// Verify no messages posted (none expected)
@@ -354,12 +382,12 @@ func TestDryRunModeFunctionality(t *testing.T) {
 		cutoffTime := time.Now().Add(-2 * time.Hour)
 
 		// Test that function executes without error and generates expected announcement format
-		err = runDetectWithClient(client, cutoffTime, "", true) // dry run mode = true, no announcement channel
+		err = runDetectWithClient(context.Background(), client, cutoffTime, "", true) // dry run mode = true, no announcement channel
 		assert.NoError(t, err)
 
 		// Verify the announcement message would be properly formatted
 		// We can test this by calling the format function directly
-		newChannels, err := client.GetNewChannels(cutoffTime)
+		newChannels, err := client.GetNewChannels(context.Background(), cutoffTime)
 		require.NoError(t, err)
 		assert.Len(t, newChannels, 2)
 
@@ -383,7 +411,7 @@ func TestDryRunModeFunctionality(t *testing.T) {
 		require.NoError(t, err)
 
 		cutoffTime := time.Now().Add(-2 * time.Hour)
-		err = runDetectWithClient(client, cutoffTime, "", true) // dry run mode = true, no announcement channel
+		err = runDetectWithClient(context.Background(), client, cutoffTime, "", true) // dry run mode = true, no announcement channel
 		assert.NoError(t, err)
 
 		// Verify no messages posted (none expected)
@@ -391,7 +419,7 @@ func TestDryRunModeFunctionality(t *testing.T) {
 		assert.Len(t, messages, 0)
 
 		// When no channels are found, the function should return early and not show any dry run
-		newChannels, err := client.GetNewChannels(cutoffTime)
+		newChannels, err := client.GetNewChannels(context.Background(), cutoffTime)
 		require.NoError(t, err)
 		assert.Len(t, newChannels, 0)
 	})
@@ -407,7 +435,7 @@ func TestDryRunModeFunctionality(t *testing.T) {
 		require.NoError(t, err)
 
 		cutoffTime := time.Now().Add(-2 * time.Hour)
-		err = runDetectWithClient(client, cutoffTime, "#general", true) // dry run mode = true WITH announcement channel
+		err = runDetectWithClient(context.Background(), client, cutoffTime, "#general", true) // dry run mode = true WITH announcement channel
 		assert.NoError(t, err)
 
 		// Verify NO message was posted in dry run mode
@@ -415,7 +443,7 @@ func TestDryRunModeFunctionality(t *testing.T) {
 		assert.Len(t, messages, 0)
 
 		// Verify the message would be properly formatted
-		newChannels, err := client.GetNewChannels(cutoffTime)
+		newChannels, err := client.GetNewChannels(context.Background(), cutoffTime)
 		require.NoError(t, err)
 		assert.Len(t, newChannels, 1)
 
@@ -435,7 +463,7 @@ func TestDuplicateAnnouncementDetection(t *testing.T) {
 		client, err := slack.NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		isDuplicate, err := client.CheckForDuplicateAnnouncement("#general", "test message", []string{"new-channel"})
+		isDuplicate, err := client.CheckForDuplicateAnnouncement(context.Background(), "#general", "test message", []string{"new-channel"})
 		assert.NoError(t, err)
 		assert.False(t, isDuplicate)
 	})
@@ -451,7 +479,7 @@ func TestDuplicateAnnouncementDetection(t *testing.T) {
 		client, err := slack.NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		isDuplicate, err := client.CheckForDuplicateAnnouncement("#general", "test message", []string{"test-channel"})
+		isDuplicate, err := client.CheckForDuplicateAnnouncement(context.Background(), "#general", "test message", []string{"test-channel"})
 		assert.NoError(t, err)
 		assert.True(t, isDuplicate)
 	})
@@ -467,7 +495,7 @@ func TestDuplicateAnnouncementDetection(t *testing.T) {
 		client, err := slack.NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		isDuplicate, err := client.CheckForDuplicateAnnouncement("#general", "test message", []string{"test-channel"})
+		isDuplicate, err := client.CheckForDuplicateAnnouncement(context.Background(), "#general", "test message", []string{"test-channel"})
 		assert.NoError(t, err)
 		assert.False(t, isDuplicate)
 	})
@@ -483,7 +511,7 @@ func TestDuplicateAnnouncementDetection(t *testing.T) {
 		client, err := slack.NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		isDuplicate, err := client.CheckForDuplicateAnnouncement("#general", "test message", []string{"test-channel"})
+		isDuplicate, err := client.CheckForDuplicateAnnouncement(context.Background(), "#general", "test message", []string{"test-channel"})
 		assert.NoError(t, err)
 		assert.False(t, isDuplicate)
 	})
@@ -499,7 +527,7 @@ func TestDuplicateAnnouncementDetection(t *testing.T) {
 		client, err := slack.NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		isDuplicate, err := client.CheckForDuplicateAnnouncement("#general", "test message", []string{"test-channel"})
+		isDuplicate, err := client.CheckForDuplicateAnnouncement(context.Background(), "#general", "test message", []string{"test-channel"})
 		assert.NoError(t, err)
 		assert.False(t, isDuplicate)
 	})
@@ -515,7 +543,7 @@ func TestDuplicateAnnouncementDetection(t *testing.T) {
 		client, err := slack.NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		isDuplicate, err := client.CheckForDuplicateAnnouncement("#general", "test message", []string{"test-channel"})
+		isDuplicate, err := client.CheckForDuplicateAnnouncement(context.Background(), "#general", "test message", []string{"test-channel"})
 		assert.NoError(t, err)
 		assert.False(t, isDuplicate) // Should return false when can't check
 	})
@@ -536,7 +564,7 @@ func TestDuplicateAnnouncementIntegration(t *testing.T) {
 		require.NoError(t, err)
 
 		cutoffTime := time.Now().Add(-2 * time.Hour)
-		err = runDetectWithClient(client, cutoffTime, "#general", false) // commit mode
+		err = runDetectWithClient(context.Background(), client, cutoffTime, "#general", false) // commit mode
 		assert.NoError(t, err)
 
 		// Verify NO new message was posted (duplicate was detected)
@@ -558,7 +586,7 @@ func TestDuplicateAnnouncementIntegration(t *testing.T) {
 		require.NoError(t, err)
 
 		cutoffTime := time.Now().Add(-2 * time.Hour)
-		err = runDetectWithClient(client, cutoffTime, "#general", false) // commit mode
+		err = runDetectWithClient(context.Background(), client, cutoffTime, "#general", false) // commit mode
 		assert.NoError(t, err)
 
 		// Verify message WAS posted (no duplicate detected)
@@ -720,7 +748,7 @@ func TestDisplayExclusionInfo(t *testing.T) {
 		require.NoError(t, err)
 		os.Stdout = w
 
-		displayExclusionInfo([]string{"general", "random"}, []string{"test-", "dev-"}, []string{}, "")
+		displayExclusionInfo([]string{"general", "random"}, []string{"test-", "dev-"}, []string{}, "", nil, nil)
 
 		err = w.Close()
 		require.NoError(t, err)
@@ -740,7 +768,7 @@ func TestDisplayExclusionInfo(t *testing.T) {
 		require.NoError(t, err)
 		os.Stdout = w
 
-		displayExclusionInfo([]string{"general"}, []string{}, []string{}, "")
+		displayExclusionInfo([]string{"general"}, []string{}, []string{}, "", nil, nil)
 
 		err = w.Close()
 		require.NoError(t, err)
@@ -760,7 +788,7 @@ func TestDisplayExclusionInfo(t *testing.T) {
 		require.NoError(t, err)
 		os.Stdout = w
 
-		displayExclusionInfo([]string{}, []string{"test-"}, []string{}, "")
+		displayExclusionInfo([]string{}, []string{"test-"}, []string{}, "", nil, nil)
 
 		err = w.Close()
 		require.NoError(t, err)
@@ -775,12 +803,46 @@ func TestDisplayExclusionInfo(t *testing.T) {
 		assert.Contains(t, outputStr, "Excluded prefixes: test-")
 	})
 
+	t.Run("Display protected names", func(t *testing.T) {
+		r, w, err := os.Pipe()
+		require.NoError(t, err)
+		os.Stdout = w
+
+		displayExclusionInfo([]string{}, []string{}, []string{}, "", []string{"*general*", "team-*"}, nil)
+
+		err = w.Close()
+		require.NoError(t, err)
+		os.Stdout = oldStdout
+
+		output, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Contains(t, string(output), "Protected names (--protected-names): *general*, team-*")
+	})
+
+	t.Run("Display excluded patterns", func(t *testing.T) {
+		r, w, err := os.Pipe()
+		require.NoError(t, err)
+		os.Stdout = w
+
+		hold, err := slack.NewExclusion("legal-*", "litigation hold", time.Time{})
+		require.NoError(t, err)
+		displayExclusionInfo([]string{}, []string{}, []string{}, "", nil, []slack.Exclusion{hold})
+
+		err = w.Close()
+		require.NoError(t, err)
+		os.Stdout = oldStdout
+
+		output, err := io.ReadAll(r)
+		require.NoError(t, err)
+		assert.Contains(t, string(output), "Excluded patterns: legal-* (litigation hold)")
+	})
+
 	t.Run("Display nothing when no exclusions", func(t *testing.T) {
 		r, w, err := os.Pipe()
 		require.NoError(t, err)
 		os.Stdout = w
 
-		displayExclusionInfo([]string{}, []string{}, []string{}, "")
+		displayExclusionInfo([]string{}, []string{}, []string{}, "", nil, nil)
 
 		err = w.Close()
 		require.NoError(t, err)
@@ -796,6 +858,105 @@ func TestDisplayExclusionInfo(t *testing.T) {
 	os.Stdout = oldStdout
 }
 
+func TestParseProtectedNames(t *testing.T) {
+	names, err := parseProtectedNames("general, team-*,/^ops-\\d+$/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"general", "team-*", "/^ops-\\d+$/"}, names.Patterns())
+
+	names, err = parseProtectedNames("none")
+	require.NoError(t, err)
+	assert.Empty(t, names.Patterns())
+
+	names, err = parseProtectedNames(strings.Join(slack.DefaultProtectedNames, ","))
+	require.NoError(t, err)
+	assert.Equal(t, slack.DefaultProtectedNames, names.Patterns())
+
+	names, err = parseProtectedNames("/^ops-\\d{2,3}$/, general,/^(a|b),c$/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/^ops-\\d{2,3}$/", "general", "/^(a|b),c$/"}, names.Patterns())
+	_, protected := names.Match("ops-123")
+	assert.True(t, protected)
+	_, protected = names.Match("ops-1")
+	assert.False(t, protected)
+
+	_, err = parseProtectedNames("team-[")
+	assert.ErrorContains(t, err, "invalid --protected-names")
+	_, err = parseProtectedNames("/^ops-\\d{2,3}$")
+	assert.ErrorContains(t, err, "invalid --protected-names")
+
+	for _, empty := range []string{"", "  ", ",,"} {
+		_, err = parseProtectedNames(empty)
+		assert.ErrorContains(t, err, "use 'none' to protect no names", "value %q", empty)
+	}
+}
+
+func TestKeepVotes(t *testing.T) {
+	assert.Equal(t, "keep", parseKeepReaction("keep"))
+	assert.Empty(t, parseKeepReaction("None"))
+
+	channels := []slack.Channel{
+		{Name: "quiet", LastActivity: time.Now().Add(-60 * 24 * time.Hour), KeepVoters: []string{"Alice", "Bob"}},
+		{Name: "idle", LastActivity: time.Now().Add(-60 * 24 * time.Hour)},
+	}
+	assert.Equal(t, []string{"quiet"}, keptByVote(channels))
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	oldStdout := os.Stdout
+	os.Stdout = w
+	displayChannelDetails(channels, "Channels to warn about inactivity", time.Now())
+	require.NoError(t, w.Close())
+	os.Stdout = oldStdout
+
+	output, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Contains(t, string(output), "Voted to keep by: Alice, Bob (grace period restarts)")
+	assert.Equal(t, 1, strings.Count(string(output), "Voted to keep"))
+}
+
+func TestDisplayThreadActivityStatus(t *testing.T) {
+	capture := func(skipThreads bool) string {
+		r, w, err := os.Pipe()
+		require.NoError(t, err)
+		oldStdout := os.Stdout
+		os.Stdout = w
+		displayThreadActivityStatus(skipThreads)
+		require.NoError(t, w.Close())
+		os.Stdout = oldStdout
+
+		output, err := io.ReadAll(r)
+		require.NoError(t, err)
+		return string(output)
+	}
+
+	assert.Empty(t, capture(false))
+	assert.Contains(t, capture(true), "Thread replies are IGNORED")
+}
+
+func TestDisplayExportDir(t *testing.T) {
+	client, err := slack.NewClientWithAPI(slack.NewMockSlackAPI())
+	require.NoError(t, err)
+	capture := func(archiveCount int, isDryRun bool) string {
+		r, w, err := os.Pipe()
+		require.NoError(t, err)
+		oldStdout := os.Stdout
+		os.Stdout = w
+		displayExportDir(client, archiveCount, isDryRun)
+		require.NoError(t, w.Close())
+		os.Stdout = oldStdout
+
+		output, err := io.ReadAll(r)
+		require.NoError(t, err)
+		return string(output)
+	}
+
+	assert.Empty(t, capture(2, true))
+	client.SetExportDir("exports")
+	assert.Empty(t, capture(0, true))
+	assert.Contains(t, capture(2, true), "Would export the history of 2 channels to exports before archiving them")
+	assert.Contains(t, capture(2, false), "Exporting the history of each channel to exports before archiving it")
+}
+
 func TestGetUserMapWithErrorHandling(t *testing.T) {
 	t.Run("Success with debug mode", func(t *testing.T) {
 		mockAPI := slack.NewMockSlackAPI()
@@ -804,7 +965,7 @@ func TestGetUserMapWithErrorHandling(t *testing.T) {
 		client, err := slack.NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		userMap, err := getUserMapWithErrorHandling(client, true)
+		userMap, err := getUserMapWithErrorHandling(context.Background(), client, true)
 		assert.NoError(t, err)
 		assert.Len(t, userMap, 1)
 		assert.Equal(t, "Test User", userMap["U1234567"])
@@ -817,7 +978,7 @@ func TestGetUserMapWithErrorHandling(t *testing.T) {
 		client, err := slack.NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		userMap, err := getUserMapWithErrorHandling(client, false)
+		userMap, err := getUserMapWithErrorHandling(context.Background(), client, false)
 		assert.NoError(t, err)
 		assert.Len(t, userMap, 1)
 		assert.Equal(t, "Test User", userMap["U1234567"])
@@ -830,7 +991,7 @@ func TestGetUserMapWithErrorHandling(t *testing.T) {
 		client, err := slack.NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		userMap, err := getUserMapWithErrorHandling(client, false)
+		userMap, err := getUserMapWithErrorHandling(context.Background(), client, false)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "rate limited by Slack API")
 		assert.Nil(t, userMap)
@@ -838,12 +999,12 @@ func TestGetUserMapWithErrorHandling(t *testing.T) {
 
 	t.Run("Missing scope error", func(t *testing.T) {
 		mockAPI := slack.NewMockSlackAPI()
-		mockAPI.SetGetUsersError("missing_scope: users:read")
+		mockAPI.SetGetUsersError("missing_scope")
 
 		client, err := slack.NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		userMap, err := getUserMapWithErrorHandling(client, false)
+		userMap, err := getUserMapWithErrorHandling(context.Background(), client, false)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "missing required OAuth scope 'users:read'")
 		assert.Nil(t, userMap)
@@ -856,7 +1017,7 @@ func TestGetUserMapWithErrorHandling(t *testing.T) {
 		client, err := slack.NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		userMap, err := getUserMapWithErrorHandling(client, false)
+		userMap, err := getUserMapWithErrorHandling(context.Background(), client, false)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to get users")
 		assert.Nil(t, userMap)
@@ -876,7 +1037,7 @@ func TestGetInactiveChannelsWithErrorHandling(t *testing.T) {
 		require.NoError(t, err)
 
 		userMap := map[string]string{"U1234567": "testuser"}
-		toWarn, toArchive, _, err := getInactiveChannelsWithErrorHandling(client, 30, 7, userMap, []string{}, []string{}, false, false, 0)
+		toWarn, toArchive, _, err := getInactiveChannelsWithErrorHandling(context.Background(), client, 30, 7, userMap, []string{}, []string{}, false, false, 0)
 
 		assert.NoError(t, err)
 		assert.Len(t, toWarn, 1)
@@ -892,7 +1053,7 @@ func TestGetInactiveChannelsWithErrorHandling(t *testing.T) {
 		require.NoError(t, err)
 
 		userMap := map[string]string{}
-		toWarn, toArchive, _, err := getInactiveChannelsWithErrorHandling(client, 30, 7, userMap, []string{}, []string{}, false, false, 0)
+		toWarn, toArchive, _, err := getInactiveChannelsWithErrorHandling(context.Background(), client, 30, 7, userMap, []string{}, []string{}, false, false, 0)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to analyze inactive channels")
@@ -1059,7 +1220,7 @@ func TestRunHighlightWithClient(t *testing.T) {
 		oldStdout := os.Stdout
 		os.Stdout = w
 
-		err = runHighlightWithClient(client, 2, "", true)
+		err = runHighlightWithClient(context.Background(), client, 2, "", true)
 
 		// Restore stdout
 		_ = w.Close() //nolint:errcheck
@@ -1084,7 +1245,7 @@ func TestRunHighlightWithClient(t *testing.T) {
 		oldStdout := os.Stdout
 		os.Stdout = w
 
-		err = runHighlightWithClient(client, 5, "", true)
+		err = runHighlightWithClient(context.Background(), client, 5, "", true)
 
 		// Restore stdout
 		_ = w.Close() //nolint:errcheck
@@ -1114,7 +1275,7 @@ func TestRunHighlightWithClient(t *testing.T) {
 		oldStdout := os.Stdout
 		os.Stdout = w
 
-		err = runHighlightWithClient(client, 1, "#general", true)
+		err = runHighlightWithClient(context.Background(), client, 1, "#general", true)
 
 		// Restore stdout
 		_ = w.Close() //nolint:errcheck
@@ -1150,7 +1311,7 @@ func TestRunHighlightWithClient(t *testing.T) {
 		oldStdout := os.Stdout
 		os.Stdout = w
 
-		err = runHighlightWithClient(client, 1, "#general", false)
+		err = runHighlightWithClient(context.Background(), client, 1, "#general", false)
 
 		// Restore stdout
 		_ = w.Close() //nolint:errcheck
@@ -1176,7 +1337,7 @@ func TestRunHighlightWithClient(t *testing.T) {
 		client, err := slack.NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		err = runHighlightWithClient(client, 1, "", true)
+		err = runHighlightWithClient(context.Background(), client, 1, "", true)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to get random channels")
 	})
@@ -1200,7 +1361,7 @@ func TestHandleHighlightAnnouncement(t *testing.T) {
 		oldStdout := os.Stdout
 		os.Stdout = w
 
-		err = handleHighlightAnnouncement(client, channels, "#general", true)
+		err = handleHighlightAnnouncement(context.Background(), client, channels, "#general", true)
 
 		// Restore stdout
 		_ = w.Close() //nolint:errcheck
@@ -1240,7 +1401,7 @@ func TestHandleHighlightAnnouncement(t *testing.T) {
 		oldStdout := os.Stdout
 		os.Stdout = w
 
-		err = handleHighlightAnnouncement(client, channels, "#general", false)
+		err = handleHighlightAnnouncement(context.Background(), client, channels, "#general", false)
 
 		// Restore stdout
 		_ = w.Close() //nolint:errcheck
@@ -1272,7 +1433,7 @@ func TestHandleHighlightAnnouncement(t *testing.T) {
 			},
 		}
 
-		err = handleHighlightAnnouncement(client, channels, "#nonexistent", false)
+		err = handleHighlightAnnouncement(context.Background(), client, channels, "#nonexistent", false)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to post highlight")
 	})
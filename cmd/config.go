@@ -0,0 +1,448 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/astrostl/slack-butler/pkg/slack"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// profilesKey is the config file section holding named profiles.
+const profilesKey = "profiles"
+
+// fileConfig is the config file as it applies to the running command.
+type fileConfig struct {
+	values  map[string]any    // setting name (underscored) -> value
+	sources map[string]string // setting name -> where in the file it was set
+	path    string
+	profile string
+}
+
+// activeConfig is the config file loaded for the running command, if any.
+var activeConfig *fileConfig
+
+// defaultConfigPath returns ~/.config/slack-butler/config.yaml, honouring
+// XDG_CONFIG_HOME.
+func defaultConfigPath() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "slack-butler", "config.yaml")
+}
+
+// configFilePath returns the config file to load and whether it was asked
+// for explicitly (via --config or SLACK_CONFIG) rather than being the default.
+func configFilePath() (string, bool) {
+	if path := viper.GetString("config"); path != "" {
+		return path, true
+	}
+	return defaultConfigPath(), false
+}
+
+// loadConfigFile reads the config file and applies it to cmd's flags that
+// weren't set on the command line. Precedence is: flag, then environment
+// variable, then the profile's command section, the profile, the command
+// section and finally the top level of the file, then the flag default.
+func loadConfigFile(cmd *cobra.Command) error {
+	activeConfig = nil
+	cfg, err := readConfigFile(commandSection(cmd))
+	if err != nil || cfg == nil {
+		return err
+	}
+	activeConfig = cfg
+
+	if err := cfg.applyToFlags(cmd.Flags()); err != nil {
+		return fmt.Errorf("invalid config file %s: %w", cfg.path, err)
+	}
+	applyLogLevel()
+	return nil
+}
+
+// readConfigFile returns the config file values for the given command
+// section and the selected profile, or nil when there is no config file.
+func readConfigFile(section string) (*fileConfig, error) {
+	path, explicit := configFilePath()
+	profile := viper.GetString("profile")
+
+	if path == "" || !explicit && !fileExists(path) {
+		if profile != "" {
+			return nil, fmt.Errorf("--profile %s requires a config file, but %s does not exist", profile, path)
+		}
+		return nil, nil
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	cfg, err := resolveFileConfig(v.AllSettings(), section, profile)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	cfg.path = path
+	return cfg, nil
+}
+
+// fileExists reports whether path exists.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// commandSection returns the config file section for cmd, e.g. "archive"
+// for `channels archive`, or "" for commands without one.
+func commandSection(cmd *cobra.Command) string {
+	if cmd == nil || !cmd.HasParent() || cmd.Parent() != channelsCmd {
+		return ""
+	}
+	return cmd.Name()
+}
+
+// resolveFileConfig flattens settings into the values that apply to the
+// given command section and profile. Later layers override earlier ones.
+func resolveFileConfig(settings map[string]any, section, profile string) (*fileConfig, error) {
+	cfg := &fileConfig{
+		values:  make(map[string]any),
+		sources: make(map[string]string),
+		profile: profile,
+	}
+
+	cfg.merge(settings, "top level")
+	if section != "" {
+		cfg.merge(toSettingsMap(settings[section]), section+" section")
+	}
+
+	if profile == "" {
+		return cfg, nil
+	}
+	profileSettings, ok := toSettingsMap(settings[profilesKey])[profile]
+	if !ok {
+		return nil, fmt.Errorf("profile %q not found (available: %s)", profile, strings.Join(profileNames(settings), ", "))
+	}
+	profileMap := toSettingsMap(profileSettings)
+	cfg.merge(profileMap, "profile "+profile)
+	if section != "" {
+		cfg.merge(toSettingsMap(profileMap[section]), "profile "+profile+", "+section+" section")
+	}
+	return cfg, nil
+}
+
+// merge copies the plain settings in layer, skipping nested sections.
+func (cfg *fileConfig) merge(layer map[string]any, source string) {
+	for key, value := range layer {
+		if _, nested := value.(map[string]any); nested {
+			continue
+		}
+		key = normalizeConfigKey(key)
+		cfg.values[key] = value
+		cfg.sources[key] = source
+	}
+}
+
+// applyToFlags sets every flag in flags that wasn't given on the command
+// line to its config file value. Flags stay unchanged so environment
+// variables still take precedence in viper and the resolve*Config helpers.
+func (cfg *fileConfig) applyToFlags(flags *pflag.FlagSet) error {
+	var err error
+	flags.VisitAll(func(flag *pflag.Flag) {
+		value, ok := cfg.values[normalizeConfigKey(flag.Name)]
+		if !ok || flag.Changed || err != nil || flag.Name == "config" || flag.Name == "profile" {
+			return
+		}
+		if setErr := setFlagFromConfig(flag, value); setErr != nil {
+			err = fmt.Errorf("%s (%s): %w", flag.Name, cfg.sources[normalizeConfigKey(flag.Name)], setErr)
+		}
+	})
+	return err
+}
+
+// setFlagFromConfig sets flag to a config file value. Lists given to
+// repeatable flags such as --exclude-pattern set one value per item rather
+// than a single comma-separated value.
+func setFlagFromConfig(flag *pflag.Flag, value any) error {
+	list, isList := value.([]any)
+	sliceValue, isSlice := flag.Value.(pflag.SliceValue)
+	if !isList || !isSlice {
+		return flag.Value.Set(configValueString(value))
+	}
+	items := make([]string, 0, len(list))
+	for _, item := range list {
+		items = append(items, fmt.Sprint(item))
+	}
+	return sliceValue.Replace(items)
+}
+
+// normalizeConfigKey maps flag-style names such as warn-days to the
+// underscored keys used by viper and the environment (warn_days).
+func normalizeConfigKey(key string) string {
+	return strings.ReplaceAll(strings.ToLower(key), "-", "_")
+}
+
+// configValueString renders a config value the way it would be typed on
+// the command line. Lists become comma-separated, as for --exclude-channels.
+func configValueString(value any) string {
+	if list, ok := value.([]any); ok {
+		items := make([]string, 0, len(list))
+		for _, item := range list {
+			items = append(items, fmt.Sprint(item))
+		}
+		return strings.Join(items, ",")
+	}
+	return fmt.Sprint(value)
+}
+
+// toSettingsMap returns value as a settings map, or nil.
+func toSettingsMap(value any) map[string]any {
+	settings, _ := value.(map[string]any) //nolint:errcheck // not a map means no settings
+	return settings
+}
+
+// profileNames returns the profiles defined in settings, sorted.
+func profileNames(settings map[string]any) []string {
+	profiles := toSettingsMap(settings[profilesKey])
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var configCmd = &cobra.Command{
+	Use:          "config",
+	Short:        "Inspect slack-butler configuration",
+	Long:         `Commands for inspecting how slack-butler is configured.`,
+	SilenceUsage: true, // Don't show usage on errors
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show [command]",
+	Short: "Show every effective setting and where it came from",
+	Long: `Show the value of every setting and whether it came from a command-line flag, an environment variable, the config file or the built-in default.
+
+Global settings are always shown. Settings for the channels subcommands are shown for all of them, or only for the one given (detect, archive or highlight). Flags that only apply to a subcommand can't be given to config show, so their source is an environment variable, the config file or the default.
+
+The token is always redacted, as is any password in the proxy URL.`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true, // Don't show usage on errors
+	RunE:         runConfigShow,
+}
+
+var configShowFormat string
+
+// Output formats for config show.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+)
+
+// resolvedSetting is one effective setting as reported by config show.
+type resolvedSetting struct {
+	Command string `json:"command"`
+	Setting string `json:"setting"`
+	Value   string `json:"value"`
+	Source  string `json:"source"`
+}
+
+// configReport is the output of config show.
+type configReport struct {
+	ConfigFile string            `json:"config_file,omitempty"`
+	Profile    string            `json:"profile,omitempty"`
+	Settings   []resolvedSetting `json:"settings"`
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configShowCmd)
+
+	configShowCmd.Flags().StringVar(&configShowFormat, "format", formatTable, "Output format: table or json")
+}
+
+func runConfigShow(cmd *cobra.Command, args []string) error {
+	if configShowFormat != formatTable && configShowFormat != formatJSON {
+		return fmt.Errorf("invalid --format %q: must be %s or %s", configShowFormat, formatTable, formatJSON)
+	}
+
+	commands := channelsCmd.Commands()
+	if len(args) == 1 {
+		sub, _, err := channelsCmd.Find(args)
+		if err != nil || sub == channelsCmd {
+			return fmt.Errorf("unknown command %q: must be one of the channels subcommands", args[0])
+		}
+		commands = []*cobra.Command{sub}
+	}
+
+	report, err := buildConfigReport(cmd, commands)
+	if err != nil {
+		return err
+	}
+	if configShowFormat == formatJSON {
+		return printConfigReportJSON(report)
+	}
+	printConfigReportTable(report)
+	return nil
+}
+
+// buildConfigReport resolves the global settings, as seen by cmd, and the
+// settings of each of the given channels subcommands.
+func buildConfigReport(cmd *cobra.Command, commands []*cobra.Command) (*configReport, error) {
+	globalConfig, err := readConfigFile("")
+	if err != nil {
+		return nil, err
+	}
+	report := &configReport{Profile: viper.GetString("profile")}
+	if globalConfig != nil {
+		report.ConfigFile = globalConfig.path
+	}
+
+	rootCmd.PersistentFlags().VisitAll(func(flag *pflag.Flag) {
+		changed := cmd.Flags().Changed(flag.Name)
+		if changed {
+			flag = cmd.Flags().Lookup(flag.Name)
+		}
+		report.Settings = append(report.Settings, resolveSetting("global", flag, changed, globalEnvVar(flag.Name), globalConfig))
+	})
+
+	for _, sub := range commands {
+		sectionConfig, err := readConfigFile(sub.Name())
+		if err != nil {
+			return nil, err
+		}
+		command := sub.Parent().Name() + " " + sub.Name()
+		sub.Flags().VisitAll(func(flag *pflag.Flag) {
+			if flag.Name == "help" {
+				return
+			}
+			report.Settings = append(report.Settings, resolveSetting(command, flag, false, commandEnvVar(flag.Name), sectionConfig))
+		})
+	}
+	return report, nil
+}
+
+// resolveSetting works out the effective value of flag for command and where
+// it came from, following the precedence described at loadConfigFile.
+func resolveSetting(command string, flag *pflag.Flag, changed bool, envVar string, cfg *fileConfig) resolvedSetting {
+	setting := resolvedSetting{Command: command, Setting: flag.Name}
+	key := normalizeConfigKey(flag.Name)
+	fileValue, inFile := any(nil), false
+	if cfg != nil && flag.Name != "config" && flag.Name != "profile" {
+		fileValue, inFile = cfg.values[key]
+	}
+
+	switch {
+	case changed:
+		setting.Value, setting.Source = flag.Value.String(), "flag"
+	case envVar != "" && os.Getenv(envVar) != "":
+		setting.Value, setting.Source = os.Getenv(envVar), "env "+envVar
+	case inFile:
+		setting.Value, setting.Source = configValueString(fileValue), "config file ("+cfg.sources[key]+")"
+	default:
+		setting.Value, setting.Source = flag.DefValue, "default"
+	}
+
+	setting.Value = redactSetting(key, setting.Value)
+	return setting
+}
+
+// globalEnvVar returns the environment variable for a global flag. Global
+// flags are bound to viper, so any SLACK_ variable named after them applies.
+func globalEnvVar(flagName string) string {
+	if env := commandEnvVar(flagName); env != "" {
+		return env
+	}
+	return "SLACK_" + strings.ToUpper(normalizeConfigKey(flagName))
+}
+
+// commandEnvVar returns the environment variable that the resolve*Config
+// helpers consult for a subcommand flag, or "" if there is none.
+func commandEnvVar(flagName string) string {
+	key := normalizeConfigKey(flagName)
+	for _, binding := range envBindings {
+		if binding.key == key {
+			return binding.env
+		}
+	}
+	return ""
+}
+
+// redactSetting hides the token and any proxy password from config show.
+func redactSetting(key, value string) string {
+	switch {
+	case value == "":
+		return value
+	case key == "token":
+		return "[REDACTED]"
+	case key == "http_proxy":
+		if proxyURL, err := url.Parse(value); err == nil {
+			return proxyURL.Redacted()
+		}
+		return "[REDACTED]"
+	default:
+		return slack.SanitizeForLogging(value)
+	}
+}
+
+// printConfigReportTable prints report as one table per command.
+func printConfigReportTable(report *configReport) {
+	if report.ConfigFile != "" {
+		fmt.Printf("Config file: %s\n", report.ConfigFile)
+	} else {
+		fmt.Println("Config file: none")
+	}
+	if report.Profile != "" {
+		fmt.Printf("Profile: %s\n", report.Profile)
+	}
+
+	var w *tabwriter.Writer
+	command := ""
+	for _, setting := range report.Settings {
+		if setting.Command != command {
+			if w != nil {
+				_ = w.Flush() //nolint:errcheck // stdout
+			}
+			command = setting.Command
+			fmt.Printf("\n%s:\n", command)
+			w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "  SETTING\tVALUE\tSOURCE")
+		}
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", setting.Setting, displayValue(setting.Value), setting.Source)
+	}
+	if w != nil {
+		_ = w.Flush() //nolint:errcheck // stdout
+	}
+}
+
+// displayValue shows empty values as "(empty)" so table columns stay readable.
+func displayValue(value string) string {
+	if value == "" {
+		return "(empty)"
+	}
+	return value
+}
+
+// printConfigReportJSON prints report as indented JSON.
+func printConfigReportJSON(report *configReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode settings: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
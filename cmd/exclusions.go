@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/astrostl/slack-butler/pkg/slack"
+)
+
+// exclusionDateLayout is the format of exclusion file expiry dates.
+const exclusionDateLayout = "2006-01-02"
+
+// buildExclusions compiles the --exclude-pattern values and the entries of
+// the --exclude-file, if any.
+func buildExclusions(patterns []string, path string) ([]slack.Exclusion, error) {
+	exclusions := make([]slack.Exclusion, 0, len(patterns))
+	for _, pattern := range patterns {
+		exclusion, err := slack.NewExclusion(pattern, "", time.Time{})
+		if err != nil {
+			return nil, fmt.Errorf("invalid --exclude-pattern %q: %w", pattern, err)
+		}
+		exclusions = append(exclusions, exclusion)
+	}
+	if path == "" {
+		return exclusions, nil
+	}
+
+	fileExclusions, err := loadExclusionFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return append(exclusions, fileExclusions...), nil
+}
+
+// loadExclusionFile reads an exclusion file: one channel name, glob or
+// /regex/ per line, optionally followed by expires=YYYY-MM-DD and a
+// # comment giving the reason, e.g.
+//
+//	# Channels kept regardless of activity.
+//	legal-hold                            # litigation hold, ask legal
+//	offsite-2026-*  expires=2026-12-31    # event planning
+func loadExclusionFile(path string) ([]slack.Exclusion, error) {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read exclusion file %s: %w", path, err)
+	}
+	defer file.Close() //nolint:errcheck // read-only
+
+	exclusions, err := parseExclusionFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exclusion file %s: %w", path, err)
+	}
+	return exclusions, nil
+}
+
+// parseExclusionFile parses the exclusion file format described at
+// loadExclusionFile.
+func parseExclusionFile(r io.Reader) ([]slack.Exclusion, error) {
+	var exclusions []slack.Exclusion
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		exclusion, err := parseExclusionLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+		exclusions = append(exclusions, exclusion)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return exclusions, nil
+}
+
+// parseExclusionLine parses a single non-comment exclusion file line.
+func parseExclusionLine(line string) (slack.Exclusion, error) {
+	line, reason := cutComment(line)
+	fields := strings.Fields(line)
+	var expires time.Time
+	for _, field := range fields[1:] {
+		value, ok := strings.CutPrefix(field, "expires=")
+		if !ok {
+			return slack.Exclusion{}, fmt.Errorf("unexpected %q after pattern (reasons go after #)", field)
+		}
+		date, err := time.ParseInLocation(exclusionDateLayout, value, time.Local)
+		if err != nil {
+			return slack.Exclusion{}, fmt.Errorf("invalid expiry date %q, expected YYYY-MM-DD", value)
+		}
+		expires = date
+	}
+	return slack.NewExclusion(fields[0], reason, expires)
+}
+
+// cutComment splits line at the first # preceded by whitespace.
+func cutComment(line string) (string, string) {
+	for i := 1; i < len(line); i++ {
+		if line[i] == '#' && (line[i-1] == ' ' || line[i-1] == '\t') {
+			return line[:i], strings.TrimSpace(line[i+1:])
+		}
+	}
+	return line, ""
+}
+
+// activeExclusions splits exclusions into those still in effect at now
+// and those that have expired.
+func activeExclusions(exclusions []slack.Exclusion, now time.Time) ([]slack.Exclusion, []slack.Exclusion) {
+	var active, expired []slack.Exclusion
+	for _, exclusion := range exclusions {
+		if exclusion.Expired(now) {
+			expired = append(expired, exclusion)
+		} else {
+			active = append(active, exclusion)
+		}
+	}
+	return active, expired
+}
+
+// formatExclusion describes an exclusion for the run summary.
+func formatExclusion(exclusion slack.Exclusion) string {
+	var notes []string
+	if !exclusion.Expires.IsZero() {
+		notes = append(notes, "until "+exclusion.Expires.Format(exclusionDateLayout))
+	}
+	if exclusion.Reason != "" {
+		notes = append(notes, exclusion.Reason)
+	}
+	if len(notes) == 0 {
+		return exclusion.Pattern
+	}
+	return fmt.Sprintf("%s (%s)", exclusion.Pattern, strings.Join(notes, "; "))
+}
+
+// displayExpiredExclusions warns about exclusions past their expiry date,
+// which no longer protect their channels.
+func displayExpiredExclusions(expired []slack.Exclusion) {
+	if len(expired) == 0 {
+		return
+	}
+	fmt.Printf("⚠️  Expired exclusions (no longer applied; remove or extend them in --exclude-file):\n")
+	for _, exclusion := range expired {
+		fmt.Printf("   %s\n", formatExclusion(exclusion))
+	}
+	fmt.Println()
+}
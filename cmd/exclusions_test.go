@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/astrostl/slack-butler/pkg/slack"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExclusionFile(t *testing.T) {
+	exclusions, err := parseExclusionFile(strings.NewReader(`
+# Channels kept regardless of activity.
+legal-hold                          # litigation hold, ask legal
+offsite-2026-*	expires=2026-03-31	# event planning
+/^inc-\d+$/
+`))
+	require.NoError(t, err)
+	require.Len(t, exclusions, 3)
+
+	assert.Equal(t, "legal-hold", exclusions[0].Pattern)
+	assert.Equal(t, "litigation hold, ask legal", exclusions[0].Reason)
+	assert.True(t, exclusions[0].Expires.IsZero())
+
+	assert.Equal(t, "offsite-2026-*", exclusions[1].Pattern)
+	assert.Equal(t, "event planning", exclusions[1].Reason)
+	assert.Equal(t, "2026-03-31", exclusions[1].Expires.Format(exclusionDateLayout))
+
+	assert.Equal(t, `/^inc-\d+$/`, exclusions[2].Pattern)
+	assert.Empty(t, exclusions[2].Reason)
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := parseExclusionFile(strings.NewReader("ok\nlegal-hold litigation hold\n"))
+		assert.ErrorContains(t, err, `line 2: unexpected "litigation" after pattern`)
+
+		_, err = parseExclusionFile(strings.NewReader("legal-hold expires=31/03/2026\n"))
+		assert.ErrorContains(t, err, "invalid expiry date")
+
+		_, err = parseExclusionFile(strings.NewReader("tmp-[\n"))
+		assert.ErrorContains(t, err, "line 1: invalid exclusion pattern")
+	})
+}
+
+func TestBuildExclusions(t *testing.T) {
+	path := writeTestConfig(t, "exclusions.txt", "legal-hold # litigation hold\n")
+	exclusions, err := buildExclusions([]string{"tmp-*"}, path)
+	require.NoError(t, err)
+	require.Len(t, exclusions, 2)
+	assert.Equal(t, "tmp-*", exclusions[0].Pattern)
+	assert.Equal(t, "legal-hold", exclusions[1].Pattern)
+
+	_, err = buildExclusions([]string{"/inc-(/"}, "")
+	assert.ErrorContains(t, err, "invalid --exclude-pattern")
+
+	_, err = buildExclusions(nil, "missing-exclusions.txt")
+	assert.ErrorContains(t, err, "failed to read exclusion file")
+}
+
+func TestActiveExclusions(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.Local)
+	newExclusion := func(pattern, reason, expires string) slack.Exclusion {
+		var date time.Time
+		if expires != "" {
+			var err error
+			date, err = time.ParseInLocation(exclusionDateLayout, expires, time.Local)
+			require.NoError(t, err)
+		}
+		exclusion, err := slack.NewExclusion(pattern, reason, date)
+		require.NoError(t, err)
+		return exclusion
+	}
+
+	active, expired := activeExclusions([]slack.Exclusion{
+		newExclusion("legal-hold", "litigation hold", ""),
+		newExclusion("offsite-*", "event planning", "2026-05-31"),
+		newExclusion("launch-*", "", "2026-06-01"),
+	}, now)
+	require.Len(t, active, 2)
+	require.Len(t, expired, 1)
+	assert.Equal(t, "legal-hold (litigation hold)", formatExclusion(active[0]))
+	assert.Equal(t, "launch-* (until 2026-06-01)", formatExclusion(active[1]))
+	assert.Equal(t, "offsite-* (until 2026-05-31; event planning)", formatExclusion(expired[0]))
+}
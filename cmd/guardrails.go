@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/astrostl/slack-butler/pkg/logger"
+	"github.com/astrostl/slack-butler/pkg/slack"
+)
+
+// errBlastRadius is returned when a --commit run would warn or archive more
+// channels than the safety limits allow and --force wasn't given.
+var errBlastRadius = errors.New("safety limits exceeded")
+
+// blastRadiusLimits cap how many channels one channels archive --commit run
+// may warn or archive, so a misconfigured threshold can't empty a workspace.
+// Zero means no limit.
+type blastRadiusLimits struct {
+	maxWarn    int
+	maxArchive int
+	maxPercent float64
+	force      bool
+}
+
+// currentBlastRadiusLimits returns the limits set with --max-warn,
+// --max-archive, --max-percent and --force.
+func currentBlastRadiusLimits() blastRadiusLimits {
+	return blastRadiusLimits{maxWarn: maxWarn, maxArchive: maxArchive, maxPercent: maxPercent, force: force}
+}
+
+func (l blastRadiusLimits) validate() error {
+	if l.maxWarn < 0 {
+		return fmt.Errorf("max-warn must be non-negative, got %d", l.maxWarn)
+	}
+	if l.maxArchive < 0 {
+		return fmt.Errorf("max-archive must be non-negative, got %d", l.maxArchive)
+	}
+	if l.maxPercent < 0 || l.maxPercent > 100 {
+		return fmt.Errorf("max-percent must be between 0 and 100, got %g", l.maxPercent)
+	}
+	return nil
+}
+
+// exceeded describes each limit that warning warnCount and archiving
+// archiveCount of totalChannels channels would go beyond.
+func (l blastRadiusLimits) exceeded(warnCount, archiveCount, totalChannels int) []string {
+	var reasons []string
+	if l.maxWarn > 0 && warnCount > l.maxWarn {
+		reasons = append(reasons, fmt.Sprintf("%d channels to warn is more than --max-warn %d", warnCount, l.maxWarn))
+	}
+	if l.maxArchive > 0 && archiveCount > l.maxArchive {
+		reasons = append(reasons, fmt.Sprintf("%d channels to archive is more than --max-archive %d", archiveCount, l.maxArchive))
+	}
+	if l.maxPercent > 0 && totalChannels > 0 {
+		percent := float64(warnCount+archiveCount) * 100 / float64(totalChannels)
+		if percent > l.maxPercent {
+			reasons = append(reasons, fmt.Sprintf("%d of %d channels (%.1f%%) to warn or archive is more than --max-percent %g", warnCount+archiveCount, totalChannels, percent, l.maxPercent))
+		}
+	}
+	return reasons
+}
+
+// checkBlastRadius stops a live run that would warn or archive more channels
+// than limits allow, listing them so they can be reviewed, unless --force
+// was given. Dry runs only report that the limits would stop the run. now is
+// the time channels' inactivity is listed as of.
+func checkBlastRadius(limits blastRadiusLimits, toWarn, toArchive []slack.Channel, totalChannels int, isDryRun bool, now time.Time) error {
+	reasons := limits.exceeded(len(toWarn), len(toArchive), totalChannels)
+	if len(reasons) == 0 {
+		return nil
+	}
+
+	switch {
+	case isDryRun:
+		fmt.Printf("⚠️  This run exceeds the safety limits; with --commit it would stop before changing anything unless --force is given:\n")
+		printBlastRadiusReasons(reasons)
+		return nil
+	case limits.force:
+		logger.WithField("reasons", strings.Join(reasons, "; ")).Warn("Safety limits exceeded, proceeding because of --force")
+		fmt.Printf("⚠️  Safety limits exceeded, proceeding because of --force:\n")
+		printBlastRadiusReasons(reasons)
+		return nil
+	}
+
+	fmt.Printf("🛑 Stopping before changing anything, this run exceeds the safety limits:\n")
+	printBlastRadiusReasons(reasons)
+	if len(toWarn) > 0 {
+		displayChannelDetails(toWarn, "Channels that would be warned", now)
+	}
+	if len(toArchive) > 0 {
+		displayChannelDetails(toArchive, "Channels that would be archived", now)
+	}
+	return fmt.Errorf("%w: review the channels above, then raise the limits or re-run with --force", errBlastRadius)
+}
+
+func printBlastRadiusReasons(reasons []string) {
+	for _, reason := range reasons {
+		fmt.Printf("  • %s\n", reason)
+	}
+	fmt.Println()
+}
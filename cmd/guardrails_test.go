@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/astrostl/slack-butler/pkg/slack"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlastRadiusLimits(t *testing.T) {
+	assert.NoError(t, blastRadiusLimits{}.validate())
+	assert.ErrorContains(t, blastRadiusLimits{maxWarn: -1}.validate(), "max-warn")
+	assert.ErrorContains(t, blastRadiusLimits{maxArchive: -1}.validate(), "max-archive")
+	assert.ErrorContains(t, blastRadiusLimits{maxPercent: 101}.validate(), "max-percent")
+
+	assert.Empty(t, blastRadiusLimits{}.exceeded(500, 500, 1000))
+	limits := blastRadiusLimits{maxWarn: 10, maxArchive: 5, maxPercent: 20}
+	assert.Empty(t, limits.exceeded(10, 5, 100))
+	assert.Equal(t, []string{
+		"11 channels to warn is more than --max-warn 10",
+		"6 channels to archive is more than --max-archive 5",
+	}, limits.exceeded(11, 6, 100))
+	assert.Equal(t, []string{"8 of 20 channels (40.0%) to warn or archive is more than --max-percent 20"}, limits.exceeded(5, 3, 20))
+}
+
+func TestBlastRadiusStopsArchiveRun(t *testing.T) {
+	capture := func(t *testing.T, run func() error) (string, error) {
+		r, w, err := os.Pipe()
+		require.NoError(t, err)
+		oldStdout := os.Stdout
+		os.Stdout = w
+		runErr := run()
+		require.NoError(t, w.Close())
+		os.Stdout = oldStdout
+		output, err := io.ReadAll(r)
+		require.NoError(t, err)
+		return string(output), runErr
+	}
+	run := func(t *testing.T, isDryRun bool) (*slack.MockSlackAPI, string, error) {
+		mockAPI := slack.NewMockSlackAPI()
+		setupCommitModeTest(mockAPI)
+		client, err := slack.NewClientWithAPI(mockAPI)
+		require.NoError(t, err)
+		output, err := capture(t, func() error {
+			return runArchiveWithClient(context.Background(), client, 30, 7, isDryRun, "", "", 30.0/86400, 7.0/86400, false, 10, 0.9, false, 0)
+		})
+		return mockAPI, output, err
+	}
+
+	originalMaxPercent, originalForce := maxPercent, force
+	defer func() { maxPercent, force = originalMaxPercent, originalForce }()
+	// setupCommitModeTest has one channel to warn and one to archive.
+	maxPercent, force = 50, false
+
+	t.Run("Live run over the limit changes nothing", func(t *testing.T) {
+		mockAPI, output, err := run(t, false)
+		require.ErrorIs(t, err, errBlastRadius)
+		assert.Contains(t, output, "2 of 2 channels (100.0%) to warn or archive is more than --max-percent 50")
+		assert.Contains(t, output, "Channels that would be warned:\n  #warn-channel")
+		assert.Contains(t, output, "Channels that would be archived:\n  #archive-channel")
+		assert.Empty(t, mockAPI.GetPostedMessages())
+		assert.Empty(t, mockAPI.ArchivedChannels)
+	})
+
+	t.Run("Dry run reports the limits", func(t *testing.T) {
+		_, output, err := run(t, true)
+		require.NoError(t, err)
+		assert.Contains(t, output, "with --commit it would stop before changing anything unless --force is given")
+	})
+
+	t.Run("Force proceeds", func(t *testing.T) {
+		force = true
+		defer func() { force = false }()
+		mockAPI, output, err := run(t, false)
+		require.NoError(t, err)
+		assert.Contains(t, output, "proceeding because of --force")
+		assert.Equal(t, []string{"C2"}, mockAPI.ArchivedChannels)
+	})
+}
@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/astrostl/slack-butler/pkg/logger"
@@ -20,6 +22,8 @@ This command verifies:
 - Token validity and format
 - Slack API connectivity  
 - Required OAuth scopes and permissions (channels:read, channels:join, channels:manage, channels:history, chat:write, users:read)
+- Optional private channel scopes used by --include-private (groups:read, groups:history, groups:write)
+- Which scopes each subcommand needs versus what the token has been granted
 - Bot user information
 - Basic API functionality`,
 	SilenceUsage: true, // Don't show usage on errors
@@ -55,20 +59,22 @@ func runHealth(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	defer closeSlackClient(client)
+	ctx := commandContext(cmd)
 
 	// Check 4: API connectivity
-	authInfo, err := testAPIConnectivity(client)
+	authInfo, err := testAPIConnectivity(ctx, client)
 	if err != nil {
 		return err
 	}
 
 	// Check 5: OAuth scope validation
-	if err := validateOAuthScopes(client); err != nil {
+	if err := validateOAuthScopes(ctx, client); err != nil {
 		return err
 	}
 
 	// Check 6: Basic functionality test
-	testBasicFunctionalityAndReport(client)
+	testBasicFunctionalityAndReport(ctx, client)
 
 	// Success summary
 	displaySuccessSummary(authInfo)
@@ -108,7 +114,7 @@ func validateTokenFormat(token string) error {
 // createAndTestClient creates a Slack client and tests initialization.
 func createAndTestClient(token string) (*slack.Client, error) {
 	fmt.Print("✓ Slack client initialization... ")
-	client, err := slack.NewClient(token)
+	client, err := newSlackClient(token)
 	if err != nil {
 		fmt.Println("❌ FAILED")
 		fmt.Printf("  Error: %v\n", err)
@@ -119,9 +125,9 @@ func createAndTestClient(token string) (*slack.Client, error) {
 }
 
 // testAPIConnectivity tests API connectivity and returns auth info.
-func testAPIConnectivity(client *slack.Client) (*slack.AuthInfo, error) {
+func testAPIConnectivity(ctx context.Context, client *slack.Client) (*slack.AuthInfo, error) {
 	fmt.Print("✓ Slack API connectivity... ")
-	authInfo, err := client.TestAuth()
+	authInfo, err := client.TestAuth(ctx)
 	if err != nil {
 		fmt.Println("❌ FAILED")
 		fmt.Printf("  Error: %v\n", err)
@@ -138,14 +144,14 @@ func testAPIConnectivity(client *slack.Client) (*slack.AuthInfo, error) {
 }
 
 // validateOAuthScopes validates required and optional OAuth scopes.
-func validateOAuthScopes(client *slack.Client) error {
+func validateOAuthScopes(ctx context.Context, client *slack.Client) error {
 	fmt.Print("✓ OAuth scope validation (channels:read, channels:join, channels:manage, channels:history, chat:write, users:read)... ")
 	if healthVerbose {
 		fmt.Printf("\n  Testing required scopes: channels:read, channels:join, channels:manage, channels:history, chat:write, users:read\n")
 		fmt.Print("  Validation result: ")
 	}
 
-	scopes, err := client.CheckOAuthScopes()
+	scopes, err := client.CheckOAuthScopes(ctx)
 	if err != nil {
 		fmt.Println("❌ FAILED")
 		fmt.Printf("  Error: %v\n", err)
@@ -160,13 +166,19 @@ func validateOAuthScopes(client *slack.Client) error {
 		"chat:write":       true, // Required - post warning messages
 		"users:read":       true, // Required - resolve user names for message authors
 	}
-	optionalScopes := map[string]bool{}
+	optionalScopes := map[string]bool{
+		"groups:read":    true, // Optional - list private channels the bot was invited to (--include-private)
+		"groups:history": true, // Optional - check private channel activity (--include-private)
+		"groups:write":   true, // Optional - archive private channels (--include-private)
+		"reactions:read": true, // Optional - read archive approvals (--request-approval)
+	}
 
 	missingRequired, missingOptional := checkMissingScopes(scopes, requiredScopes, optionalScopes)
 
 	if len(missingRequired) > 0 {
 		displayScopeErrors(missingRequired, missingOptional)
-		return fmt.Errorf("missing required OAuth scopes")
+		displayCommandScopes(scopes)
+		return fmt.Errorf("missing required OAuth scopes %v: %w", missingRequired, slack.ErrMissingScope)
 	}
 
 	fmt.Println("✅ PASSED")
@@ -175,11 +187,35 @@ func validateOAuthScopes(client *slack.Client) error {
 	}
 
 	if len(missingOptional) > 0 {
-		fmt.Println("  ⚠️  Note: Some optional scopes are missing - private channels won't be accessible")
+		fmt.Println("  ⚠️  Note: Some optional scopes are missing - private channels or archive approvals won't be accessible")
 	}
+	displayCommandScopes(scopes)
 	return nil
 }
 
+// displayCommandScopes reports, per subcommand, which needed scopes the
+// token lacks.
+func displayCommandScopes(scopes map[string]bool) {
+	fmt.Println("✓ Command permissions:")
+	for _, req := range commandScopeRequirements {
+		dryRunMissing := missingScopes(scopes, scopesForCommand(req.command, false, false))
+		commitMissing := missingScopes(scopes, scopesForCommand(req.command, true, false))
+		switch {
+		case len(dryRunMissing) > 0:
+			fmt.Printf("  ❌ %s: missing %s\n", req.command, strings.Join(dryRunMissing, ", "))
+		case len(commitMissing) > 0:
+			fmt.Printf("  ⚠️  %s: dry run only, --commit needs %s\n", req.command, strings.Join(commitMissing, ", "))
+		default:
+			fmt.Printf("  ✅ %s\n", req.command)
+		}
+
+		privateMissing := missingScopes(scopes, append(append([]string{}, req.private...), req.privateCommit...))
+		if len(privateMissing) > 0 {
+			fmt.Printf("     --include-private needs %s\n", strings.Join(privateMissing, ", "))
+		}
+	}
+}
+
 // checkMissingScopes checks which scopes are missing.
 func checkMissingScopes(scopes map[string]bool, requiredScopes, optionalScopes map[string]bool) ([]string, []string) {
 	var missingRequired []string
@@ -210,63 +246,49 @@ func displayScopeErrors(missingRequired, missingOptional []string) {
 	if len(missingOptional) > 0 {
 		fmt.Println("  Missing OPTIONAL OAuth scopes:")
 		for _, scope := range missingOptional {
-			fmt.Printf("    - %s (private channels won't be accessible)\n", scope)
+			fmt.Printf("    - %s (%s)\n", scope, optionalScopeImpact(scope))
 		}
 	}
 	fmt.Println("  Fix: Add missing OAuth scopes in your Slack app settings at https://api.slack.com/apps")
 }
 
+// optionalScopeImpact describes what doesn't work without an optional scope.
+func optionalScopeImpact(scope string) string {
+	if scope == "reactions:read" {
+		return "archive approvals won't be readable"
+	}
+	return "private channels won't be accessible"
+}
+
 // displayScopeDetails displays detailed scope information in verbose mode.
 func displayScopeDetails(scopes map[string]bool, requiredScopes, optionalScopes map[string]bool) {
-	fmt.Println("  OAuth scope test results:")
+	fmt.Println("  OAuth scopes granted to the token (from X-OAuth-Scopes):")
 
 	// Show required scopes first
 	fmt.Println("    Required scopes:")
 	for scope := range requiredScopes {
-		status := "❌"
-		if scopes[scope] {
-			status = "✅"
-		}
-		testMethod := getScopeTestMethod(scope)
-		fmt.Printf("      %s %s - %s\n", status, scope, testMethod)
+		fmt.Printf("      %s %s\n", scopeStatus(scopes[scope]), scope)
 	}
 
 	// Show optional scopes
 	fmt.Println("    Optional scopes:")
 	for scope := range optionalScopes {
-		status := "❌"
-		if scopes[scope] {
-			status = "✅"
-		}
-		testMethod := getScopeTestMethod(scope)
-		fmt.Printf("      %s %s - %s\n", status, scope, testMethod)
+		fmt.Printf("      %s %s\n", scopeStatus(scopes[scope]), scope)
 	}
 }
 
-// getScopeTestMethod returns the test method description for a scope.
-func getScopeTestMethod(scope string) string {
-	switch scope {
-	case "channels:read":
-		return "tested with GetConversations()"
-	case "channels:join":
-		return "tested with JoinConversation()"
-	case "chat:write":
-		return "tested with PostMessage()"
-	case "channels:manage":
-		return "tested with ArchiveConversation()"
-	case "channels:history":
-		return "tested with GetConversationHistory()"
-	case "users:read":
-		return "tested with GetUsers()"
-	default:
-		return "unknown test method"
+// scopeStatus renders whether a scope is granted.
+func scopeStatus(granted bool) string {
+	if granted {
+		return "✅ granted"
 	}
+	return "❌ not granted"
 }
 
 // testBasicFunctionalityAndReport tests basic functionality and reports results.
-func testBasicFunctionalityAndReport(client *slack.Client) {
+func testBasicFunctionalityAndReport(ctx context.Context, client *slack.Client) {
 	fmt.Print("✓ Basic functionality test... ")
-	if err := testBasicFunctionality(client); err != nil {
+	if err := testBasicFunctionality(ctx, client); err != nil {
 		fmt.Println("⚠️  WARNING")
 		fmt.Printf("  Warning: %v\n", err)
 		fmt.Println("  Note: Basic connectivity works, but some features may be limited")
@@ -288,12 +310,12 @@ func isValidTokenFormat(token string) bool {
 	return len(token) > 8 && (token[:5] == "xoxb-" || token[:5] == "xoxp-" || token[:5] == "test-")
 }
 
-func testBasicFunctionality(client *slack.Client) error {
+func testBasicFunctionality(ctx context.Context, client *slack.Client) error {
 	// Test getting channel list with a reasonable timeout
 	logger.WithField("operation", "health_check").Debug("Testing basic channel listing functionality")
 
-	cutoffTime := time.Now().Add(-24 * time.Hour)
-	channels, err := client.GetNewChannels(cutoffTime)
+	cutoffTime := client.Now().Add(-24 * time.Hour)
+	channels, err := client.GetNewChannels(ctx, cutoffTime)
 	if err != nil {
 		return fmt.Errorf("channel listing test failed: %w", err)
 	}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"os"
 	"strings"
 	"testing"
@@ -93,7 +94,7 @@ func TestCheckOAuthScopes(t *testing.T) {
 		client, err := slack.NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		scopes, err := client.CheckOAuthScopes()
+		scopes, err := client.CheckOAuthScopes(context.Background())
 		require.NoError(t, err)
 
 		// The mock implementation should return true for all scopes by default
@@ -106,11 +107,11 @@ func TestCheckOAuthScopes(t *testing.T) {
 
 	t.Run("Missing users:read scope", func(t *testing.T) {
 		mockAPI := slack.NewMockSlackAPI()
-		mockAPI.SetGetUsersError("missing_scope")
+		mockAPI.RevokeScopes("users:read")
 		client, err := slack.NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		scopes, err := client.CheckOAuthScopes()
+		scopes, err := client.CheckOAuthScopes(context.Background())
 		require.NoError(t, err)
 
 		// users:read should be false due to missing scope
@@ -128,7 +129,7 @@ func TestCheckOAuthScopes(t *testing.T) {
 		// Now set auth error for the CheckOAuthScopes call
 		mockAPI.SetAuthError(true)
 
-		scopes, err := client.CheckOAuthScopes()
+		scopes, err := client.CheckOAuthScopes(context.Background())
 		assert.Error(t, err)
 		assert.Nil(t, scopes)
 		assert.Contains(t, err.Error(), "failed to authenticate")
@@ -183,7 +184,7 @@ func TestRunHealthWithMockClient(t *testing.T) {
 		client, err := slack.NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		scopes, err := client.CheckOAuthScopes()
+		scopes, err := client.CheckOAuthScopes(context.Background())
 		require.NoError(t, err)
 		assert.True(t, scopes["channels:read"])
 		assert.True(t, scopes["chat:write"])
@@ -191,11 +192,11 @@ func TestRunHealthWithMockClient(t *testing.T) {
 
 	t.Run("Health check with missing optional scopes", func(t *testing.T) {
 		mockAPI := slack.NewMockSlackAPI()
-		mockAPI.SetGetUsersError("missing_scope")
+		mockAPI.RevokeScopes("users:read")
 		client, err := slack.NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		scopes, err := client.CheckOAuthScopes()
+		scopes, err := client.CheckOAuthScopes(context.Background())
 		require.NoError(t, err)
 
 		assert.False(t, scopes["users:read"])
@@ -207,7 +208,7 @@ func TestRunHealthWithMockClient(t *testing.T) {
 		client, err := slack.NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		basicErr := testBasicFunctionality(client)
+		basicErr := testBasicFunctionality(context.Background(), client)
 		assert.NoError(t, basicErr)
 	})
 
@@ -223,12 +224,12 @@ func TestRunHealthWithMockClient(t *testing.T) {
 		require.NoError(t, err)
 
 		// Test all the components runHealth uses
-		authInfo, err := client.TestAuth()
+		authInfo, err := client.TestAuth(context.Background())
 		assert.NoError(t, err)
 		assert.NotEmpty(t, authInfo.User)
 		assert.NotEmpty(t, authInfo.Team)
 
-		scopes, err := client.CheckOAuthScopes()
+		scopes, err := client.CheckOAuthScopes(context.Background())
 		assert.NoError(t, err)
 
 		// Verify all required scopes are available
@@ -237,7 +238,7 @@ func TestRunHealthWithMockClient(t *testing.T) {
 			assert.True(t, scopes[scope], "Required scope %s should be available", scope)
 		}
 
-		err = testBasicFunctionality(client)
+		err = testBasicFunctionality(context.Background(), client)
 		assert.NoError(t, err)
 	})
 
@@ -263,13 +264,13 @@ func TestRunHealthWithMockClient(t *testing.T) {
 		}
 
 		// Test verbose auth info
-		authInfo, err := client.TestAuth()
+		authInfo, err := client.TestAuth(context.Background())
 		assert.NoError(t, err)
 		assert.NotEmpty(t, authInfo.User)
 		assert.NotEmpty(t, authInfo.Team)
 		assert.NotEmpty(t, authInfo.UserID)
 
-		scopes, err := client.CheckOAuthScopes()
+		scopes, err := client.CheckOAuthScopes(context.Background())
 		assert.NoError(t, err)
 		assert.True(t, scopes["channels:read"])
 
@@ -280,11 +281,11 @@ func TestRunHealthWithMockClient(t *testing.T) {
 func TestHealthCheckScopesErrorPaths(t *testing.T) {
 	t.Run("Missing channels:read scope", func(t *testing.T) {
 		mockAPI := slack.NewMockSlackAPI()
-		mockAPI.SetMissingScopeError(true) // This will simulate missing scope specifically
+		mockAPI.RevokeScopes("channels:read")
 		client, err := slack.NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		scopes, err := client.CheckOAuthScopes()
+		scopes, err := client.CheckOAuthScopes(context.Background())
 		require.NoError(t, err)
 
 		// Should detect missing channels:read scope
@@ -309,7 +310,7 @@ func TestHealthVerboseOutput(t *testing.T) {
 		client, err := slack.NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		scopes, err := client.CheckOAuthScopes()
+		scopes, err := client.CheckOAuthScopes(context.Background())
 		require.NoError(t, err)
 
 		// Verify that all expected scopes are present in the result
@@ -449,7 +450,7 @@ func TestAPIConnectivityAndScopes(t *testing.T) {
 		require.NoError(t, err)
 		os.Stdout = w
 
-		authInfo, err := testAPIConnectivity(client)
+		authInfo, err := testAPIConnectivity(context.Background(), client)
 
 		w.Close() // nolint:errcheck
 		os.Stdout = oldStdout
@@ -475,7 +476,7 @@ func TestAPIConnectivityAndScopes(t *testing.T) {
 		require.NoError(t, err)
 		os.Stdout = w
 
-		authInfo, err := testAPIConnectivity(client)
+		authInfo, err := testAPIConnectivity(context.Background(), client)
 
 		w.Close() // nolint:errcheck
 		os.Stdout = oldStdout
@@ -498,7 +499,7 @@ func TestAPIConnectivityAndScopes(t *testing.T) {
 		require.NoError(t, err)
 		os.Stdout = w
 
-		authInfo, err := testAPIConnectivity(client)
+		authInfo, err := testAPIConnectivity(context.Background(), client)
 
 		w.Close() // nolint:errcheck
 		os.Stdout = oldStdout
@@ -519,7 +520,7 @@ func TestAPIConnectivityAndScopes(t *testing.T) {
 		require.NoError(t, err)
 		os.Stdout = w
 
-		err = validateOAuthScopes(client)
+		err = validateOAuthScopes(context.Background(), client)
 
 		w.Close() // nolint:errcheck
 		os.Stdout = oldStdout
@@ -542,7 +543,7 @@ func TestAPIConnectivityAndScopes(t *testing.T) {
 		require.NoError(t, err)
 		os.Stdout = w
 
-		err = validateOAuthScopes(client)
+		err = validateOAuthScopes(context.Background(), client)
 
 		w.Close() // nolint:errcheck
 		os.Stdout = oldStdout
@@ -552,7 +553,7 @@ func TestAPIConnectivityAndScopes(t *testing.T) {
 
 	t.Run("validateOAuthScopes with missing scopes", func(t *testing.T) {
 		mockAPI := slack.NewMockSlackAPI()
-		mockAPI.SetMissingScopeError(true)
+		mockAPI.RevokeScopes("channels:read")
 		client, err := slack.NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
@@ -562,7 +563,7 @@ func TestAPIConnectivityAndScopes(t *testing.T) {
 		require.NoError(t, err)
 		os.Stdout = w
 
-		err = validateOAuthScopes(client)
+		err = validateOAuthScopes(context.Background(), client)
 
 		w.Close() // nolint:errcheck
 		os.Stdout = oldStdout
@@ -585,7 +586,7 @@ func TestAPIConnectivityAndScopes(t *testing.T) {
 		require.NoError(t, err)
 		os.Stdout = w
 
-		err = validateOAuthScopes(client)
+		err = validateOAuthScopes(context.Background(), client)
 
 		w.Close() // nolint:errcheck
 		os.Stdout = oldStdout
@@ -709,26 +710,6 @@ func TestScopeHelperFunctions(t *testing.T) {
 		assert.Contains(t, output, "chat:write")
 	})
 
-	t.Run("getScopeTestMethod", func(t *testing.T) {
-		tests := []struct {
-			scope    string
-			expected string
-		}{
-			{"channels:read", "tested with GetConversations()"},
-			{"channels:join", "tested with JoinConversation()"},
-			{"channels:manage", "tested with ArchiveConversation()"},
-			{"channels:history", "tested with GetConversationHistory()"},
-			{"chat:write", "tested with PostMessage()"},
-			{"users:read", "tested with GetUsers()"},
-			{"unknown:scope", "unknown test method"},
-		}
-
-		for _, test := range tests {
-			result := getScopeTestMethod(test.scope)
-			assert.Equal(t, test.expected, result)
-		}
-	})
-
 	t.Run("testBasicFunctionalityAndReport", func(t *testing.T) {
 		mockAPI := slack.NewMockSlackAPI()
 		client, err := slack.NewClientWithAPI(mockAPI)
@@ -740,7 +721,7 @@ func TestScopeHelperFunctions(t *testing.T) {
 		require.NoError(t, err)
 		os.Stdout = w
 
-		testBasicFunctionalityAndReport(client)
+		testBasicFunctionalityAndReport(context.Background(), client)
 
 		w.Close() // nolint:errcheck
 		os.Stdout = oldStdout
@@ -760,7 +741,7 @@ func TestScopeHelperFunctions(t *testing.T) {
 		require.NoError(t, err)
 		os.Stdout = w
 
-		testBasicFunctionalityAndReport(client)
+		testBasicFunctionalityAndReport(context.Background(), client)
 
 		w.Close() // nolint:errcheck
 		os.Stdout = oldStdout
@@ -843,7 +824,7 @@ func TestTestBasicFunctionality(t *testing.T) {
 		client, err := slack.NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		err = testBasicFunctionality(client)
+		err = testBasicFunctionality(context.Background(), client)
 		assert.NoError(t, err)
 	})
 
@@ -854,7 +835,7 @@ func TestTestBasicFunctionality(t *testing.T) {
 		client, err := slack.NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		err = testBasicFunctionality(client)
+		err = testBasicFunctionality(context.Background(), client)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "channel listing test failed")
 	})
@@ -889,15 +870,15 @@ func TestRunHealthWithMockSuccess(t *testing.T) {
 		require.NoError(t, err)
 
 		// Test individual components that runHealth uses
-		authInfo, err := client.TestAuth()
+		authInfo, err := client.TestAuth(context.Background())
 		assert.NoError(t, err)
 		assert.NotEmpty(t, authInfo.User)
 
-		scopes, err := client.CheckOAuthScopes()
+		scopes, err := client.CheckOAuthScopes(context.Background())
 		assert.NoError(t, err)
 		assert.True(t, scopes["channels:read"])
 
-		err = testBasicFunctionality(client)
+		err = testBasicFunctionality(context.Background(), client)
 		assert.NoError(t, err)
 	})
 }
@@ -910,12 +891,12 @@ func TestRunHealthWithMissingScopeScenarios(t *testing.T) {
 
 		// Create mock that simulates missing users:read scope
 		mockAPI := slack.NewMockSlackAPI()
-		mockAPI.SetGetUsersError("missing_scope")
+		mockAPI.RevokeScopes("users:read")
 
 		client, err := slack.NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		scopes, err := client.CheckOAuthScopes()
+		scopes, err := client.CheckOAuthScopes(context.Background())
 		assert.NoError(t, err)
 
 		// Should fail users:read scope
@@ -951,7 +932,7 @@ func TestRunHealthAPIFailureScenarios(t *testing.T) {
 		// Now set auth error for subsequent scope check calls
 		mockAPI.SetAuthError(true)
 
-		_, err = client.CheckOAuthScopes()
+		_, err = client.CheckOAuthScopes(context.Background())
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to authenticate")
 	})
@@ -966,7 +947,7 @@ func TestRunHealthAPIFailureScenarios(t *testing.T) {
 		client, err := slack.NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		err = testBasicFunctionality(client)
+		err = testBasicFunctionality(context.Background(), client)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "channel listing test failed")
 	})
@@ -1052,13 +1033,13 @@ func TestRunHealthIntegrationSuccess(t *testing.T) {
 		require.NoError(t, err)
 
 		// Test auth info retrieval
-		authInfo, err := client.TestAuth()
+		authInfo, err := client.TestAuth(context.Background())
 		assert.NoError(t, err)
 		assert.NotEmpty(t, authInfo.User)
 		assert.NotEmpty(t, authInfo.Team)
 
 		// Test OAuth scope validation
-		scopes, err := client.CheckOAuthScopes()
+		scopes, err := client.CheckOAuthScopes(context.Background())
 		assert.NoError(t, err)
 		assert.True(t, scopes["channels:read"])
 		assert.True(t, scopes["channels:join"])
@@ -1067,7 +1048,7 @@ func TestRunHealthIntegrationSuccess(t *testing.T) {
 		assert.True(t, scopes["users:read"])
 
 		// Test basic functionality
-		err = testBasicFunctionality(client)
+		err = testBasicFunctionality(context.Background(), client)
 		assert.NoError(t, err)
 
 		// Verify that all required scopes are present
@@ -1109,7 +1090,7 @@ func TestRunHealthIntegrationSuccess(t *testing.T) {
 		}
 
 		// Test auth info with verbose details
-		authInfo, err := client.TestAuth()
+		authInfo, err := client.TestAuth(context.Background())
 		assert.NoError(t, err)
 		assert.NotEmpty(t, authInfo.User)
 		assert.NotEmpty(t, authInfo.Team)
@@ -1117,7 +1098,7 @@ func TestRunHealthIntegrationSuccess(t *testing.T) {
 		// TeamID may be empty in mock - that's ok for this test
 
 		// Test scope validation with verbose details
-		scopes, err := client.CheckOAuthScopes()
+		scopes, err := client.CheckOAuthScopes(context.Background())
 		assert.NoError(t, err)
 
 		// Verify verbose scope reporting logic
@@ -1134,7 +1115,7 @@ func TestRunHealthIntegrationSuccess(t *testing.T) {
 		}
 
 		// Test basic functionality with verbose output
-		err = testBasicFunctionality(client)
+		err = testBasicFunctionality(context.Background(), client)
 		assert.NoError(t, err)
 
 		healthVerbose = false
@@ -1148,13 +1129,12 @@ func TestRunHealthMissingRequiredScopes(t *testing.T) {
 
 		// Create mock that simulates missing required scopes
 		mockAPI := slack.NewMockSlackAPI()
-		mockAPI.SetMissingScopeError(true)        // Missing channels:read
-		mockAPI.SetGetUsersError("missing_scope") // Missing users:read
+		mockAPI.RevokeScopes("channels:read", "users:read")
 
 		client, err := slack.NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		scopes, err := client.CheckOAuthScopes()
+		scopes, err := client.CheckOAuthScopes(context.Background())
 		require.NoError(t, err)
 
 		// Manually check what runHealth would do with missing required scopes
@@ -1,13 +1,20 @@
 package cmd
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/astrostl/slack-butler/pkg/slack"
+	"github.com/astrostl/slack-butler/pkg/slack/fakeslack"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRunDetectIntegration(t *testing.T) {
@@ -28,7 +35,7 @@ func TestRunDetectIntegration(t *testing.T) {
 
 		// Test getting new channels
 		since := time.Now().Add(-2 * time.Hour)
-		channels, err := client.GetNewChannels(since)
+		channels, err := client.GetNewChannels(context.Background(), since)
 		assert.NoError(t, err)
 		assert.Len(t, channels, 1)
 		assert.Equal(t, "test-channel", channels[0].Name)
@@ -70,3 +77,140 @@ func TestCommandFlags(t *testing.T) {
 		announceTo = originalAnnounceTo
 	})
 }
+
+func TestRunDetectAgainstFakeSlack(t *testing.T) {
+	server := fakeslack.New()
+	defer server.Close()
+	server.AddChannel("C001", "general", time.Now().Add(-30*24*time.Hour), "General chat")
+	server.AddChannel("C002", "fresh-project", time.Now().Add(-time.Hour), "A brand new project")
+	server.AddMember("C001", fakeslack.BotUserID)
+
+	originalSince, originalAnnounceTo, originalCommit := since, announceTo, commit
+	defer func() {
+		since, announceTo, commit = originalSince, originalAnnounceTo, originalCommit
+	}()
+
+	viper.Set("token", "MOCK-BOT-TOKEN-FOR-TESTING-ONLY-NOT-REAL-TOKEN-AT-ALL")
+	defer viper.Set("token", "")
+	t.Setenv("SLACK_API_URL", strings.TrimSuffix(server.URL(), "/"))
+	initConfig()
+
+	since = "1"
+	announceTo = "#general"
+	commit = true
+
+	err := runDetect(&cobra.Command{}, []string{})
+	require.NoError(t, err)
+
+	posted := server.PostedMessages()
+	require.Len(t, posted, 1)
+	assert.Equal(t, "C001", posted[0].ChannelID)
+	assert.Contains(t, posted[0].Text, "<#C002>")
+	assert.NotContains(t, posted[0].Text, "<#C001>")
+}
+
+func TestRecordAndReplayDetect(t *testing.T) {
+	server := fakeslack.New()
+	server.AddChannel("C001", "general", time.Now().Add(-30*24*time.Hour), "General chat")
+	server.AddChannel("C002", "fresh-project", time.Now().Add(-time.Hour), "A brand new project")
+	server.AddMember("C001", fakeslack.BotUserID)
+	path := filepath.Join(t.TempDir(), "session.json")
+
+	originalSince, originalAnnounceTo, originalCommit := since, announceTo, commit
+	defer func() {
+		since, announceTo, commit = originalSince, originalAnnounceTo, originalCommit
+		viper.Set("token", "")
+		viper.Set("record", "")
+		viper.Set("replay", "")
+	}()
+	since, announceTo, commit = "1", "#general", true
+
+	viper.Set("token", "MOCK-BOT-TOKEN-FOR-TESTING-ONLY-NOT-REAL-TOKEN-AT-ALL")
+	viper.Set("record", path)
+	t.Setenv("SLACK_API_URL", strings.TrimSuffix(server.URL(), "/"))
+	initConfig()
+
+	require.NoError(t, runDetect(&cobra.Command{}, []string{}))
+	require.Len(t, server.PostedMessages(), 1)
+	server.Close()
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "MOCK-BOT-TOKEN-FOR-TESTING-ONLY")
+
+	// Replay works offline and without a token.
+	viper.Set("token", "")
+	viper.Set("record", "")
+	viper.Set("replay", path)
+	assert.NoError(t, runDetect(&cobra.Command{}, []string{}))
+
+	t.Run("Record and replay are mutually exclusive", func(t *testing.T) {
+		viper.Set("record", path)
+		defer viper.Set("record", "")
+		err := runDetect(&cobra.Command{}, []string{})
+		assert.ErrorContains(t, err, "--record and --replay")
+	})
+}
+
+func TestRunDetectFailsFastOnMissingScope(t *testing.T) {
+	server := fakeslack.New()
+	defer server.Close()
+	server.AddChannel("C001", "general", time.Now().Add(-30*24*time.Hour), "General chat")
+	server.AddMember("C001", fakeslack.BotUserID)
+	server.SetScopes("channels:read", "channels:history")
+
+	originalSince, originalAnnounceTo, originalCommit := since, announceTo, commit
+	defer func() {
+		since, announceTo, commit = originalSince, originalAnnounceTo, originalCommit
+	}()
+
+	viper.Set("token", "MOCK-BOT-TOKEN-FOR-TESTING-ONLY-NOT-REAL-TOKEN-AT-ALL")
+	defer viper.Set("token", "")
+	t.Setenv("SLACK_API_URL", strings.TrimSuffix(server.URL(), "/"))
+	initConfig()
+
+	since, announceTo, commit = "1", "#general", true
+
+	err := runDetect(&cobra.Command{}, []string{})
+	require.ErrorIs(t, err, slack.ErrMissingScope)
+	assert.Contains(t, err.Error(), "chat:write")
+	assert.Zero(t, server.Calls("conversations.list"))
+	assert.Empty(t, server.PostedMessages())
+}
+
+func TestAuditLogDetect(t *testing.T) {
+	server := fakeslack.New()
+	defer server.Close()
+	server.AddChannel("C001", "general", time.Now().Add(-30*24*time.Hour), "General chat")
+	server.AddChannel("C002", "fresh-project", time.Now().Add(-time.Hour), "A brand new project")
+	server.AddMember("C001", fakeslack.BotUserID)
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	originalSince, originalAnnounceTo, originalCommit := since, announceTo, commit
+	defer func() {
+		since, announceTo, commit = originalSince, originalAnnounceTo, originalCommit
+		viper.Set("token", "")
+		viper.Set("audit_log", "")
+	}()
+	since, announceTo = "1", "#general"
+
+	viper.Set("token", "MOCK-BOT-TOKEN-FOR-TESTING-ONLY-NOT-REAL-TOKEN-AT-ALL")
+	viper.Set("audit_log", path)
+	t.Setenv("SLACK_API_URL", strings.TrimSuffix(server.URL(), "/"))
+	initConfig()
+
+	// Dry runs change nothing, so nothing is audited.
+	commit = false
+	require.NoError(t, runDetect(detectCmd, []string{}))
+	assert.NoFileExists(t, path)
+
+	commit = true
+	require.NoError(t, runDetect(detectCmd, []string{}))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 1)
+	assert.Contains(t, lines[0], `"command":"channels detect"`)
+	assert.Contains(t, lines[0], `"action":"post","channel_id":"C001","channel":"general","result":"ok"`)
+	assert.Contains(t, lines[0], `"actor":"`+fakeslack.BotUserID+`"`)
+}
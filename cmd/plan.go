@@ -0,0 +1,451 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/astrostl/slack-butler/pkg/logger"
+	"github.com/astrostl/slack-butler/pkg/slack"
+)
+
+// archivePlanVersion is the format version of plan files.
+const archivePlanVersion = 1
+
+// Actions a plan can hold.
+const (
+	planActionWarn    = "warn"
+	planActionArchive = "archive"
+)
+
+// archivePlan is the set of warnings and archivals a dry run of channels
+// archive decided on, written with --plan-out and carried out with --apply.
+type archivePlan struct {
+	Created        time.Time       `json:"created"`
+	TeamID         string          `json:"team_id"`
+	Team           string          `json:"team"`
+	Actions        []plannedAction `json:"actions"`
+	Version        int             `json:"version"`
+	WarnSeconds    int             `json:"warn_seconds"`
+	ArchiveSeconds int             `json:"archive_seconds"`
+	// TotalChannels is the number of channels the dry run analyzed, which
+	// --max-percent is checked against when the plan is applied.
+	TotalChannels int  `json:"total_channels"`
+	WarnOnly      bool `json:"warn_only"`
+}
+
+// plannedAction is one planned warning or archival together with the
+// evidence it was based on, which --apply checks again before acting.
+type plannedAction struct {
+	LastActivity   time.Time `json:"last_activity"`
+	WarnedAt       time.Time `json:"warned_at,omitzero"`
+	Action         string    `json:"action"`
+	ChannelID      string    `json:"channel_id"`
+	Channel        string    `json:"channel"`
+	PolicyRule     string    `json:"policy_rule,omitempty"`
+	Marker         string    `json:"marker,omitempty"`
+	KeepVoters     []string  `json:"keep_voters,omitempty"`
+	WarnSeconds    int       `json:"warn_seconds"`
+	ArchiveSeconds int       `json:"archive_seconds"`
+	IsPrivate      bool      `json:"is_private,omitempty"`
+}
+
+// newArchivePlan builds the plan for the channels an analysis of
+// totalChannels channels decided to warn and archive.
+func newArchivePlan(auth *slack.AuthInfo, toWarn, toArchive []slack.Channel, warnSeconds, archiveSeconds, totalChannels int, warnOnlyMode bool) *archivePlan {
+	plan := &archivePlan{
+		Version:        archivePlanVersion,
+		Created:        time.Now().UTC(),
+		TeamID:         auth.TeamID,
+		Team:           auth.Team,
+		WarnSeconds:    warnSeconds,
+		ArchiveSeconds: archiveSeconds,
+		TotalChannels:  totalChannels,
+		WarnOnly:       warnOnlyMode,
+		Actions:        make([]plannedAction, 0, len(toWarn)+len(toArchive)),
+	}
+	for _, channel := range toWarn {
+		plan.Actions = append(plan.Actions, newPlannedAction(planActionWarn, channel, warnSeconds, archiveSeconds))
+	}
+	if !warnOnlyMode {
+		for _, channel := range toArchive {
+			plan.Actions = append(plan.Actions, newPlannedAction(planActionArchive, channel, warnSeconds, archiveSeconds))
+		}
+	}
+	return plan
+}
+
+func newPlannedAction(action string, channel slack.Channel, warnSeconds, archiveSeconds int) plannedAction {
+	channelWarnSeconds, channelArchiveSeconds := channel.Thresholds(warnSeconds, archiveSeconds)
+	return plannedAction{
+		Action:         action,
+		ChannelID:      channel.ID,
+		Channel:        channel.Name,
+		LastActivity:   channel.LastActivity,
+		WarnedAt:       channel.WarnedAt,
+		PolicyRule:     channel.PolicyRule,
+		Marker:         channel.Marker,
+		KeepVoters:     channel.KeepVoters,
+		WarnSeconds:    channelWarnSeconds,
+		ArchiveSeconds: channelArchiveSeconds,
+		IsPrivate:      channel.IsPrivate,
+	}
+}
+
+// channel returns the channel to pass to the warning and archival calls.
+func (a plannedAction) channel() slack.Channel {
+	return slack.Channel{
+		ID:             a.ChannelID,
+		Name:           a.Channel,
+		LastActivity:   a.LastActivity,
+		WarnedAt:       a.WarnedAt,
+		PolicyRule:     a.PolicyRule,
+		Marker:         a.Marker,
+		KeepVoters:     a.KeepVoters,
+		WarnSeconds:    a.WarnSeconds,
+		ArchiveSeconds: a.ArchiveSeconds,
+		IsPrivate:      a.IsPrivate,
+	}
+}
+
+// hasPrivateChannels reports whether any planned action is on a private
+// channel.
+func (p *archivePlan) hasPrivateChannels() bool {
+	for _, action := range p.Actions {
+		if action.IsPrivate {
+			return true
+		}
+	}
+	return false
+}
+
+// writeArchivePlan writes plan as JSON. Plans name channels and the members
+// who voted to keep them, so the file is created owner-only.
+func writeArchivePlan(path string, plan *archivePlan) error {
+	data, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode plan: %w", err)
+	}
+	if err := os.WriteFile(filepath.Clean(path), append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write plan file %s: %w", path, err)
+	}
+	return nil
+}
+
+// loadArchivePlan reads a plan written by writeArchivePlan.
+func loadArchivePlan(path string) (*archivePlan, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plan file %s: %w", path, err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var plan archivePlan
+	if err := decoder.Decode(&plan); err != nil {
+		return nil, fmt.Errorf("invalid plan file %s: %w", path, err)
+	}
+	if err := plan.validate(); err != nil {
+		return nil, fmt.Errorf("invalid plan file %s: %w", path, err)
+	}
+	return &plan, nil
+}
+
+// validate checks the plan can be applied by this version.
+func (p *archivePlan) validate() error {
+	if p.Version != archivePlanVersion {
+		return fmt.Errorf("unsupported version %d (expected %d)", p.Version, archivePlanVersion)
+	}
+	if p.TeamID == "" {
+		return errors.New("missing team_id")
+	}
+	for i, action := range p.Actions {
+		if action.ChannelID == "" || action.Channel == "" {
+			return fmt.Errorf("action %d: missing channel_id or channel", i+1)
+		}
+		switch action.Action {
+		case planActionWarn:
+		case planActionArchive:
+			if p.WarnOnly {
+				return fmt.Errorf("action %d: archive in a warn-only plan", i+1)
+			}
+		default:
+			return fmt.Errorf("action %d: unknown action %q", i+1, action.Action)
+		}
+	}
+	return nil
+}
+
+// channels returns the channels the plan warns and archives.
+func (p *archivePlan) channels() (toWarn, toArchive []slack.Channel) {
+	for _, action := range p.Actions {
+		if action.Action == planActionArchive {
+			toArchive = append(toArchive, action.channel())
+		} else {
+			toWarn = append(toWarn, action.channel())
+		}
+	}
+	return toWarn, toArchive
+}
+
+// writePlanFromAnalysis writes the plan for an analysis to path and tells
+// the user how to apply it.
+func writePlanFromAnalysis(ctx context.Context, client *slack.Client, path string, toWarn, toArchive []slack.Channel, warnSeconds, archiveSeconds, totalChannels int, warnOnlyMode bool) error {
+	auth, err := client.TestAuth(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace info: %w", err)
+	}
+	plan := newArchivePlan(auth, toWarn, toArchive, warnSeconds, archiveSeconds, totalChannels, warnOnlyMode)
+	if err := writeArchivePlan(path, plan); err != nil {
+		return err
+	}
+	fmt.Printf("📝 Wrote plan with %d actions to %s\n", len(plan.Actions), path)
+	fmt.Printf("   Review it, then carry it out with: channels archive --apply %s --commit\n", path)
+	return nil
+}
+
+// checkPlannedAction compares a channel's current state with the evidence
+// its planned action was based on and returns why the action no longer
+// applies, or "" if nothing changed.
+func checkPlannedAction(action plannedAction, state *slack.ChannelState, now time.Time) string {
+	switch {
+	case state.IsArchived:
+		return "already archived"
+	case state.Name != action.Channel:
+		return fmt.Sprintf("renamed to #%s", state.Name)
+	case state.LastActivity.After(action.LastActivity):
+		return fmt.Sprintf("new activity at %s", state.LastActivity.Local().Format("2006-01-02 15:04"))
+	case !state.WarnedAt.Equal(action.WarnedAt) && state.WarnedAt.IsZero():
+		return "warning no longer found"
+	case !state.WarnedAt.Equal(action.WarnedAt):
+		return fmt.Sprintf("warned again at %s", state.WarnedAt.Local().Format("2006-01-02 15:04"))
+	case state.SnoozedUntil.After(now):
+		return fmt.Sprintf("snoozed until %s", state.SnoozedUntil.Local().Format("2006-01-02 15:04"))
+	case state.Marker != action.Marker:
+		return fmt.Sprintf("marker changed from %q to %q", action.Marker, state.Marker)
+	case action.Action == planActionArchive && len(state.KeepVoters) > 0:
+		return "members voted to keep it"
+	}
+	return ""
+}
+
+// applyRunResults records what applying a plan did.
+type applyRunResults struct {
+	archiveRunResults
+	archivedChannels []slack.Channel
+	drifted          []string
+}
+
+// appliedPlan is the record of what committed --apply runs of a plan
+// archived, kept next to the plan so unarchive --plan restores only those
+// channels and not archivals that were skipped or failed.
+type appliedPlan struct {
+	TeamID   string            `json:"team_id"`
+	Archived []appliedArchival `json:"archived"`
+}
+
+// appliedArchival is one channel archived by applying a plan.
+type appliedArchival struct {
+	Time      time.Time `json:"time"`
+	ChannelID string    `json:"channel_id"`
+	Channel   string    `json:"channel"`
+}
+
+// appliedPlanPath returns where the record of applying the plan at path is
+// kept.
+func appliedPlanPath(path string) string {
+	return strings.TrimSuffix(path, filepath.Ext(path)) + ".applied.json"
+}
+
+// recordAppliedArchivals adds the channels an --apply run of the plan at
+// path archived to its applied record, creating it owner-only if needed.
+func recordAppliedArchivals(path, teamID string, archived []slack.Channel, now time.Time) error {
+	record, err := loadAppliedPlan(path)
+	if errors.Is(err, os.ErrNotExist) {
+		record, err = &appliedPlan{TeamID: teamID}, nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, channel := range archived {
+		record.Archived = append(record.Archived, appliedArchival{Time: now.UTC(), ChannelID: channel.ID, Channel: channel.Name})
+	}
+
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode applied plan record: %w", err)
+	}
+	if err := os.WriteFile(filepath.Clean(appliedPlanPath(path)), append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write applied plan record %s: %w", appliedPlanPath(path), err)
+	}
+	return nil
+}
+
+// loadAppliedPlan reads the applied record of the plan at path. The error
+// wraps os.ErrNotExist when the plan was never applied with --commit.
+func loadAppliedPlan(path string) (*appliedPlan, error) {
+	recordPath := appliedPlanPath(path)
+	data, err := os.ReadFile(filepath.Clean(recordPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read applied plan record %s: %w", recordPath, err)
+	}
+	var record appliedPlan
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("invalid applied plan record %s: %w", recordPath, err)
+	}
+	return &record, nil
+}
+
+// runApplyPlan carries out the plan in path, or shows what it would do in
+// dry run mode.
+func runApplyPlan(ctx context.Context, client *slack.Client, path string, isDryRun bool) error {
+	plan, err := loadArchivePlan(path)
+	if err != nil {
+		return err
+	}
+	if err := requireCommandScopes(ctx, client, "channels archive", !isDryRun, plan.hasPrivateChannels()); err != nil {
+		return err
+	}
+	return runApplyPlanWithClient(ctx, client, plan, path, isDryRun)
+}
+
+// runApplyPlanWithClient checks each planned action against the channel's
+// current state and carries out those whose channel is unchanged, skipping
+// and reporting the rest. The channels it archives are added to the applied
+// record of the plan at path, unless path is empty.
+func runApplyPlanWithClient(ctx context.Context, client *slack.Client, plan *archivePlan, path string, isDryRun bool) error {
+	if client == nil {
+		return fmt.Errorf("client cannot be nil")
+	}
+
+	auth, err := client.TestAuth(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace info: %w", err)
+	}
+	fmt.Printf("Workspace: %s (%s)\n\n", auth.Team, auth.WorkspaceURL)
+	if auth.TeamID != plan.TeamID {
+		return fmt.Errorf("plan was made for workspace %s (%s), not %s (%s)", plan.Team, plan.TeamID, auth.Team, auth.TeamID)
+	}
+
+	fmt.Printf("📝 Applying plan created %s (%s ago) with %d actions\n\n", plan.Created.Local().Format("2006-01-02 15:04"), formatDuration(client.Now().Sub(plan.Created).Round(time.Second)), len(plan.Actions))
+	// Approval and the safety limits apply to a plan as they do to the run
+	// that made it
+	plan, unapproved, err := gatePlanOnApproval(ctx, client, currentApprovalSettings(), plan)
+	if err != nil {
+		return err
+	}
+	toWarn, toArchive := plan.channels()
+	if err := checkBlastRadius(currentBlastRadiusLimits(), toWarn, toArchive, plan.TotalChannels, isDryRun, client.Now()); err != nil {
+		return err
+	}
+
+	if isDryRun {
+		fmt.Printf("--- DRY RUN ---\n")
+	}
+	displayExportDir(client, len(toArchive), isDryRun)
+
+	results := &applyRunResults{}
+	discussionChannelID := resolveDiscussionChannelID(ctx, client)
+	for i, action := range plan.Actions {
+		if ctx.Err() != nil {
+			for _, rest := range plan.Actions[i:] {
+				results.notProcessed = append(results.notProcessed, rest.Channel)
+			}
+			break
+		}
+		applyPlannedAction(ctx, client, plan, action, discussionChannelID, isDryRun, results)
+	}
+	if path != "" && len(results.archivedChannels) > 0 {
+		if err := recordAppliedArchivals(path, plan.TeamID, results.archivedChannels, client.Now()); err != nil {
+			logger.WithField("error", err.Error()).Error("Failed to record applied plan")
+			fmt.Printf("⚠️  %s; unarchive --plan won't find these archivals, use --run-id instead\n", err.Error())
+		}
+	}
+
+	if isDryRun {
+		fmt.Printf("--- END DRY RUN ---\n\n")
+	} else {
+		fmt.Println()
+	}
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		displayInterruptedSummary(&results.archiveRunResults)
+		return fmt.Errorf("apply interrupted: %w", ctxErr)
+	}
+
+	fmt.Printf("Plan Results:\n")
+	if !isDryRun {
+		displaySummaryLine("Warned", results.warned)
+		displaySummaryLine("Archived", results.archived)
+		displaySummaryLine("Failed", results.failed)
+	}
+	displaySummaryLine("Skipped (changed since the plan)", results.drifted)
+	if len(unapproved) > 0 {
+		displaySummaryLine("Skipped (not approved)", unapproved)
+	}
+	fmt.Println()
+	if isDryRun {
+		fmt.Printf("To actually apply this plan, add --commit to your command\n")
+	}
+	return nil
+}
+
+// applyPlannedAction re-validates one planned action and carries it out.
+func applyPlannedAction(ctx context.Context, client *slack.Client, plan *archivePlan, action plannedAction, discussionChannelID string, isDryRun bool, results *applyRunResults) {
+	state, err := client.GetChannelState(ctx, action.ChannelID)
+	if err != nil {
+		fmt.Printf("  ⏭️  Skipped #%s: could not check its current state: %s\n", action.Channel, err.Error())
+		results.drifted = append(results.drifted, action.Channel)
+		return
+	}
+	if reason := checkPlannedAction(action, state, client.Now()); reason != "" {
+		logger.WithFields(logger.LogFields{
+			"channel": action.Channel,
+			"action":  action.Action,
+			"reason":  reason,
+		}).Info("Skipping planned action, channel changed since the plan")
+		fmt.Printf("  ⏭️  Skipped #%s (%s): %s\n", action.Channel, action.Action, reason)
+		results.drifted = append(results.drifted, action.Channel)
+		return
+	}
+
+	if isDryRun {
+		fmt.Printf("  Would %s #%s\n", action.Action, action.Channel)
+		return
+	}
+
+	channel := action.channel()
+	switch {
+	case action.Action == planActionArchive:
+		err = client.ArchiveChannelWithThresholds(ctx, channel, plan.WarnSeconds, plan.ArchiveSeconds)
+	case plan.WarnOnly:
+		err = client.WarnInactiveChannelWarnOnly(ctx, channel, plan.WarnSeconds, plan.ArchiveSeconds, discussionChannelID)
+	default:
+		err = client.WarnInactiveChannel(ctx, channel, plan.WarnSeconds, plan.ArchiveSeconds, discussionChannelID)
+	}
+	if err != nil {
+		logger.WithFields(logger.LogFields{
+			"channel": action.Channel,
+			"action":  action.Action,
+			"error":   err.Error(),
+		}).Error("Failed to apply planned action")
+		fmt.Printf("  Failed to %s #%s: %s\n", action.Action, action.Channel, err.Error())
+		results.failed = append(results.failed, action.Channel)
+		return
+	}
+
+	if action.Action == planActionArchive {
+		results.archived = append(results.archived, action.Channel)
+		results.archivedChannels = append(results.archivedChannels, channel)
+		fmt.Printf("  ✓ Archived #%s\n", action.Channel)
+	} else {
+		results.warned = append(results.warned, action.Channel)
+		fmt.Printf("  ✓ Warned #%s\n", action.Channel)
+	}
+}
@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/astrostl/slack-butler/pkg/slack"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchivePlanFile(t *testing.T) {
+	const day = 24 * 60 * 60
+	lastActivity := time.Date(2026, 8, 1, 12, 0, 0, 0, time.UTC)
+	warnedAt := time.Date(2026, 9, 15, 9, 30, 0, 0, time.UTC)
+	auth := &slack.AuthInfo{Team: "Test Team", TeamID: "T0000000"}
+	toWarn := []slack.Channel{{ID: "C1", Name: "quiet", LastActivity: lastActivity, PolicyRule: "glob tmp-*", WarnSeconds: 7 * day}}
+	toArchive := []slack.Channel{{ID: "C2", Name: "stale", LastActivity: warnedAt, WarnedAt: warnedAt}}
+
+	plan := newArchivePlan(auth, toWarn, toArchive, 45*day, 30*day, 10, false)
+	require.Len(t, plan.Actions, 2)
+	assert.Equal(t, planActionWarn, plan.Actions[0].Action)
+	assert.Equal(t, 7*day, plan.Actions[0].WarnSeconds)
+	assert.Equal(t, 30*day, plan.Actions[0].ArchiveSeconds)
+	assert.Equal(t, planActionArchive, plan.Actions[1].Action)
+	assert.Len(t, newArchivePlan(auth, toWarn, toArchive, 45*day, 30*day, 10, true).Actions, 1)
+
+	path := filepath.Join(t.TempDir(), "plan.json")
+	require.NoError(t, writeArchivePlan(path, plan))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	loaded, err := loadArchivePlan(path)
+	require.NoError(t, err)
+	assert.Equal(t, "T0000000", loaded.TeamID)
+	assert.Equal(t, 10, loaded.TotalChannels)
+	require.Len(t, loaded.Actions, 2)
+	assert.True(t, loaded.Actions[0].WarnedAt.IsZero())
+	assert.True(t, loaded.Actions[1].WarnedAt.Equal(warnedAt))
+	assert.Equal(t, "glob tmp-*", loaded.Actions[0].channel().PolicyRule)
+
+	t.Run("Invalid plans", func(t *testing.T) {
+		for name, content := range map[string]string{
+			"unsupported version": `{"version": 2, "team_id": "T1", "actions": []}`,
+			"unknown action":      `{"version": 1, "team_id": "T1", "actions": [{"action": "delete", "channel_id": "C1", "channel": "quiet"}]}`,
+			"archive in a warn-only plan": `{"version": 1, "team_id": "T1", "warn_only": true,
+				"actions": [{"action": "archive", "channel_id": "C1", "channel": "quiet"}]}`,
+			"unknown field":   `{"version": 1, "team_id": "T1", "actions": [], "extra": true}`,
+			"missing team_id": `{"version": 1, "actions": []}`,
+		} {
+			path := filepath.Join(t.TempDir(), "plan.json")
+			require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+			_, err := loadArchivePlan(path)
+			assert.ErrorContains(t, err, "invalid plan file", name)
+		}
+
+		_, err := loadArchivePlan(filepath.Join(t.TempDir(), "missing.json"))
+		assert.ErrorContains(t, err, "failed to read plan file")
+	})
+}
+
+func TestCheckPlannedAction(t *testing.T) {
+	now := time.Now()
+	lastActivity := now.Add(-60 * 24 * time.Hour).Truncate(time.Second)
+	warnedAt := now.Add(-40 * 24 * time.Hour).Truncate(time.Second)
+	action := plannedAction{Action: planActionArchive, ChannelID: "C1", Channel: "stale", LastActivity: warnedAt, WarnedAt: warnedAt}
+	unchanged := func() *slack.ChannelState {
+		return &slack.ChannelState{Name: "stale", LastActivity: warnedAt.In(time.Local), WarnedAt: warnedAt}
+	}
+
+	assert.Empty(t, checkPlannedAction(action, unchanged(), now))
+
+	tests := []struct {
+		change func(*slack.ChannelState)
+		name   string
+		reason string
+	}{
+		{func(s *slack.ChannelState) { s.IsArchived = true }, "archived", "already archived"},
+		{func(s *slack.ChannelState) { s.Name = "renamed" }, "renamed", "renamed to #renamed"},
+		{func(s *slack.ChannelState) { s.LastActivity = now }, "new activity", "new activity at"},
+		{func(s *slack.ChannelState) { s.WarnedAt = time.Time{}; s.LastActivity = lastActivity }, "warning gone", "warning no longer found"},
+		{func(s *slack.ChannelState) { s.WarnedAt = warnedAt.Add(-time.Hour) }, "other warning", "warned again at"},
+		{func(s *slack.ChannelState) { s.SnoozedUntil = now.Add(time.Hour) }, "snoozed", "snoozed until"},
+		{func(s *slack.ChannelState) { s.Marker = "[butler:keep]" }, "marker", `marker changed from "" to "[butler:keep]"`},
+		{func(s *slack.ChannelState) { s.KeepVoters = []string{"Alice"} }, "keep vote", "members voted to keep it"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := unchanged()
+			tt.change(state)
+			assert.Contains(t, checkPlannedAction(action, state, now), tt.reason)
+		})
+	}
+
+	t.Run("Keep votes don't stop a planned warning", func(t *testing.T) {
+		warn := action
+		warn.Action = planActionWarn
+		state := unchanged()
+		state.KeepVoters = []string{"Alice"}
+		assert.Empty(t, checkPlannedAction(warn, state, now))
+	})
+}
+
+func TestPlanAndApply(t *testing.T) {
+	ts := func(t time.Time) string { return fmt.Sprintf("%.6f", float64(t.Unix())) }
+	capture := func(t *testing.T, run func() error) (string, error) {
+		r, w, err := os.Pipe()
+		require.NoError(t, err)
+		oldStdout := os.Stdout
+		os.Stdout = w
+		runErr := run()
+		require.NoError(t, w.Close())
+		os.Stdout = oldStdout
+		output, err := io.ReadAll(r)
+		require.NoError(t, err)
+		return string(output), runErr
+	}
+	setup := func(t *testing.T) (*slack.MockSlackAPI, *slack.Client, *archivePlan) {
+		mockAPI := slack.NewMockSlackAPI()
+		setupCommitModeTest(mockAPI)
+		mockAPI.AddChannel("C3", "also-stale", time.Now().Add(-2*time.Hour), "")
+		mockAPI.AddMessageToHistory("C3", "old message", "U1234567", ts(time.Now().Add(-35*time.Second)))
+		client, err := slack.NewClientWithAPI(mockAPI)
+		require.NoError(t, err)
+
+		path := filepath.Join(t.TempDir(), "plan.json")
+		planOut = path
+		defer func() { planOut = "" }()
+		output, err := capture(t, func() error {
+			return runArchiveWithClient(context.Background(), client, 30, 7, true, "", "", 30.0/86400, 7.0/86400, false, 10, 0.9, false, 0)
+		})
+		require.NoError(t, err)
+		assert.Contains(t, output, "Wrote plan with 3 actions to "+path)
+		assert.Empty(t, mockAPI.GetPostedMessages())
+
+		plan, err := loadArchivePlan(path)
+		require.NoError(t, err)
+		return mockAPI, client, plan
+	}
+
+	t.Run("Dry run applies nothing", func(t *testing.T) {
+		mockAPI, client, plan := setup(t)
+		output, err := capture(t, func() error { return runApplyPlanWithClient(context.Background(), client, plan, "", true) })
+		require.NoError(t, err)
+		assert.Contains(t, output, "Would warn #warn-channel")
+		assert.Contains(t, output, "Would archive #archive-channel")
+		assert.Empty(t, mockAPI.GetPostedMessages())
+		assert.Empty(t, mockAPI.ArchivedChannels)
+	})
+
+	t.Run("Drifted channels are skipped", func(t *testing.T) {
+		mockAPI, client, plan := setup(t)
+		mockAPI.AddMessageToHistory("C3", "back again", "U1234567", ts(time.Now()))
+
+		output, err := capture(t, func() error { return runApplyPlanWithClient(context.Background(), client, plan, "", false) })
+		require.NoError(t, err)
+		assert.Contains(t, output, "Skipped #also-stale (warn): new activity at")
+		assert.Contains(t, output, "Skipped (changed since the plan): 1 (#also-stale)")
+		assert.Equal(t, []string{"C2"}, mockAPI.ArchivedChannels)
+		posted := mockAPI.GetPostedMessages()
+		require.Len(t, posted, 2)
+		assert.Equal(t, "C1", posted[0].ChannelID)
+	})
+
+	t.Run("Safety limits apply to plans", func(t *testing.T) {
+		original := currentBlastRadiusLimits()
+		defer func() {
+			maxWarn, maxArchive, maxPercent, force = original.maxWarn, original.maxArchive, original.maxPercent, original.force
+		}()
+		maxArchive = 0
+		maxPercent = 50
+
+		mockAPI, client, plan := setup(t)
+		assert.Positive(t, plan.TotalChannels)
+		output, err := capture(t, func() error { return runApplyPlanWithClient(context.Background(), client, plan, "", false) })
+		require.ErrorIs(t, err, errBlastRadius)
+		assert.Contains(t, output, "Stopping before changing anything")
+		assert.Empty(t, mockAPI.GetPostedMessages())
+		assert.Empty(t, mockAPI.ArchivedChannels)
+
+		force = true
+		_, err = capture(t, func() error { return runApplyPlanWithClient(context.Background(), client, plan, "", false) })
+		require.NoError(t, err)
+		assert.Equal(t, []string{"C2"}, mockAPI.ArchivedChannels)
+	})
+
+	t.Run("Unarchive restores only what applying the plan archived", func(t *testing.T) {
+		mockAPI, client, plan := setup(t)
+		path := filepath.Join(t.TempDir(), "plan.json")
+		_, err := archivedInPlan(path)
+		assert.ErrorContains(t, err, "was never applied with --commit")
+
+		// A channel archived by someone else since the plan is skipped by
+		// --apply and must not be restored by unarchive --plan.
+		plan.Actions = append(plan.Actions, plannedAction{Action: planActionArchive, ChannelID: "C9", Channel: "archived-by-hand"})
+		_, err = capture(t, func() error { return runApplyPlanWithClient(context.Background(), client, plan, path, false) })
+		require.NoError(t, err)
+		assert.Equal(t, []string{"C2"}, mockAPI.ArchivedChannels)
+
+		targets, err := archivedInPlan(path)
+		require.NoError(t, err)
+		assert.Equal(t, "T0000000", targets.teamID)
+		assert.Equal(t, []slack.Channel{{ID: "C2", Name: "archive-channel"}}, targets.channels)
+		info, err := os.Stat(appliedPlanPath(path))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	})
+
+	t.Run("Plan for another workspace", func(t *testing.T) {
+		_, client, plan := setup(t)
+		plan.TeamID = "T9999999"
+		_, err := capture(t, func() error { return runApplyPlanWithClient(context.Background(), client, plan, "", false) })
+		assert.ErrorContains(t, err, "plan was made for workspace")
+	})
+}
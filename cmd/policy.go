@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/astrostl/slack-butler/pkg/slack"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/spf13/viper"
+)
+
+// loadArchivePolicy reads the archive policy rules from a YAML, TOML or JSON
+// file, e.g.
+//
+//	rules:
+//	  - glob: tmp-*
+//	    warn-days: 7
+//	    archive-days: 3
+//	  - prefix: team-
+//	    never: true
+func loadArchivePolicy(path string) (*slack.ArchivePolicy, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read policy file %s: %w", path, err)
+	}
+
+	var rules []slack.PolicyRule
+	if err := v.UnmarshalKey("rules", &rules, viper.DecoderConfigOption(func(dc *mapstructure.DecoderConfig) {
+		dc.ErrorUnused = true
+	})); err != nil {
+		return nil, fmt.Errorf("invalid policy file %s: %w", path, err)
+	}
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("invalid policy file %s: no rules found", path)
+	}
+
+	policy, err := slack.NewArchivePolicy(rules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy file %s: %w", path, err)
+	}
+	return policy, nil
+}
+
+// displayArchivePolicy lists the policy rules in match order.
+func displayArchivePolicy(policy *slack.ArchivePolicy, warnDays, archiveDays float64, warnOnlyMode bool) {
+	rules := policy.Rules()
+	if len(rules) == 0 {
+		return
+	}
+
+	fmt.Printf("📜 Archive policy (first matching rule applies):\n")
+	for i := range rules {
+		fmt.Printf("   %d. %s: %s\n", i+1, rules[i].Describe(), describePolicyThresholds(&rules[i], warnDays, archiveDays, warnOnlyMode))
+	}
+	fmt.Printf("   Other channels: %s\n\n", describeThresholds(warnDays, archiveDays, warnOnlyMode))
+}
+
+// describePolicyThresholds summarizes what a rule does to matching channels.
+func describePolicyThresholds(rule *slack.PolicyRule, warnDays, archiveDays float64, warnOnlyMode bool) string {
+	if rule.Never {
+		return "never archived"
+	}
+	if rule.WarnDays > 0 {
+		warnDays = rule.WarnDays
+	}
+	if rule.ArchiveDays > 0 {
+		archiveDays = rule.ArchiveDays
+	}
+	return describeThresholds(warnDays, archiveDays, warnOnlyMode)
+}
+
+// describeThresholds summarizes warn and archive thresholds given in days.
+func describeThresholds(warnDays, archiveDays float64, warnOnlyMode bool) string {
+	if warnOnlyMode {
+		return fmt.Sprintf("warn at %s days", formatDays(warnDays))
+	}
+	return fmt.Sprintf("warn at %s days, archive %s days later", formatDays(warnDays), formatDays(archiveDays))
+}
+
+// formatPolicyMatch describes the policy rule or channel marker applied to
+// channel, for the per-channel dry run output.
+func formatPolicyMatch(channel slack.Channel) string {
+	label, rule := "policy", channel.PolicyRule
+	if channel.Marker != "" {
+		label, rule = "marker", channel.Marker
+	}
+	if rule == "" {
+		return ""
+	}
+	var thresholds []string
+	if channel.WarnSeconds > 0 {
+		thresholds = append(thresholds, "warn "+formatDays(float64(channel.WarnSeconds)/(24*60*60))+"d")
+	}
+	if channel.ArchiveSeconds > 0 {
+		thresholds = append(thresholds, "archive "+formatDays(float64(channel.ArchiveSeconds)/(24*60*60))+"d")
+	}
+	return fmt.Sprintf(" [%s: %s; %s]", label, rule, strings.Join(thresholds, ", "))
+}
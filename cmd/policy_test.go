@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/astrostl/slack-butler/pkg/slack"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadArchivePolicy(t *testing.T) {
+	t.Run("YAML", func(t *testing.T) {
+		path := writeTestConfig(t, "policy.yaml", `
+rules:
+  - name: temporary
+    glob: tmp-*
+    warn-days: 7
+    archive-days: 3
+  - prefix: team-
+    never: true
+  - max-members: 2
+    warn-days: 14
+`)
+		policy, err := loadArchivePolicy(path)
+		require.NoError(t, err)
+		rules := policy.Rules()
+		require.Len(t, rules, 3)
+		assert.Equal(t, "temporary", rules[0].Describe())
+		assert.True(t, rules[1].Never)
+		assert.Equal(t, "members <= 2", rules[2].Describe())
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		path := writeTestConfig(t, "policy.json", `{"rules": [{"regex": "^inc-\\d+$", "archive-days": 5}]}`)
+		policy, err := loadArchivePolicy(path)
+		require.NoError(t, err)
+		assert.Equal(t, 5.0, policy.Rules()[0].ArchiveDays)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := loadArchivePolicy(writeTestConfig(t, "policy.yaml", "rules:\n  - glob: tmp-*\n    warn-dys: 7\n"))
+		assert.ErrorContains(t, err, "warn-dys")
+
+		_, err = loadArchivePolicy(writeTestConfig(t, "policy.yaml", "rules: []\n"))
+		assert.ErrorContains(t, err, "no rules found")
+
+		_, err = loadArchivePolicy(writeTestConfig(t, "policy.yaml", "rules:\n  - glob: tmp-*\n"))
+		assert.ErrorContains(t, err, "policy rule 1 (glob tmp-*)")
+
+		_, err = loadArchivePolicy("missing-policy.yaml")
+		assert.ErrorContains(t, err, "failed to read policy file")
+	})
+}
+
+func TestFormatPolicyMatch(t *testing.T) {
+	assert.Empty(t, formatPolicyMatch(slack.Channel{Name: "misc"}))
+	assert.Equal(t, " [policy: temporary; warn 7d, archive 3d]",
+		formatPolicyMatch(slack.Channel{Name: "tmp-x", PolicyRule: "temporary", WarnSeconds: 7 * 24 * 60 * 60, ArchiveSeconds: 3 * 24 * 60 * 60}))
+	assert.Equal(t, " [policy: glob proj-*; archive 30d]",
+		formatPolicyMatch(slack.Channel{Name: "proj-x", PolicyRule: "glob proj-*", ArchiveSeconds: 30 * 24 * 60 * 60}))
+	assert.Equal(t, " [marker: [butler:archive-after=120d]; warn 120d]",
+		formatPolicyMatch(slack.Channel{Name: "proj-y", Marker: "[butler:archive-after=120d]", WarnSeconds: 120 * 24 * 60 * 60}))
+}
+
+func TestDescribePolicyThresholds(t *testing.T) {
+	assert.Equal(t, "never archived", describePolicyThresholds(&slack.PolicyRule{Never: true}, 45, 30, false))
+	assert.Equal(t, "warn at 7 days, archive 30 days later", describePolicyThresholds(&slack.PolicyRule{WarnDays: 7}, 45, 30, false))
+	assert.Equal(t, "warn at 45 days", describePolicyThresholds(&slack.PolicyRule{ArchiveDays: 3}, 45, 30, true))
+}
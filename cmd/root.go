@@ -2,12 +2,20 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"os/user"
+	"strings"
+	"syscall"
 
 	"github.com/astrostl/slack-butler/pkg/logger"
+	"github.com/astrostl/slack-butler/pkg/slack"
 
 	"github.com/sirupsen/logrus"
+	slackapi "github.com/slack-go/slack"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -22,6 +30,17 @@ var (
 // Constants for version info.
 const unknownValue = "unknown"
 
+// Process exit codes, so scripts can react to specific Slack failures.
+const (
+	exitGeneric         = 1
+	exitInvalidAuth     = 2
+	exitMissingScope    = 3
+	exitRateLimited     = 4
+	exitChannelNotFound = 5
+	exitChannelArchived = 6
+	exitInterrupted     = 130 // conventional 128 + SIGINT
+)
+
 var rootCmd = &cobra.Command{
 	Use:   "slack-butler",
 	Short: "A CLI tool to help manage Slack workspaces",
@@ -47,6 +66,9 @@ Note: this tool was vibe coded with generative AI.`,
 			return
 		}
 	},
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return loadConfigFile(cmd)
+	},
 	CompletionOptions: cobra.CompletionOptions{
 		DisableDefaultCmd: true,
 	},
@@ -58,9 +80,41 @@ func Execute(ver, build, commit string) {
 	buildTime = build
 	gitCommit = commit
 
-	if err := rootCmd.Execute(); err != nil {
+	// Cancel the command's context on Ctrl-C or SIGTERM so in-flight Slack
+	// calls and rate-limit waits stop and commands can report partial results.
+	// Once cancelled, default signal handling is restored so a second
+	// interrupt terminates immediately.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
+	if err != nil {
 		// Cobra already displays the error, no need to log it again
-		os.Exit(1)
+		os.Exit(exitCodeForError(err))
+	}
+}
+
+// exitCodeForError maps a command error to the process exit code.
+func exitCodeForError(err error) int {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return exitInterrupted
+	case errors.Is(err, slack.ErrInvalidAuth):
+		return exitInvalidAuth
+	case errors.Is(err, slack.ErrMissingScope):
+		return exitMissingScope
+	case errors.Is(err, slack.ErrRateLimited):
+		return exitRateLimited
+	case errors.Is(err, slack.ErrChannelNotFound):
+		return exitChannelNotFound
+	case errors.Is(err, slack.ErrChannelArchived):
+		return exitChannelArchived
+	default:
+		return exitGeneric
 	}
 }
 
@@ -70,6 +124,15 @@ func init() {
 	// Global flags
 	rootCmd.PersistentFlags().String("token", "", "Slack bot token (can also be set via SLACK_TOKEN env var)")
 	rootCmd.PersistentFlags().BoolP("debug", "d", false, "Enable debug logging")
+	rootCmd.PersistentFlags().String("config", "", "Config file (default ~/.config/slack-butler/config.yaml; can also be set via SLACK_CONFIG env var)")
+	rootCmd.PersistentFlags().String("profile", "", "Config file profile to use, e.g. for another workspace (can also be set via SLACK_PROFILE env var)")
+	rootCmd.PersistentFlags().String("record", "", "Record every Slack API request and response to this JSON file (tokens redacted)")
+	rootCmd.PersistentFlags().String("replay", "", "Serve Slack API responses from a file written by --record instead of calling Slack")
+	rootCmd.PersistentFlags().String("audit-log", "", "Append a JSON line for every change made in Slack to this file (can also be set via SLACK_AUDIT_LOG env var)")
+	rootCmd.PersistentFlags().String("api-url", "", "Slack API base URL (can also be set via SLACK_API_URL env var)")
+	rootCmd.PersistentFlags().String("http-proxy", "", "Proxy URL for Slack API requests (can also be set via SLACK_HTTP_PROXY env var)")
+	rootCmd.PersistentFlags().String("ca-file", "", "PEM file of extra CA certificates to trust (can also be set via SLACK_CA_FILE env var)")
+	rootCmd.PersistentFlags().Duration("http-timeout", 0, "Timeout for each Slack API request, e.g. 30s; 0 means none (can also be set via SLACK_HTTP_TIMEOUT env var)")
 	rootCmd.Flags().BoolP("version", "v", false, "Print version information")
 
 	// Bind flags to viper
@@ -79,47 +142,208 @@ func init() {
 	if err := viper.BindPFlag("debug", rootCmd.PersistentFlags().Lookup("debug")); err != nil {
 		logger.WithField("error", err.Error()).Fatal("Failed to bind debug flag")
 	}
+	if err := viper.BindPFlag("config", rootCmd.PersistentFlags().Lookup("config")); err != nil {
+		logger.WithField("error", err.Error()).Fatal("Failed to bind config flag")
+	}
+	if err := viper.BindPFlag("profile", rootCmd.PersistentFlags().Lookup("profile")); err != nil {
+		logger.WithField("error", err.Error()).Fatal("Failed to bind profile flag")
+	}
+	if err := viper.BindPFlag("record", rootCmd.PersistentFlags().Lookup("record")); err != nil {
+		logger.WithField("error", err.Error()).Fatal("Failed to bind record flag")
+	}
+	if err := viper.BindPFlag("replay", rootCmd.PersistentFlags().Lookup("replay")); err != nil {
+		logger.WithField("error", err.Error()).Fatal("Failed to bind replay flag")
+	}
+	if err := viper.BindPFlag("audit_log", rootCmd.PersistentFlags().Lookup("audit-log")); err != nil {
+		logger.WithField("error", err.Error()).Fatal("Failed to bind audit-log flag")
+	}
+	if err := viper.BindPFlag("api_url", rootCmd.PersistentFlags().Lookup("api-url")); err != nil {
+		logger.WithField("error", err.Error()).Fatal("Failed to bind api-url flag")
+	}
+	if err := viper.BindPFlag("http_proxy", rootCmd.PersistentFlags().Lookup("http-proxy")); err != nil {
+		logger.WithField("error", err.Error()).Fatal("Failed to bind http-proxy flag")
+	}
+	if err := viper.BindPFlag("ca_file", rootCmd.PersistentFlags().Lookup("ca-file")); err != nil {
+		logger.WithField("error", err.Error()).Fatal("Failed to bind ca-file flag")
+	}
+	if err := viper.BindPFlag("http_timeout", rootCmd.PersistentFlags().Lookup("http-timeout")); err != nil {
+		logger.WithField("error", err.Error()).Fatal("Failed to bind http-timeout flag")
+	}
 
 }
 
+// envBindings are the settings that can be set via environment variables,
+// by viper key.
+var envBindings = []struct {
+	key string
+	env string
+}{
+	{"token", "SLACK_TOKEN"},
+	{"debug", "SLACK_DEBUG"},
+	{"config", "SLACK_CONFIG"},
+	{"profile", "SLACK_PROFILE"},
+	{"include_default_channels", "SLACK_INCLUDE_DEFAULT_CHANNELS"},
+	{"default_channel_sample_size", "SLACK_DEFAULT_CHANNEL_SAMPLE_SIZE"},
+	{"default_channel_threshold", "SLACK_DEFAULT_CHANNEL_THRESHOLD"},
+	{"discussion_channel", "SLACK_DISCUSSION_CHANNEL"},
+	{"include_ext_shared", "SLACK_INCLUDE_EXT_SHARED"},
+	{"include_private", "SLACK_INCLUDE_PRIVATE"},
+	{"concurrency", "SLACK_CONCURRENCY"},
+	{"policy", "SLACK_POLICY"},
+	{"protected_names", "SLACK_PROTECTED_NAMES"},
+	{"exclude_file", "SLACK_EXCLUDE_FILE"},
+	{"keep_reaction", "SLACK_KEEP_REACTION"},
+	{"skip_threads", "SLACK_SKIP_THREADS"},
+	{"export_dir", "SLACK_EXPORT_DIR"},
+	{"audit_log", "SLACK_AUDIT_LOG"},
+	{"api_url", "SLACK_API_URL"},
+	{"http_proxy", "SLACK_HTTP_PROXY"},
+	{"ca_file", "SLACK_CA_FILE"},
+	{"http_timeout", "SLACK_HTTP_TIMEOUT"},
+}
+
 func initConfig() {
 	viper.SetEnvPrefix("SLACK")
 	viper.AutomaticEnv()
 
 	// Explicitly bind environment variables
-	if err := viper.BindEnv("token", "SLACK_TOKEN"); err != nil {
-		// BindEnv rarely fails, but handle for completeness
-		return
+	for _, binding := range envBindings {
+		if err := viper.BindEnv(binding.key, binding.env); err != nil {
+			// BindEnv rarely fails, but handle for completeness
+			return
+		}
 	}
-	if err := viper.BindEnv("debug", "SLACK_DEBUG"); err != nil {
-		// BindEnv rarely fails, but handle for completeness
-		return
+
+	applyLogLevel()
+}
+
+// applyLogLevel sets the log level based on the debug flag.
+func applyLogLevel() {
+	if viper.GetBool("debug") {
+		logger.Log.SetLevel(logrus.DebugLevel)
+	} else {
+		logger.Log.SetLevel(logrus.InfoLevel)
 	}
-	if err := viper.BindEnv("include_default_channels", "SLACK_INCLUDE_DEFAULT_CHANNELS"); err != nil {
-		// BindEnv rarely fails, but handle for completeness
-		return
+}
+
+// requireToken returns the configured Slack token. Replaying a recorded
+// session doesn't talk to Slack, so no token is needed then.
+func requireToken() (string, error) {
+	token := viper.GetString("token")
+	if token == "" && viper.GetString("replay") == "" {
+		return "", fmt.Errorf("slack token is required. Set SLACK_TOKEN environment variable or use --token flag")
 	}
-	if err := viper.BindEnv("default_channel_sample_size", "SLACK_DEFAULT_CHANNEL_SAMPLE_SIZE"); err != nil {
-		// BindEnv rarely fails, but handle for completeness
-		return
+	return token, nil
+}
+
+// newSlackClient creates a Slack client for token. --api-url (SLACK_API_URL)
+// points the client at a different base URL than https://slack.com/api/,
+// e.g. a local fakeslack server for offline end-to-end runs, and the
+// --http-* / --ca-file settings configure the HTTP transport. --record and
+// --replay wrap or replace the API; callers must closeSlackClient when done.
+func newSlackClient(token string) (*slack.Client, error) {
+	recordPath, replayPath := viper.GetString("record"), viper.GetString("replay")
+	if recordPath != "" && replayPath != "" {
+		return nil, fmt.Errorf("--record and --replay cannot be used together")
 	}
-	if err := viper.BindEnv("default_channel_threshold", "SLACK_DEFAULT_CHANNEL_THRESHOLD"); err != nil {
-		// BindEnv rarely fails, but handle for completeness
-		return
+	if replayPath != "" {
+		client, err := slack.NewReplayClient(replayPath)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Printf("📼 Replaying recorded Slack session from %s\n", replayPath)
+		return client, nil
 	}
-	if err := viper.BindEnv("discussion_channel", "SLACK_DISCUSSION_CHANNEL"); err != nil {
-		// BindEnv rarely fails, but handle for completeness
-		return
+
+	options, err := slackClientOptions()
+	if err != nil {
+		return nil, err
 	}
-	if err := viper.BindEnv("include_ext_shared", "SLACK_INCLUDE_EXT_SHARED"); err != nil {
-		// BindEnv rarely fails, but handle for completeness
-		return
+	if recordPath != "" {
+		return slack.NewRecordingClient(recordPath, token, options...)
 	}
+	return slack.NewClient(token, options...)
+}
 
-	// Set log level based on debug flag
-	if viper.GetBool("debug") {
-		logger.Log.SetLevel(logrus.DebugLevel)
-	} else {
-		logger.Log.SetLevel(logrus.InfoLevel)
+// slackClientOptions builds the slack-go options for the configured API base
+// URL and HTTP settings.
+func slackClientOptions() ([]slackapi.Option, error) {
+	var options []slackapi.Option
+	if apiURL := viper.GetString("api_url"); apiURL != "" {
+		if !strings.HasSuffix(apiURL, "/") {
+			apiURL += "/"
+		}
+		options = append(options, slackapi.OptionAPIURL(apiURL))
+	}
+
+	httpConfig := slack.HTTPConfig{
+		ProxyURL: viper.GetString("http_proxy"),
+		CAFile:   viper.GetString("ca_file"),
+		Timeout:  viper.GetDuration("http_timeout"),
+	}
+	if httpConfig.IsZero() {
+		return options, nil
+	}
+	httpClient, err := slack.NewHTTPClient(httpConfig)
+	if err != nil {
+		return nil, err
+	}
+	return append(options, slackapi.OptionHTTPClient(httpClient)), nil
+}
+
+// enableAuditLog makes client append every change it makes in Slack to the
+// --audit-log file, if one is set. Dry runs and replayed sessions change
+// nothing and aren't audited.
+func enableAuditLog(ctx context.Context, cmd *cobra.Command, client *slack.Client, commit bool) error {
+	path := viper.GetString("audit_log")
+	if path == "" || !commit || viper.GetString("replay") != "" {
+		return nil
+	}
+	authInfo, err := client.TestAuth(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace info: %w", err)
+	}
+	auditLog, err := slack.OpenAuditLog(path, slack.AuditRun{
+		Command:  strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" "),
+		Operator: currentOperator(),
+		Actor:    authInfo.UserID,
+		TeamID:   authInfo.TeamID,
+	})
+	if err != nil {
+		return err
+	}
+	client.SetAuditLog(auditLog)
+	fmt.Printf("📒 Auditing changes to %s (run ID %s)\n", path, auditLog.RunID())
+	return nil
+}
+
+// currentOperator returns the name of the local user running the command.
+func currentOperator() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
+
+// commandContext returns the context a command's Slack calls run under,
+// which Execute cancels on Ctrl-C or SIGTERM. Commands run without Execute
+// get context.Background().
+func commandContext(cmd *cobra.Command) context.Context {
+	if cmd == nil || cmd.Context() == nil {
+		return context.Background()
+	}
+	return cmd.Context()
+}
+
+// closeSlackClient closes client, which saves the session when recording
+// and closes the audit log.
+func closeSlackClient(client *slack.Client) {
+	if err := client.Close(); err != nil {
+		logger.WithField("error", err.Error()).Error("Failed to close Slack client")
+		fmt.Printf("❌ Failed to save recorded Slack session or audit log: %v\n", err)
+		return
+	}
+	if recordPath := viper.GetString("record"); recordPath != "" {
+		fmt.Printf("📼 Recorded Slack session saved to %s\n", recordPath)
 	}
 }
@@ -1,8 +1,14 @@
 package cmd
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
 	"testing"
+	"time"
+
+	"github.com/astrostl/slack-butler/pkg/slack"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -138,3 +144,59 @@ func TestRootCommandVersionFlag(t *testing.T) {
 		})
 	})
 }
+
+func TestExitCodeForError(t *testing.T) {
+	tests := []struct {
+		err  error
+		name string
+		want int
+	}{
+		{name: "Generic error", err: errors.New("boom"), want: exitGeneric},
+		{name: "Invalid auth", err: fmt.Errorf("client initialization failed: %w", slack.ErrInvalidAuth), want: exitInvalidAuth},
+		{name: "Missing scope", err: &slack.MissingScopeError{Scope: "chat:write", Err: errors.New("missing_scope")}, want: exitMissingScope},
+		{name: "Rate limited", err: &slack.RateLimitError{Err: errors.New("slack rate limit exceeded")}, want: exitRateLimited},
+		{name: "Channel not found", err: fmt.Errorf("archiving: %w", slack.ErrChannelNotFound), want: exitChannelNotFound},
+		{name: "Channel archived", err: slack.ErrChannelArchived, want: exitChannelArchived},
+		{name: "Interrupted", err: fmt.Errorf("archive interrupted: %w", context.Canceled), want: exitInterrupted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, exitCodeForError(tt.err))
+		})
+	}
+}
+
+func TestSlackClientOptions(t *testing.T) {
+	t.Run("No settings", func(t *testing.T) {
+		t.Setenv("SLACK_API_URL", "")
+		initConfig()
+		options, err := slackClientOptions()
+		assert.NoError(t, err)
+		assert.Empty(t, options)
+	})
+
+	t.Run("API URL and HTTP settings from env", func(t *testing.T) {
+		t.Setenv("SLACK_API_URL", "http://localhost:8080/api")
+		t.Setenv("SLACK_HTTP_PROXY", "http://proxy.example.com:3128")
+		t.Setenv("SLACK_HTTP_TIMEOUT", "30s")
+		initConfig()
+
+		assert.Equal(t, 30*time.Second, viper.GetDuration("http_timeout"))
+		options, err := slackClientOptions()
+		assert.NoError(t, err)
+		assert.Len(t, options, 2)
+	})
+
+	t.Run("Invalid settings", func(t *testing.T) {
+		t.Setenv("SLACK_HTTP_PROXY", "ftp://proxy.example.com")
+		initConfig()
+		_, err := slackClientOptions()
+		assert.ErrorContains(t, err, "invalid HTTP proxy URL")
+
+		t.Setenv("SLACK_HTTP_PROXY", "")
+		t.Setenv("SLACK_CA_FILE", "/nonexistent/ca.pem")
+		_, err = slackClientOptions()
+		assert.ErrorContains(t, err, "failed to read CA file")
+	})
+}
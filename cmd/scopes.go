@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"github.com/astrostl/slack-butler/pkg/slack"
+)
+
+// commandScopes lists the OAuth scopes a subcommand needs.
+type commandScopes struct {
+	command string
+	// always are needed for every run, including dry runs.
+	always []string
+	// commit are additionally needed to post or archive with --commit.
+	commit []string
+	// private are additionally needed with --include-private.
+	private []string
+	// privateCommit are additionally needed with both.
+	privateCommit []string
+}
+
+// commandScopeRequirements are the scopes each subcommand needs, as reported
+// by health and checked before a run starts.
+var commandScopeRequirements = []commandScopes{
+	{
+		command: "channels detect",
+		always:  []string{"channels:read", "channels:history"},
+		commit:  []string{"chat:write"},
+		private: []string{"groups:read"},
+	},
+	{
+		command:       "channels archive",
+		always:        []string{"channels:read", "channels:join", "channels:history", "users:read"},
+		commit:        []string{"chat:write", "channels:manage"},
+		private:       []string{"groups:read", "groups:history"},
+		privateCommit: []string{"groups:write"},
+	},
+	{
+		command: "channels highlight",
+		always:  []string{"channels:read"},
+		commit:  []string{"chat:write"},
+	},
+	{
+		command: "channels snooze",
+		always:  []string{"channels:read"},
+		commit:  []string{"channels:join", "chat:write"},
+	},
+	{
+		command: "channels unarchive",
+		always:  []string{"channels:read"},
+		commit:  []string{"channels:manage", "channels:join", "chat:write"},
+	},
+}
+
+// scopesForCommand returns the scopes command needs for a run with the given
+// options.
+func scopesForCommand(command string, commitMode, withPrivate bool) []string {
+	for _, req := range commandScopeRequirements {
+		if req.command != command {
+			continue
+		}
+		scopes := append([]string{}, req.always...)
+		if commitMode {
+			scopes = append(scopes, req.commit...)
+		}
+		if withPrivate {
+			scopes = append(scopes, req.private...)
+		}
+		if commitMode && withPrivate {
+			scopes = append(scopes, req.privateCommit...)
+		}
+		return scopes
+	}
+	return nil
+}
+
+// missingScopes returns the scopes in needed that granted lacks.
+func missingScopes(granted map[string]bool, needed []string) []string {
+	var missing []string
+	for _, scope := range needed {
+		if !granted[scope] {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
+
+// requireCommandScopes fails fast when the token lacks a scope command needs,
+// instead of failing halfway through a run.
+func requireCommandScopes(ctx context.Context, client *slack.Client, command string, commitMode, withPrivate bool) error {
+	return client.RequireScopes(ctx, scopesForCommand(command, commitMode, withPrivate)...)
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/astrostl/slack-butler/pkg/slack"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScopesForCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		command     string
+		commit      bool
+		withPrivate bool
+		expected    []string
+	}{
+		{"Detect dry run", "channels detect", false, false, []string{"channels:read", "channels:history"}},
+		{"Detect commit", "channels detect", true, false, []string{"channels:read", "channels:history", "chat:write"}},
+		{"Archive dry run with private", "channels archive", false, true, []string{"channels:read", "channels:join", "channels:history", "users:read", "groups:read", "groups:history"}},
+		{"Archive commit with private", "channels archive", true, true, []string{"channels:read", "channels:join", "channels:history", "users:read", "chat:write", "channels:manage", "groups:read", "groups:history", "groups:write"}},
+		{"Highlight commit", "channels highlight", true, false, []string{"channels:read", "chat:write"}},
+		{"Snooze commit", "channels snooze", true, false, []string{"channels:read", "channels:join", "chat:write"}},
+		{"Unknown command", "channels unknown", true, true, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, scopesForCommand(tt.command, tt.commit, tt.withPrivate))
+		})
+	}
+}
+
+func TestRequireCommandScopes(t *testing.T) {
+	mockAPI := slack.NewMockSlackAPI()
+	mockAPI.RevokeScopes("channels:manage")
+	client, err := slack.NewClientWithAPI(mockAPI)
+	require.NoError(t, err)
+
+	assert.NoError(t, requireCommandScopes(context.Background(), client, "channels archive", false, false))
+
+	err = requireCommandScopes(context.Background(), client, "channels archive", true, false)
+	assert.ErrorIs(t, err, slack.ErrMissingScope)
+	assert.Contains(t, err.Error(), "channels:manage")
+	assert.Equal(t, exitMissingScope, exitCodeForError(err))
+}
+
+func TestDisplayCommandScopes(t *testing.T) {
+	scopes := map[string]bool{
+		"channels:read":    true,
+		"channels:history": true,
+		"channels:join":    true,
+		"users:read":       true,
+		"chat:write":       true,
+	}
+
+	oldStdout := os.Stdout
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stdout = w
+
+	displayCommandScopes(scopes)
+
+	require.NoError(t, w.Close())
+	os.Stdout = oldStdout
+	output, err := io.ReadAll(r)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(output), "✅ channels detect")
+	assert.Contains(t, string(output), "⚠️  channels archive: dry run only, --commit needs channels:manage")
+	assert.Contains(t, string(output), "--include-private needs groups:read, groups:history, groups:write")
+	assert.Contains(t, string(output), "✅ channels highlight")
+}
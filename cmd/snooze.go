@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/astrostl/slack-butler/pkg/slack"
+
+	"github.com/spf13/cobra"
+)
+
+var snoozeCmd = &cobra.Command{
+	Use:   "snooze <channel>",
+	Short: "Postpone warnings and archival of a channel",
+	Long: `Postpone inactivity warnings and archival of a single channel, e.g. one that was warned but should be kept for a while longer.
+
+The snooze is recorded as a bot message in the channel, which channels archive reads back, so no state is kept anywhere else.
+The snooze message itself doesn't count as channel activity, but a channel that was already warned gets a full grace period again once the snooze ends before it can be archived.
+
+Use --commit to actually post the snooze (default is dry run mode).`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true, // Don't show usage on errors
+	RunE:         runSnooze,
+}
+
+var (
+	snoozeDays   float64
+	snoozeReason string
+)
+
+func init() {
+	channelsCmd.AddCommand(snoozeCmd)
+
+	snoozeCmd.Flags().Float64Var(&snoozeDays, "days", 30, "Number of days to postpone warnings and archival (supports decimal precision)")
+	snoozeCmd.Flags().StringVar(&snoozeReason, "reason", "", "Why the channel is snoozed, shown in the snooze message")
+	snoozeCmd.Flags().BoolVar(&commit, "commit", false, "Actually post the snooze message (default is dry run mode)")
+}
+
+func runSnooze(cmd *cobra.Command, args []string) error {
+	token, err := requireToken()
+	if err != nil {
+		return err
+	}
+
+	if snoozeDays <= 0 {
+		return fmt.Errorf("days must be positive, got %g", snoozeDays)
+	}
+
+	client, err := newSlackClient(token)
+	if err != nil {
+		return fmt.Errorf("failed to create Slack client: %w", err)
+	}
+	defer closeSlackClient(client)
+	ctx := commandContext(cmd)
+	if err := requireCommandScopes(ctx, client, "channels snooze", commit, false); err != nil {
+		return err
+	}
+	if err := enableAuditLog(ctx, cmd, client, commit); err != nil {
+		return err
+	}
+
+	until := client.Now().Add(time.Duration(snoozeDays * 24 * float64(time.Hour)))
+	return runSnoozeWithClient(ctx, client, args[0], until, snoozeReason, !commit)
+}
+
+func runSnoozeWithClient(ctx context.Context, client *slack.Client, channelName string, until time.Time, reason string, isDryRun bool) error {
+	if client == nil {
+		return fmt.Errorf("client cannot be nil")
+	}
+
+	if err := displayWorkspaceInfo(ctx, client); err != nil {
+		return err
+	}
+
+	if isDryRun {
+		if _, err := client.ResolveChannelNameToID(ctx, channelName); err != nil {
+			return fmt.Errorf("channel '%s' not found: %w", channelName, err)
+		}
+		fmt.Printf("--- DRY RUN ---\n")
+		fmt.Printf("Would post to channel: %s\n", channelName)
+		fmt.Printf("Message content:\n%s\n", slack.FormatSnoozeMessage(until, reason))
+		fmt.Printf("--- END DRY RUN ---\n")
+		fmt.Printf("\nTo actually snooze this channel, add --commit to your command\n")
+		return nil
+	}
+
+	if err := client.SnoozeChannel(ctx, channelName, until, reason); err != nil {
+		return fmt.Errorf("failed to snooze %s: %w", channelName, err)
+	}
+	fmt.Printf("💤 Snoozed %s until %s\n", channelName, until.Format("2006-01-02 15:04"))
+	return nil
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/astrostl/slack-butler/pkg/slack"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunSnoozeWithClient(t *testing.T) {
+	until := time.Date(2026, 11, 15, 12, 0, 0, 0, time.UTC)
+	setup := func(t *testing.T) (*slack.MockSlackAPI, *slack.Client) {
+		mockAPI := slack.NewMockSlackAPI()
+		mockAPI.AddChannel("C1", "quiet", time.Now().Add(-200*24*time.Hour), "")
+		client, err := slack.NewClientWithAPI(mockAPI)
+		require.NoError(t, err)
+		return mockAPI, client
+	}
+	capture := func(t *testing.T, run func() error) (string, error) {
+		r, w, err := os.Pipe()
+		require.NoError(t, err)
+		oldStdout := os.Stdout
+		os.Stdout = w
+		runErr := run()
+		require.NoError(t, w.Close())
+		os.Stdout = oldStdout
+		output, err := io.ReadAll(r)
+		require.NoError(t, err)
+		return string(output), runErr
+	}
+
+	t.Run("Dry run", func(t *testing.T) {
+		mockAPI, client := setup(t)
+		output, err := capture(t, func() error {
+			return runSnoozeWithClient(context.Background(), client, "#quiet", until, "planning", true)
+		})
+		require.NoError(t, err)
+		assert.Contains(t, output, "Would post to channel: #quiet")
+		assert.Contains(t, output, "[butler:snooze until=2026-11-15T12:00:00Z]")
+		assert.Empty(t, mockAPI.GetPostedMessages())
+	})
+
+	t.Run("Commit", func(t *testing.T) {
+		mockAPI, client := setup(t)
+		output, err := capture(t, func() error { return runSnoozeWithClient(context.Background(), client, "quiet", until, "", false) })
+		require.NoError(t, err)
+		assert.Contains(t, output, "Snoozed quiet until")
+		assert.Len(t, mockAPI.GetPostedMessages(), 1)
+	})
+
+	t.Run("Unknown channel", func(t *testing.T) {
+		_, client := setup(t)
+		_, err := capture(t, func() error { return runSnoozeWithClient(context.Background(), client, "#missing", until, "", true) })
+		assert.ErrorContains(t, err, "channel '#missing' not found")
+	})
+}
@@ -0,0 +1,302 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/astrostl/slack-butler/pkg/logger"
+	"github.com/astrostl/slack-butler/pkg/slack"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var unarchiveCmd = &cobra.Command{
+	Use:   "unarchive [channel...]",
+	Short: "Restore archived channels, e.g. to undo an archive run",
+	Long: `Restore archived channels and rejoin them, since archiving removes the bot from a channel.
+
+The channels to restore are given either by name, or as the channels a previous channels archive --commit run archived:
+  --run-id <id>   the run with this ID in the --audit-log file ("last" for the latest run that archived anything)
+  --plan <file>   the channels applying a plan file with --apply --commit archived
+
+Channels that are no longer archived are skipped. Use --notify to post a notice in each restored channel.
+
+Use --commit to actually restore the channels (default is dry run mode).`,
+	SilenceUsage: true, // Don't show usage on errors
+	RunE:         runUnarchive,
+}
+
+var (
+	unarchiveRunID  string
+	unarchivePlan   string
+	unarchiveNotify bool
+)
+
+// lastRunID selects the latest run that archived channels in the audit log.
+const lastRunID = "last"
+
+func init() {
+	channelsCmd.AddCommand(unarchiveCmd)
+
+	unarchiveCmd.Flags().StringVar(&unarchiveRunID, "run-id", "", "Restore the channels archived by this run in the --audit-log file (\"last\" for the latest)")
+	unarchiveCmd.Flags().StringVar(&unarchivePlan, "plan", "", "Restore the channels archived by applying this plan file")
+	unarchiveCmd.Flags().BoolVar(&unarchiveNotify, "notify", false, "Post a notice in each restored channel")
+	unarchiveCmd.Flags().BoolVar(&commit, "commit", false, "Actually restore the channels (default is dry run mode)")
+	unarchiveCmd.MarkFlagsMutuallyExclusive("run-id", "plan")
+}
+
+// unarchiveTargets are the channels to restore and, when they come from a
+// previous run, where they were read from and the workspace of that run.
+type unarchiveTargets struct {
+	source   string
+	teamID   string
+	channels []slack.Channel
+}
+
+// unarchiveRunResults tracks what happened to each channel of an unarchive
+// run, by name.
+type unarchiveRunResults struct {
+	restored     []string
+	notArchived  []string
+	failed       []string
+	notProcessed []string
+}
+
+func runUnarchive(cmd *cobra.Command, args []string) error {
+	token, err := requireToken()
+	if err != nil {
+		return err
+	}
+
+	sources := 0
+	for _, given := range []bool{len(args) > 0, unarchiveRunID != "", unarchivePlan != ""} {
+		if given {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return fmt.Errorf("give either channel names, --run-id or --plan")
+	}
+
+	client, err := newSlackClient(token)
+	if err != nil {
+		return fmt.Errorf("failed to create Slack client: %w", err)
+	}
+	defer closeSlackClient(client)
+	ctx := commandContext(cmd)
+	if err := requireCommandScopes(ctx, client, "channels unarchive", commit, false); err != nil {
+		return err
+	}
+
+	targets, err := resolveUnarchiveTargets(ctx, client, args)
+	if err != nil {
+		return err
+	}
+	if err := enableAuditLog(ctx, cmd, client, commit); err != nil {
+		return err
+	}
+
+	return runUnarchiveWithClient(ctx, client, targets, unarchiveNotify, !commit)
+}
+
+// resolveUnarchiveTargets returns the channels named in args, or those
+// archived by the run given with --run-id or --plan.
+func resolveUnarchiveTargets(ctx context.Context, client *slack.Client, args []string) (*unarchiveTargets, error) {
+	switch {
+	case unarchiveRunID != "":
+		path := viper.GetString("audit_log")
+		if path == "" {
+			return nil, fmt.Errorf("--run-id needs the --audit-log file the run was recorded in")
+		}
+		events, err := slack.ReadAuditLog(path)
+		if err != nil {
+			return nil, err
+		}
+		return archivedInRun(events, unarchiveRunID, path)
+	case unarchivePlan != "":
+		return archivedInPlan(unarchivePlan)
+	default:
+		channels, err := client.FindChannelsByName(ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		return &unarchiveTargets{channels: channels}, nil
+	}
+}
+
+// archivedInRun returns the channels the run with the given ID, or the latest
+// run for lastRunID, archived according to the audit log events read from
+// path.
+func archivedInRun(events []slack.AuditEvent, runID, path string) (*unarchiveTargets, error) {
+	archived := func(event slack.AuditEvent) bool {
+		return event.Action == slack.AuditActionArchive && event.Result == slack.AuditResultOK
+	}
+	if runID == lastRunID {
+		runID = ""
+		for _, event := range events {
+			if archived(event) {
+				runID = event.RunID
+			}
+		}
+		if runID == "" {
+			return nil, fmt.Errorf("no archived channels found in audit log %s", path)
+		}
+	}
+
+	targets := &unarchiveTargets{source: fmt.Sprintf("run %s", runID)}
+	seen := make(map[string]bool)
+	for _, event := range events {
+		if event.RunID != runID || !archived(event) || seen[event.ChannelID] {
+			continue
+		}
+		seen[event.ChannelID] = true
+		targets.teamID = event.TeamID
+		targets.channels = append(targets.channels, slack.Channel{ID: event.ChannelID, Name: event.Channel})
+	}
+	if len(targets.channels) == 0 {
+		return nil, fmt.Errorf("no channels archived by run %s found in audit log %s", runID, path)
+	}
+	return targets, nil
+}
+
+// archivedInPlan returns the channels committed --apply runs of the plan at
+// path actually archived, according to its applied record. Archivals that
+// were skipped because the channel changed, or that failed, aren't restored.
+func archivedInPlan(path string) (*unarchiveTargets, error) {
+	record, err := loadAppliedPlan(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("plan %s was never applied with --commit (no %s)", path, appliedPlanPath(path))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	targets := &unarchiveTargets{source: fmt.Sprintf("plan %s", path), teamID: record.TeamID}
+	seen := make(map[string]bool)
+	for _, archival := range record.Archived {
+		if seen[archival.ChannelID] {
+			continue
+		}
+		seen[archival.ChannelID] = true
+		targets.channels = append(targets.channels, slack.Channel{ID: archival.ChannelID, Name: archival.Channel})
+	}
+	if len(targets.channels) == 0 {
+		return nil, fmt.Errorf("applying plan %s archived no channels", path)
+	}
+	return targets, nil
+}
+
+// runUnarchiveWithClient restores each target channel that is still
+// archived.
+func runUnarchiveWithClient(ctx context.Context, client *slack.Client, targets *unarchiveTargets, notify, isDryRun bool) error {
+	if client == nil {
+		return fmt.Errorf("client cannot be nil")
+	}
+
+	auth, err := client.TestAuth(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace info: %w", err)
+	}
+	fmt.Printf("Workspace: %s (%s)\n\n", auth.Team, auth.WorkspaceURL)
+	if targets.teamID != "" && targets.teamID != auth.TeamID {
+		return fmt.Errorf("%s was in workspace %s, not %s (%s)", targets.source, targets.teamID, auth.Team, auth.TeamID)
+	}
+
+	if targets.source != "" {
+		fmt.Printf("♻️  Restoring %d channels archived by %s\n\n", len(targets.channels), targets.source)
+	}
+	if isDryRun {
+		fmt.Printf("--- DRY RUN ---\n")
+	}
+
+	results := &unarchiveRunResults{}
+	for i, channel := range targets.channels {
+		if ctx.Err() != nil {
+			for _, rest := range targets.channels[i:] {
+				results.notProcessed = append(results.notProcessed, rest.Name)
+			}
+			break
+		}
+		unarchiveChannel(ctx, client, channel, notify, isDryRun, results)
+	}
+
+	if isDryRun {
+		fmt.Printf("--- END DRY RUN ---\n\n")
+	} else {
+		fmt.Println()
+	}
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		fmt.Printf("⚠️  Interrupted. Partial results:\n")
+		displaySummaryLine("Restored", results.restored)
+		displaySummaryLine("Failed", results.failed)
+		displaySummaryLine("Not processed", results.notProcessed)
+		fmt.Println()
+		return fmt.Errorf("unarchive interrupted: %w", ctxErr)
+	}
+
+	fmt.Printf("Unarchive Results:\n")
+	if !isDryRun {
+		displaySummaryLine("Restored", results.restored)
+		displaySummaryLine("Failed", results.failed)
+	}
+	displaySummaryLine("Skipped (not archived)", results.notArchived)
+	fmt.Println()
+	if isDryRun {
+		fmt.Printf("To actually restore these channels, add --commit to your command\n")
+	}
+	return nil
+}
+
+// unarchiveChannel restores one channel, if it is still archived, and
+// posts the restored notice when notify is set.
+func unarchiveChannel(ctx context.Context, client *slack.Client, channel slack.Channel, notify, isDryRun bool, results *unarchiveRunResults) {
+	state, err := client.GetChannelState(ctx, channel.ID)
+	if err != nil {
+		fmt.Printf("  Failed to check #%s: %s\n", channel.Name, err.Error())
+		results.failed = append(results.failed, channel.Name)
+		return
+	}
+	// Restore the channel under its current name, in case it was renamed.
+	channel.Name = state.Name
+	if !state.IsArchived {
+		fmt.Printf("  ⏭️  Skipped #%s: not archived\n", channel.Name)
+		results.notArchived = append(results.notArchived, channel.Name)
+		return
+	}
+
+	if isDryRun {
+		if notify {
+			fmt.Printf("  Would restore #%s and post a restored notice\n", channel.Name)
+		} else {
+			fmt.Printf("  Would restore #%s\n", channel.Name)
+		}
+		return
+	}
+
+	if err := client.UnarchiveChannel(ctx, channel); err != nil {
+		if errors.Is(err, slack.ErrNotArchived) {
+			fmt.Printf("  ⏭️  Skipped #%s: not archived\n", channel.Name)
+			results.notArchived = append(results.notArchived, channel.Name)
+			return
+		}
+		fmt.Printf("  Failed to restore #%s: %s\n", channel.Name, err.Error())
+		results.failed = append(results.failed, channel.Name)
+		return
+	}
+	results.restored = append(results.restored, channel.Name)
+	fmt.Printf("  ✓ Restored #%s\n", channel.Name)
+
+	if notify {
+		if err := client.PostRestoredNotice(ctx, channel); err != nil {
+			logger.WithFields(logger.LogFields{
+				"channel": channel.Name,
+				"error":   err.Error(),
+			}).Warn("Failed to post restored notice")
+			fmt.Printf("    ⚠️  Could not post the restored notice: %s\n", err.Error())
+		}
+	}
+}
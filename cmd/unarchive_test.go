@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/astrostl/slack-butler/pkg/slack"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchivedInRun(t *testing.T) {
+	events := []slack.AuditEvent{
+		{RunID: "run-1", TeamID: "T0000000", Action: slack.AuditActionArchive, ChannelID: "C1", Channel: "old", Result: slack.AuditResultOK},
+		{RunID: "run-2", TeamID: "T0000000", Action: slack.AuditActionWarn, ChannelID: "C2", Channel: "quiet", Result: slack.AuditResultOK},
+		{RunID: "run-2", TeamID: "T0000000", Action: slack.AuditActionArchive, ChannelID: "C3", Channel: "stale", Result: slack.AuditResultOK},
+		{RunID: "run-2", TeamID: "T0000000", Action: slack.AuditActionArchive, ChannelID: "C4", Channel: "locked", Result: slack.AuditResultError},
+		{RunID: "run-3", TeamID: "T0000000", Action: slack.AuditActionSnooze, ChannelID: "C5", Channel: "later", Result: slack.AuditResultOK},
+	}
+
+	targets, err := archivedInRun(events, "run-1", "audit.jsonl")
+	require.NoError(t, err)
+	assert.Equal(t, "run run-1", targets.source)
+	assert.Equal(t, "T0000000", targets.teamID)
+	assert.Equal(t, []slack.Channel{{ID: "C1", Name: "old"}}, targets.channels)
+
+	targets, err = archivedInRun(events, lastRunID, "audit.jsonl")
+	require.NoError(t, err)
+	assert.Equal(t, []slack.Channel{{ID: "C3", Name: "stale"}}, targets.channels)
+
+	_, err = archivedInRun(events, "run-3", "audit.jsonl")
+	assert.ErrorContains(t, err, "no channels archived by run run-3")
+	_, err = archivedInRun(nil, lastRunID, "audit.jsonl")
+	assert.ErrorContains(t, err, "no archived channels found")
+}
+
+func TestRunUnarchiveWithClient(t *testing.T) {
+	capture := func(t *testing.T, run func() error) (string, error) {
+		r, w, err := os.Pipe()
+		require.NoError(t, err)
+		oldStdout := os.Stdout
+		os.Stdout = w
+		runErr := run()
+		require.NoError(t, w.Close())
+		os.Stdout = oldStdout
+		output, err := io.ReadAll(r)
+		require.NoError(t, err)
+		return string(output), runErr
+	}
+	// archiveRun archives #archive-channel with an audit log and returns
+	// the channels that run archived according to the log.
+	archiveRun := func(t *testing.T) (*slack.MockSlackAPI, *slack.Client, *unarchiveTargets) {
+		mockAPI := slack.NewMockSlackAPI()
+		setupCommitModeTest(mockAPI)
+		client, err := slack.NewClientWithAPI(mockAPI)
+		require.NoError(t, err)
+
+		path := filepath.Join(t.TempDir(), "audit.jsonl")
+		auditLog, err := slack.OpenAuditLog(path, slack.AuditRun{Command: "channels archive", TeamID: "T0000000"})
+		require.NoError(t, err)
+		client.SetAuditLog(auditLog)
+		_, err = capture(t, func() error {
+			return runArchiveWithClient(context.Background(), client, 30, 7, false, "", "", 30.0/86400, 7.0/86400, false, 10, 0.9, false, 0)
+		})
+		require.NoError(t, err)
+		require.NoError(t, auditLog.Close())
+		client.SetAuditLog(nil)
+		require.Equal(t, []string{"C2"}, mockAPI.ArchivedChannels)
+
+		events, err := slack.ReadAuditLog(path)
+		require.NoError(t, err)
+		targets, err := archivedInRun(events, lastRunID, path)
+		require.NoError(t, err)
+		return mockAPI, client, targets
+	}
+
+	t.Run("Dry run restores nothing", func(t *testing.T) {
+		mockAPI, client, targets := archiveRun(t)
+		output, err := capture(t, func() error { return runUnarchiveWithClient(context.Background(), client, targets, true, true) })
+		require.NoError(t, err)
+		assert.Contains(t, output, "Restoring 1 channels archived by run ")
+		assert.Contains(t, output, "Would restore #archive-channel and post a restored notice")
+		assert.Empty(t, mockAPI.UnarchivedChannels)
+	})
+
+	t.Run("Commit restores, rejoins and notifies", func(t *testing.T) {
+		mockAPI, client, targets := archiveRun(t)
+		joined, posted := len(mockAPI.JoinedChannels), len(mockAPI.GetPostedMessages())
+
+		output, err := capture(t, func() error { return runUnarchiveWithClient(context.Background(), client, targets, true, false) })
+		require.NoError(t, err)
+		assert.Contains(t, output, "✓ Restored #archive-channel")
+		assert.Contains(t, output, "Restored: 1 (#archive-channel)")
+		assert.Equal(t, []string{"C2"}, mockAPI.UnarchivedChannels)
+		assert.Equal(t, "C2", mockAPI.JoinedChannels[joined])
+		require.Len(t, mockAPI.GetPostedMessages(), posted+1)
+		assert.Equal(t, "C2", mockAPI.GetPostedMessages()[posted].ChannelID)
+
+		// Running it again finds nothing left to restore.
+		output, err = capture(t, func() error { return runUnarchiveWithClient(context.Background(), client, targets, true, false) })
+		require.NoError(t, err)
+		assert.Contains(t, output, "Skipped (not archived): 1 (#archive-channel)")
+		assert.Len(t, mockAPI.UnarchivedChannels, 1)
+	})
+
+	t.Run("Run from another workspace", func(t *testing.T) {
+		_, client, targets := archiveRun(t)
+		targets.teamID = "T9999999"
+		_, err := capture(t, func() error { return runUnarchiveWithClient(context.Background(), client, targets, false, false) })
+		assert.ErrorContains(t, err, "was in workspace T9999999")
+	})
+}
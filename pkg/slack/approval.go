@@ -0,0 +1,320 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/astrostl/slack-butler/pkg/logger"
+
+	"github.com/slack-go/slack"
+)
+
+// DefaultApprovalReaction is the emoji, without colons, approvers react to an
+// archive approval request with to approve archiving.
+const DefaultApprovalReaction = "white_check_mark"
+
+// ApprovalRequestMarker ends the archive approval requests the bot posts, so
+// later runs can find them in the admin channel's history.
+const ApprovalRequestMarker = "[butler:approval-request]"
+
+// approvalItemPattern matches the channel mention starting each item of an
+// approval request, e.g. <#C0123456789|old-project>.
+var approvalItemPattern = regexp.MustCompile(`^<#([A-Z0-9]+)(?:\|[^>]*)?>`)
+
+// approvalItem is a thread reply of an approval request naming a channel.
+type approvalItem struct {
+	channelID string
+	timestamp string
+}
+
+// ArchiveApproval configures the approval step before archival: requests
+// are posted to an admin channel, one thread reply per channel, and only the
+// reactions of approvers count.
+type ArchiveApproval struct {
+	// AdminChannel is where approval requests are posted.
+	AdminChannel Channel
+	// Approvers are the IDs of the users whose reactions approve archival.
+	Approvers []string
+	// Reaction is the emoji, without colons, that approves.
+	Reaction string
+}
+
+// ArchiveApprovals sorts the channels due for archival by approval state.
+// Only requests posted after a channel's warning count, so an approval can't
+// outlive the inactivity period it was given for.
+type ArchiveApprovals struct {
+	// Approved channels got the approval reaction from an approver on their
+	// item of a request, or on the whole request. ApprovedBy is set.
+	Approved []Channel
+	// Pending channels were requested but aren't approved yet.
+	Pending []Channel
+	// Unrequested channels haven't been in a request since their warning.
+	Unrequested []Channel
+}
+
+// FormatApprovalRequest formats the top-level message of an approval
+// request for the given number of channels. The channels follow as thread
+// replies formatted with FormatApprovalRequestItem.
+func FormatApprovalRequest(approval ArchiveApproval, channelCount int) string {
+	var builder strings.Builder
+
+	builder.WriteString("🗄️ Archive Approval Request 🗄️\n\n")
+	fmt.Fprintf(&builder, "%d inactive channels are due to be archived, listed in this thread. ", channelCount)
+	fmt.Fprintf(&builder, "React with :%s: to a channel to approve archiving it, or to this message to approve them all.\n\n", approval.Reaction)
+	approvers := make([]string, len(approval.Approvers))
+	for i, approver := range approval.Approvers {
+		approvers[i] = fmt.Sprintf("<@%s>", approver)
+	}
+	fmt.Fprintf(&builder, "Approvers: %s\n", strings.Join(approvers, ", "))
+	builder.WriteString("Channels without approval are left alone.\n\n")
+	builder.WriteString(ApprovalRequestMarker)
+
+	return builder.String()
+}
+
+// FormatApprovalRequestItem formats the thread reply asking to approve
+// archiving one channel. Private channels are mentioned by ID only, so
+// Slack shows their name just to admins who are members.
+func FormatApprovalRequestItem(channel Channel) string {
+	var builder strings.Builder
+
+	if channel.IsPrivate {
+		fmt.Fprintf(&builder, "<#%s>", channel.ID)
+	} else {
+		fmt.Fprintf(&builder, "<#%s|%s>", channel.ID, channel.Name)
+	}
+	if !channel.LastActivity.IsZero() {
+		fmt.Fprintf(&builder, " • last activity %s", channel.LastActivity.Format("2006-01-02"))
+	}
+	if !channel.WarnedAt.IsZero() {
+		fmt.Fprintf(&builder, " • warned %s", channel.WarnedAt.Format("2006-01-02"))
+	}
+	if channel.MemberCount > 0 {
+		fmt.Fprintf(&builder, " • %d members", channel.MemberCount)
+	}
+
+	return builder.String()
+}
+
+// RequestArchiveApproval posts an approval request for channels to the admin
+// channel: a top-level message approvers can react to for all of them, with
+// one thread reply per channel to approve it alone.
+func (c *Client) RequestArchiveApproval(ctx context.Context, approval ArchiveApproval, channels []Channel) error {
+	if len(channels) == 0 {
+		return nil
+	}
+	admin := approval.AdminChannel
+	if err := c.ensureBotInChannel(ctx, admin); err != nil {
+		return fmt.Errorf("failed to join admin channel %s: %w", admin.Name, err)
+	}
+
+	requestTS, err := c.postMessageWithOptions(ctx, admin.ID, FormatApprovalRequest(approval, len(channels)))
+	c.audit(AuditEvent{Action: AuditActionPost, ChannelID: admin.ID, Channel: admin.Name}, err)
+	if err != nil {
+		return fmt.Errorf("failed to post approval request to %s: %w", admin.Name, err)
+	}
+
+	for _, channel := range channels {
+		_, err := c.postMessageWithOptions(ctx, admin.ID, FormatApprovalRequestItem(channel), slack.MsgOptionTS(requestTS))
+		c.audit(channelAuditEvent(AuditActionApprovalRequest, channel, 0, 0), err)
+		if err != nil {
+			return fmt.Errorf("failed to request approval for %s: %w", channel.Name, err)
+		}
+	}
+	return nil
+}
+
+// CheckArchiveApprovals reads the approval requests in the admin channel and
+// sorts channels by whether they were approved, are awaiting approval or
+// still need to be requested.
+func (c *Client) CheckArchiveApprovals(ctx context.Context, approval ArchiveApproval, channels []Channel) (*ArchiveApprovals, error) {
+	result := &ArchiveApprovals{}
+	if len(channels) == 0 {
+		return result, nil
+	}
+	botUserID := c.getBotUserID(ctx)
+	requests, err := c.approvalRequests(ctx, approval.AdminChannel.ID, oldestWarning(channels), botUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]Channel, len(channels))
+	for _, channel := range channels {
+		byID[channel.ID] = channel
+	}
+	requested := make(map[string]bool)
+	approvedBy := make(map[string]string)
+	for _, request := range requests {
+		if err := c.checkApprovalRequest(ctx, approval, request, byID, botUserID, requested, approvedBy); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, channel := range channels {
+		switch {
+		case approvedBy[channel.ID] != "":
+			channel.ApprovedBy = approvedBy[channel.ID]
+			result.Approved = append(result.Approved, channel)
+		case requested[channel.ID]:
+			result.Pending = append(result.Pending, channel)
+		default:
+			result.Unrequested = append(result.Unrequested, channel)
+		}
+	}
+
+	logger.WithFields(logger.LogFields{
+		"requests":    len(requests),
+		"approved":    len(result.Approved),
+		"pending":     len(result.Pending),
+		"unrequested": len(result.Unrequested),
+	}).Debug("Checked archive approvals")
+	return result, nil
+}
+
+// checkApprovalRequest records which of the channels in byID the request
+// asked about and which of those an approver approved.
+func (c *Client) checkApprovalRequest(ctx context.Context, approval ArchiveApproval, request slack.Message, byID map[string]Channel, botUserID string, requested map[string]bool, approvedBy map[string]string) error {
+	// A request with an unreadable timestamp predates every warning.
+	requestTime, _ := parseSlackTimestamp(request.Timestamp)
+	items, err := c.approvalRequestItems(ctx, approval.AdminChannel.ID, request.Timestamp, botUserID)
+	if err != nil {
+		return err
+	}
+
+	var due []approvalItem
+	for _, item := range items {
+		channel, ok := byID[item.channelID]
+		if !ok || approvedBy[channel.ID] != "" || requestTime.Before(channel.WarnedAt) {
+			continue
+		}
+		requested[channel.ID] = true
+		due = append(due, item)
+	}
+	if len(due) == 0 {
+		return nil
+	}
+
+	requestApprover, err := c.approver(ctx, approval, request.Timestamp)
+	if err != nil {
+		return err
+	}
+	for _, item := range due {
+		approver := requestApprover
+		if approver == "" {
+			if approver, err = c.approver(ctx, approval, item.timestamp); err != nil {
+				return err
+			}
+		}
+		if approver != "" {
+			approvedBy[item.channelID] = approver
+		}
+	}
+	return nil
+}
+
+// approvalRequests returns the approval requests the bot posted to the admin
+// channel since the given time, newest first.
+func (c *Client) approvalRequests(ctx context.Context, adminChannelID string, since time.Time, botUserID string) ([]slack.Message, error) {
+	params := &slack.GetConversationHistoryParameters{
+		ChannelID: adminChannelID,
+		Limit:     historyPageLimit,
+	}
+	if !since.IsZero() {
+		params.Oldest = strconv.FormatInt(since.Unix(), 10)
+	}
+	messages, err := paginate(ctx, "conversations.history", func(cursor string) ([]slack.Message, string, error) {
+		params.Cursor = cursor
+		history, err := c.api.GetConversationHistory(ctx, params)
+		if err != nil {
+			return nil, "", err
+		}
+		if !history.HasMore {
+			return history.Messages, "", nil
+		}
+		return history.Messages, history.ResponseMetaData.NextCursor, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read approval requests: %w", err)
+	}
+
+	var requests []slack.Message
+	for _, msg := range messages {
+		if botUserID != "" && msg.User == botUserID && strings.Contains(msg.Text, ApprovalRequestMarker) {
+			requests = append(requests, msg)
+		}
+	}
+	return requests, nil
+}
+
+// approvalRequestItems returns the bot's thread replies to the request at
+// requestTS that name a channel.
+func (c *Client) approvalRequestItems(ctx context.Context, adminChannelID, requestTS, botUserID string) ([]approvalItem, error) {
+	replies, err := paginate(ctx, "conversations.replies", func(cursor string) ([]slack.Message, string, error) {
+		return c.api.GetConversationReplies(ctx, &slack.GetConversationRepliesParameters{
+			ChannelID: adminChannelID,
+			Timestamp: requestTS,
+			Cursor:    cursor,
+			Limit:     conversationsPageLimit,
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read approval request items: %w", err)
+	}
+
+	var items []approvalItem
+	for _, reply := range replies {
+		if reply.Timestamp == requestTS || reply.User != botUserID {
+			continue
+		}
+		if match := approvalItemPattern.FindStringSubmatch(reply.Text); match != nil {
+			items = append(items, approvalItem{channelID: match[1], timestamp: reply.Timestamp})
+		}
+	}
+	return items, nil
+}
+
+// approver returns the first approver who reacted to the message at ts in
+// the admin channel with the approval reaction, or "".
+func (c *Client) approver(ctx context.Context, approval ArchiveApproval, ts string) (string, error) {
+	reactions, err := c.api.GetReactions(ctx, slack.NewRefToMessage(approval.AdminChannel.ID, ts), slack.GetReactionsParameters{Full: true})
+	if err != nil {
+		if errors.Is(err, ErrMissingScope) {
+			return "", describe(withScope(err, "reactions:read"), "missing required permission to read approvals. Your bot needs the 'reactions:read' OAuth scope.\nPlease add this scope in your Slack app settings at https://api.slack.com/apps")
+		}
+		return "", fmt.Errorf("failed to read approval reactions: %w", err)
+	}
+	for _, reaction := range reactions {
+		// Skin tone variants are reported as e.g. white_check_mark::skin-tone-2.
+		name, _, _ := strings.Cut(reaction.Name, "::")
+		if !strings.EqualFold(name, approval.Reaction) {
+			continue
+		}
+		for _, user := range reaction.Users {
+			if slices.Contains(approval.Approvers, user) {
+				return user, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// oldestWarning returns the earliest warning time among channels, or the
+// zero time if any channel has none.
+func oldestWarning(channels []Channel) time.Time {
+	var oldest time.Time
+	for _, channel := range channels {
+		if channel.WarnedAt.IsZero() {
+			return time.Time{}
+		}
+		if oldest.IsZero() || channel.WarnedAt.Before(oldest) {
+			oldest = channel.WarnedAt
+		}
+	}
+	return oldest
+}
@@ -0,0 +1,129 @@
+package slack
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/astrostl/slack-butler/pkg/slack/fakeslack"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatApprovalRequest(t *testing.T) {
+	approval := ArchiveApproval{Approvers: []string{"U100", "U200"}, Reaction: DefaultApprovalReaction}
+	message := FormatApprovalRequest(approval, 2)
+	assert.Contains(t, message, "2 inactive channels")
+	assert.Contains(t, message, ":white_check_mark:")
+	assert.Contains(t, message, "<@U100>, <@U200>")
+	assert.Contains(t, message, ApprovalRequestMarker)
+
+	lastActivity := time.Date(2026, 8, 1, 12, 0, 0, 0, time.UTC)
+	item := FormatApprovalRequestItem(Channel{ID: "C1", Name: "old-project", LastActivity: lastActivity, MemberCount: 3})
+	assert.Equal(t, "<#C1|old-project> • last activity 2026-08-01 • 3 members", item)
+	assert.Equal(t, []string{"<#C1|old-project>", "C1"}, approvalItemPattern.FindStringSubmatch(item))
+
+	private := FormatApprovalRequestItem(Channel{ID: "G1", Name: "secret", IsPrivate: true})
+	assert.Equal(t, "<#G1>", private)
+	assert.Equal(t, "G1", approvalItemPattern.FindStringSubmatch(private)[1])
+}
+
+func TestArchiveApprovalsAgainstFakeSlack(t *testing.T) {
+	const token = "MOCK-BOT-TOKEN-FOR-TESTING-ONLY-NOT-REAL-TOKEN-AT-ALL"
+
+	server := fakeslack.New()
+	defer server.Close()
+	created := time.Now().Add(-90 * 24 * time.Hour)
+	server.AddChannel("C900", "admins", created, "")
+	server.AddChannel("C101", "old-a", created, "")
+	server.AddChannel("C102", "old-b", created, "")
+	server.AddChannel("C103", "old-c", created, "")
+	server.AddMember("C900", fakeslack.BotUserID)
+
+	client, err := NewClient(token, slack.OptionAPIURL(server.URL()))
+	require.NoError(t, err)
+
+	warnedAt := time.Now().Add(-time.Hour)
+	oldA := Channel{ID: "C101", Name: "old-a", WarnedAt: warnedAt}
+	oldB := Channel{ID: "C102", Name: "old-b", WarnedAt: warnedAt}
+	oldC := Channel{ID: "C103", Name: "old-c", WarnedAt: warnedAt}
+	approval := ArchiveApproval{
+		AdminChannel: Channel{ID: "C900", Name: "admins"},
+		Approvers:    []string{"U100"},
+		Reaction:     DefaultApprovalReaction,
+	}
+
+	t.Run("Channels start unrequested", func(t *testing.T) {
+		approvals, err := client.CheckArchiveApprovals(context.Background(), approval, []Channel{oldA, oldB})
+		require.NoError(t, err)
+		assert.Empty(t, approvals.Approved)
+		assert.Empty(t, approvals.Pending)
+		assert.Len(t, approvals.Unrequested, 2)
+	})
+
+	require.NoError(t, client.RequestArchiveApproval(context.Background(), approval, []Channel{oldA, oldB}))
+	posted := server.PostedMessages()
+	require.Len(t, posted, 3)
+	assert.Contains(t, posted[0].Text, ApprovalRequestMarker)
+	assert.Contains(t, posted[1].Text, "<#C101|old-a>")
+	assert.Contains(t, posted[2].Text, "<#C102|old-b>")
+	requestTS, itemA := posted[0].Timestamp, posted[1].Timestamp
+
+	t.Run("Requested channels await approval", func(t *testing.T) {
+		approvals, err := client.CheckArchiveApprovals(context.Background(), approval, []Channel{oldA, oldB, oldC})
+		require.NoError(t, err)
+		assert.Empty(t, approvals.Approved)
+		assert.Equal(t, []string{"old-a", "old-b"}, channelNames(approvals.Pending))
+		assert.Equal(t, []string{"old-c"}, channelNames(approvals.Unrequested))
+	})
+
+	t.Run("Only the approval reaction from an approver counts", func(t *testing.T) {
+		server.AddReaction("C900", itemA, DefaultApprovalReaction, "U999")
+		server.AddReaction("C900", itemA, "thumbsup", "U100")
+		approvals, err := client.CheckArchiveApprovals(context.Background(), approval, []Channel{oldA, oldB})
+		require.NoError(t, err)
+		assert.Empty(t, approvals.Approved)
+
+		server.AddReaction("C900", itemA, DefaultApprovalReaction+"::skin-tone-2", "U100")
+		approvals, err = client.CheckArchiveApprovals(context.Background(), approval, []Channel{oldA, oldB})
+		require.NoError(t, err)
+		require.Len(t, approvals.Approved, 1)
+		assert.Equal(t, "old-a", approvals.Approved[0].Name)
+		assert.Equal(t, "U100", approvals.Approved[0].ApprovedBy)
+		assert.Equal(t, []string{"old-b"}, channelNames(approvals.Pending))
+	})
+
+	t.Run("Reacting to the request approves every channel in it", func(t *testing.T) {
+		server.AddReaction("C900", requestTS, DefaultApprovalReaction, "U100")
+		approvals, err := client.CheckArchiveApprovals(context.Background(), approval, []Channel{oldA, oldB, oldC})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"old-a", "old-b"}, channelNames(approvals.Approved))
+		assert.Equal(t, []string{"old-c"}, channelNames(approvals.Unrequested))
+	})
+
+	t.Run("Requests from before a channel's warning don't count", func(t *testing.T) {
+		rewarned := oldA
+		rewarned.WarnedAt = time.Now().Add(time.Hour)
+		approvals, err := client.CheckArchiveApprovals(context.Background(), approval, []Channel{rewarned})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"old-a"}, channelNames(approvals.Unrequested))
+	})
+
+	t.Run("Missing reactions:read is reported", func(t *testing.T) {
+		server.FailWith("reactions.get", "missing_scope")
+		defer server.FailWith("reactions.get", "")
+		_, err := client.CheckArchiveApprovals(context.Background(), approval, []Channel{oldA})
+		require.ErrorIs(t, err, ErrMissingScope)
+		assert.Contains(t, err.Error(), "reactions:read")
+	})
+}
+
+func channelNames(channels []Channel) []string {
+	names := make([]string, len(channels))
+	for i, channel := range channels {
+		names[i] = channel.Name
+	}
+	return names
+}
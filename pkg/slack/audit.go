@@ -0,0 +1,222 @@
+package slack
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/astrostl/slack-butler/pkg/logger"
+)
+
+// Actions recorded in the audit log, one per mutating Slack call.
+const (
+	AuditActionJoin            = "join"
+	AuditActionWarn            = "warn"
+	AuditActionArchivalMessage = "archival_message"
+	AuditActionArchive         = "archive"
+	AuditActionUnarchive       = "unarchive"
+	AuditActionRestoredMessage = "restored_message"
+	AuditActionPost            = "post"
+	AuditActionSnooze          = "snooze"
+	AuditActionApprovalRequest = "approval_request"
+)
+
+// Results of an audited call.
+const (
+	AuditResultOK    = "ok"
+	AuditResultError = "error"
+)
+
+// AuditRun identifies the run an audit log records events for.
+type AuditRun struct {
+	// ID is generated by NewAuditLog when empty.
+	ID      string
+	Command string
+	// Operator is the local user running the command and Actor the Slack
+	// user the token acts as.
+	Operator string
+	Actor    string
+	TeamID   string
+}
+
+// AuditEvent is one line of the audit log: a mutating Slack call, the
+// channel it acted on, the thresholds and evidence behind it, and its
+// outcome.
+type AuditEvent struct {
+	Time           time.Time `json:"time"`
+	LastActivity   time.Time `json:"last_activity,omitzero"`
+	WarnedAt       time.Time `json:"warned_at,omitzero"`
+	SnoozedUntil   time.Time `json:"snoozed_until,omitzero"`
+	RunID          string    `json:"run_id"`
+	Command        string    `json:"command"`
+	Operator       string    `json:"operator,omitempty"`
+	Actor          string    `json:"actor,omitempty"`
+	TeamID         string    `json:"team_id,omitempty"`
+	Action         string    `json:"action"`
+	ChannelID      string    `json:"channel_id"`
+	Channel        string    `json:"channel,omitempty"`
+	ApprovedBy     string    `json:"approved_by,omitempty"`
+	Result         string    `json:"result"`
+	Error          string    `json:"error,omitempty"`
+	WarnSeconds    int       `json:"warn_seconds,omitempty"`
+	ArchiveSeconds int       `json:"archive_seconds,omitempty"`
+}
+
+// AuditLog appends one JSON line per mutating Slack call, so who warned or
+// archived a channel, when and why can be answered long after the run's
+// output is gone. It is safe for concurrent use.
+type AuditLog struct {
+	w      io.Writer
+	closer io.Closer
+	run    AuditRun
+	mu     sync.Mutex
+}
+
+// NewAuditLog returns an audit log writing the events of run to w.
+func NewAuditLog(w io.Writer, run AuditRun) *AuditLog {
+	if run.ID == "" {
+		run.ID = newRunID(time.Now())
+	}
+	return &AuditLog{w: w, run: run}
+}
+
+// OpenAuditLog opens the audit log file at path for appending, creating it
+// owner-only if needed.
+func OpenAuditLog(path string, run AuditRun) (*AuditLog, error) {
+	file, err := os.OpenFile(filepath.Clean(path), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	log := NewAuditLog(file, run)
+	log.closer = file
+	return log, nil
+}
+
+// ReadAuditLog reads every event from the audit log file at path, oldest
+// first.
+func ReadAuditLog(path string) ([]AuditEvent, error) {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	defer file.Close()
+
+	var events []AuditEvent
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var event AuditEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("invalid audit log %s, line %d: %w", path, line, err)
+		}
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return events, nil
+}
+
+// RunID returns the ID of the run the log records.
+func (a *AuditLog) RunID() string {
+	return a.run.ID
+}
+
+// Record appends event, stamped with the run and the current time when
+// it has none.
+func (a *AuditLog) Record(event AuditEvent) error {
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+	event.RunID = a.run.ID
+	event.Command = a.run.Command
+	event.Operator = a.run.Operator
+	event.Actor = a.run.Actor
+	event.TeamID = a.run.TeamID
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit event: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, err := a.w.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	return nil
+}
+
+// Close closes the audit log file.
+func (a *AuditLog) Close() error {
+	if a.closer == nil {
+		return nil
+	}
+	return a.closer.Close()
+}
+
+// newRunID returns a run ID sortable by start time, e.g.
+// 20261016T120000Z-1a2b3c4d.
+func newRunID(now time.Time) string {
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return now.UTC().Format("20060102T150405.000000000Z")
+	}
+	return now.UTC().Format("20060102T150405Z") + "-" + hex.EncodeToString(suffix)
+}
+
+// SetAuditLog makes the client record every mutating Slack call in log.
+// Nil disables auditing.
+func (c *Client) SetAuditLog(log *AuditLog) {
+	c.auditLog = log
+}
+
+// AuditLog returns the audit log, or nil when auditing is disabled.
+func (c *Client) AuditLog() *AuditLog {
+	return c.auditLog
+}
+
+// audit records a mutating call and its outcome in the audit log, if any.
+func (c *Client) audit(event AuditEvent, err error) {
+	if c.auditLog == nil {
+		return
+	}
+	event.Result = AuditResultOK
+	if err != nil {
+		event.Result = AuditResultError
+		event.Error = SanitizeForLogging(err.Error())
+	}
+	if recordErr := c.auditLog.Record(event); recordErr != nil {
+		logger.WithFields(logger.LogFields{
+			"action":  event.Action,
+			"channel": event.Channel,
+			"error":   recordErr.Error(),
+		}).Error("Failed to record audit event")
+	}
+}
+
+// channelAuditEvent returns the audit event for action on channel, with the
+// thresholds and evidence it was based on.
+func channelAuditEvent(action string, channel Channel, warnSeconds, archiveSeconds int) AuditEvent {
+	warnSeconds, archiveSeconds = channel.Thresholds(warnSeconds, archiveSeconds)
+	return AuditEvent{
+		Action:         action,
+		ChannelID:      channel.ID,
+		Channel:        channel.Name,
+		LastActivity:   channel.LastActivity,
+		WarnedAt:       channel.WarnedAt,
+		ApprovedBy:     channel.ApprovedBy,
+		WarnSeconds:    warnSeconds,
+		ArchiveSeconds: archiveSeconds,
+	}
+}
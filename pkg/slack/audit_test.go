@@ -0,0 +1,100 @@
+package slack
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readAuditEvents(t *testing.T, data []byte) []AuditEvent {
+	t.Helper()
+	var events []AuditEvent
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var event AuditEvent
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, event)
+	}
+	require.NoError(t, scanner.Err())
+	return events
+}
+
+func TestAuditLog(t *testing.T) {
+	run := AuditRun{Command: "channels archive", Operator: "alice", Actor: "U0000000", TeamID: "T0000000"}
+
+	t.Run("Record stamps events with the run", func(t *testing.T) {
+		var buf bytes.Buffer
+		auditLog := NewAuditLog(&buf, run)
+		assert.Regexp(t, regexp.MustCompile(`^\d{8}T\d{6}Z-[0-9a-f]{8}$`), auditLog.RunID())
+
+		require.NoError(t, auditLog.Record(AuditEvent{Action: AuditActionPost, ChannelID: "C1", Channel: "general", Result: AuditResultOK}))
+		assert.NotContains(t, buf.String(), "last_activity")
+		events := readAuditEvents(t, buf.Bytes())
+		require.Len(t, events, 1)
+		assert.Equal(t, auditLog.RunID(), events[0].RunID)
+		assert.Equal(t, "channels archive", events[0].Command)
+		assert.Equal(t, "alice", events[0].Operator)
+		assert.Equal(t, "T0000000", events[0].TeamID)
+		assert.WithinDuration(t, time.Now(), events[0].Time, time.Minute)
+	})
+
+	t.Run("File is appended to and owner-only", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "audit.jsonl")
+		for range 2 {
+			auditLog, err := OpenAuditLog(path, run)
+			require.NoError(t, err)
+			require.NoError(t, auditLog.Record(AuditEvent{Action: AuditActionSnooze, ChannelID: "C1"}))
+			require.NoError(t, auditLog.Close())
+		}
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+		events, err := ReadAuditLog(path)
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		assert.NotEqual(t, events[0].RunID, events[1].RunID)
+
+		require.NoError(t, os.WriteFile(path, []byte("{\"action\": \"archive\"}\nnot json\n"), 0o600))
+		_, err = ReadAuditLog(path)
+		assert.ErrorContains(t, err, "line 2")
+	})
+
+	t.Run("Client records archival with its evidence", func(t *testing.T) {
+		mockAPI := NewMockSlackAPI()
+		mockAPI.SetArchiveConversationErrorWithMessage("C2", true, "restricted_action")
+		client, err := NewClientWithAPI(mockAPI)
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		client.SetAuditLog(NewAuditLog(&buf, run))
+
+		lastActivity := time.Date(2026, 8, 1, 12, 0, 0, 0, time.UTC)
+		warnedAt := time.Date(2026, 9, 15, 9, 30, 0, 0, time.UTC)
+		require.NoError(t, client.ArchiveChannelWithThresholds(context.Background(), Channel{ID: "C1", Name: "stale", LastActivity: lastActivity, WarnedAt: warnedAt}, 7200, 3600))
+		require.Error(t, client.ArchiveChannelWithThresholds(context.Background(), Channel{ID: "C2", Name: "locked"}, 7200, 3600))
+
+		events := readAuditEvents(t, buf.Bytes())
+		var actions []string
+		for _, event := range events {
+			actions = append(actions, event.Action+" "+event.ChannelID+" "+event.Result)
+		}
+		assert.Equal(t, []string{
+			"join C1 ok", "archival_message C1 ok", "archive C1 ok",
+			"join C2 ok", "archival_message C2 ok", "archive C2 error",
+		}, actions)
+		assert.True(t, events[2].LastActivity.Equal(lastActivity))
+		assert.True(t, events[2].WarnedAt.Equal(warnedAt))
+		assert.Equal(t, 7200, events[2].WarnSeconds)
+		assert.Equal(t, 3600, events[2].ArchiveSeconds)
+		assert.Contains(t, events[5].Error, "restricted_action")
+	})
+}
@@ -4,10 +4,15 @@
 package slack
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"math/rand"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/astrostl/slack-butler/pkg/logger"
@@ -29,10 +34,26 @@ const (
 // override is configured.
 const DefaultDiscussionChannel = "meta"
 
+// Client is safe for concurrent use once configured; the Set* methods must
+// not be called while other calls are in flight. Methods that call Slack take
+// a context first; cancelling it aborts in-flight requests and rate-limit
+// waits.
 type Client struct {
 	api                   SlackAPI
 	discussionChannelName string
+	keepReaction          string
+	exportDir             string
+	auditLog              *AuditLog
+	policy                *ArchivePolicy
+	protectedNames        *ProtectedNames
+	exclusions            []Exclusion
+	exportUsers           map[string]string
+	markerWarnings        sync.Map
+	clock                 func() time.Time
+	concurrency           int
 	includeExtShared      bool
+	includePrivate        bool
+	skipThreads           bool
 }
 
 type Channel struct {
@@ -44,8 +65,27 @@ type Channel struct {
 	Name         string
 	Purpose      string
 	Creator      string
-	MemberCount  int
-	IsArchived   bool
+	// WarnedAt is when the bot's standing inactivity warning was posted, if
+	// any, and SnoozedUntil when a snooze posted with SnoozeChannel ends.
+	WarnedAt     time.Time
+	SnoozedUntil time.Time
+	// KeepVoters are the members who reacted to the channel's warning with
+	// the keep reaction, by name where known. See SetKeepReaction.
+	KeepVoters []string
+	// ApprovedBy is the approver who approved archiving the channel, when
+	// archival needs approval. See CheckArchiveApprovals.
+	ApprovedBy string
+	// PolicyRule is the archive policy rule that matched the channel, if any,
+	// and Marker the [butler:...] marker in its topic or purpose that took
+	// precedence over the policy; WarnSeconds and ArchiveSeconds are their
+	// thresholds, zero when keeping the command's. See Thresholds.
+	PolicyRule     string
+	Marker         string
+	WarnSeconds    int
+	ArchiveSeconds int
+	MemberCount    int
+	IsArchived     bool
+	IsPrivate      bool
 }
 
 type AuthInfo struct {
@@ -56,17 +96,48 @@ type AuthInfo struct {
 	WorkspaceURL string
 }
 
-func NewClient(token string) (*Client, error) {
+// NewClient connects to Slack with token. Options are passed to slack-go,
+// e.g. slack.OptionAPIURL to talk to a different API base URL.
+func NewClient(token string, options ...slack.Option) (*Client, error) {
 	// Validate token format before using it
 	if err := ValidateSlackToken(token); err != nil {
 		return nil, fmt.Errorf("invalid token: %w", err)
 	}
 
-	api := NewRealSlackAPI(token)
+	return connectClient(NewRateLimitedAPI(NewRealSlackAPI(token, options...)))
+}
+
+// NewRecordingClient is like NewClient, but records every Slack API request
+// and response. The session is written to path when the client is closed.
+func NewRecordingClient(path, token string, options ...slack.Option) (*Client, error) {
+	if err := ValidateSlackToken(token); err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+
+	return connectClient(NewRecordingAPI(NewRateLimitedAPI(NewRealSlackAPI(token, options...)), path))
+}
 
-	auth, err := api.AuthTest()
+// NewReplayClient creates a client that serves a session recorded by
+// NewRecordingClient instead of calling Slack. Its clock is pinned to when the session
+// was recorded; see SetClock.
+func NewReplayClient(path string) (*Client, error) {
+	api, err := LoadReplayAPI(path)
+	if err != nil {
+		return nil, err
+	}
+	client, err := connectClient(api)
 	if err != nil {
-		return nil, fmt.Errorf("authentication failed: %v", SanitizeForLogging(err.Error()))
+		return nil, err
+	}
+	client.SetClock(api.RecordedAt)
+	return client, nil
+}
+
+// connectClient verifies api's credentials and creates a client for it.
+func connectClient(api SlackAPI) (*Client, error) {
+	auth, err := api.AuthTest(context.Background())
+	if err != nil {
+		return nil, describe(err, "authentication failed: %v", SanitizeForLogging(err.Error()))
 	}
 
 	logger.WithFields(logger.LogFields{
@@ -77,6 +148,7 @@ func NewClient(token string) (*Client, error) {
 	return &Client{
 		api:                   api,
 		discussionChannelName: DefaultDiscussionChannel,
+		keepReaction:          DefaultKeepReaction,
 	}, nil
 }
 
@@ -86,7 +158,7 @@ func NewClientWithAPI(api SlackAPI) (*Client, error) {
 		return nil, fmt.Errorf("API cannot be nil")
 	}
 
-	auth, err := api.AuthTest()
+	auth, err := api.AuthTest(context.Background())
 	if err != nil {
 		return nil, fmt.Errorf("authentication failed: %w", err)
 	}
@@ -99,9 +171,39 @@ func NewClientWithAPI(api SlackAPI) (*Client, error) {
 	return &Client{
 		api:                   api,
 		discussionChannelName: DefaultDiscussionChannel,
+		keepReaction:          DefaultKeepReaction,
 	}, nil
 }
 
+// SetClock sets the clock inactivity analysis measures time against. A
+// replayed session is pinned to the time it was recorded, so it classifies
+// channels the way the recorded run did however much later it is replayed.
+// Nil reverts to the wall clock.
+func (c *Client) SetClock(now func() time.Time) {
+	c.clock = now
+}
+
+// Now returns the current time on the client's clock.
+func (c *Client) Now() time.Time {
+	if c.clock == nil {
+		return time.Now()
+	}
+	return c.clock()
+}
+
+// Close releases the client's API and closes its audit log. For a
+// recording client this writes the recorded session file.
+func (c *Client) Close() error {
+	var apiErr, auditErr error
+	if closer, ok := c.api.(io.Closer); ok {
+		apiErr = closer.Close()
+	}
+	if c.auditLog != nil {
+		auditErr = c.auditLog.Close()
+	}
+	return errors.Join(apiErr, auditErr)
+}
+
 // SetDiscussionChannel configures the channel name used for the
 // "discuss admin intervention" link in warning/archival messages.
 // Empty input or input equal to "#" reverts to the default ("meta").
@@ -136,13 +238,46 @@ func (c *Client) IncludeExtShared() bool {
 	return c.includeExtShared
 }
 
-func (c *Client) GetNewChannels(since time.Time) ([]Channel, error) {
+// SetIncludePrivate controls whether private channels the bot has been
+// invited to are listed alongside public channels. When false (the
+// default), only public channels are considered. Private channels are
+// never included in public announcements either way.
+func (c *Client) SetIncludePrivate(include bool) {
+	c.includePrivate = include
+}
+
+// IncludePrivate reports whether private channels are included.
+func (c *Client) IncludePrivate() bool {
+	return c.includePrivate
+}
+
+// SetConcurrency sets how many channels are joined, analyzed or looked up
+// at once. All workers share the client's rate-limit budget. Values below 1
+// revert to DefaultConcurrency.
+func (c *Client) SetConcurrency(n int) {
+	c.concurrency = n
+}
+
+// Concurrency returns the number of channels processed at once.
+func (c *Client) Concurrency() int {
+	if c.concurrency < 1 {
+		return DefaultConcurrency
+	}
+	return c.concurrency
+}
+
+// conversationTypes returns the conversations.list types to request.
+func (c *Client) conversationTypes() []string {
+	if c.includePrivate {
+		return []string{"public_channel", "private_channel"}
+	}
+	return []string{"public_channel"}
+}
+
+func (c *Client) GetNewChannels(ctx context.Context, since time.Time) ([]Channel, error) {
 	logger.WithField("since", since.Format("2006-01-02 15:04:05")).Debug("Fetching channels from Slack API")
 
-	channels, _, err := c.api.GetConversations(&slack.GetConversationsParameters{
-		Types: []string{"public_channel"},
-		Limit: 1000,
-	})
+	channels, err := c.getAllConversations(ctx, false)
 	if err != nil {
 		errStr := err.Error()
 		logger.WithFields(logger.LogFields{
@@ -151,17 +286,17 @@ func (c *Client) GetNewChannels(since time.Time) ([]Channel, error) {
 		}).Error("Slack API error")
 
 		// Handle rate limiting
-		if strings.Contains(errStr, "rate_limited") {
-			return nil, fmt.Errorf("rate limited by Slack API. Please wait before retrying")
+		if errors.Is(err, ErrRateLimited) {
+			return nil, describe(err, "rate limited by Slack API. Please wait before retrying")
 		}
 
-		if strings.Contains(errStr, "missing_scope") {
+		if errors.Is(err, ErrMissingScope) {
 			logger.Error("Missing OAuth scopes for channel access")
-			return nil, fmt.Errorf("missing required permissions. Your bot needs this OAuth scope:\n  - channels:read (to list public channels) - REQUIRED\n\nPlease add this scope in your Slack app settings at https://api.slack.com/apps")
+			return nil, describe(withScope(err, "channels:read"), "missing required permissions. Your bot needs this OAuth scope:\n  - channels:read (to list public channels) - REQUIRED\n\nPlease add this scope in your Slack app settings at https://api.slack.com/apps")
 		}
-		if strings.Contains(errStr, "invalid_auth") {
+		if errors.Is(err, ErrInvalidAuth) {
 			logger.Error("Invalid Slack authentication token")
-			return nil, fmt.Errorf("invalid token. Please check your SLACK_TOKEN")
+			return nil, describe(err, "invalid token. Please check your SLACK_TOKEN")
 		}
 		return nil, fmt.Errorf("failed to get conversations: %w", err)
 	}
@@ -179,11 +314,12 @@ func (c *Client) GetNewChannels(since time.Time) ([]Channel, error) {
 			}).Debug("Found new channel")
 
 			newChannels = append(newChannels, Channel{
-				ID:      ch.ID,
-				Name:    ch.Name,
-				Created: created,
-				Purpose: ch.Purpose.Value,
-				Creator: ch.Creator,
+				ID:        ch.ID,
+				Name:      ch.Name,
+				Created:   created,
+				Purpose:   ch.Purpose.Value,
+				Creator:   ch.Creator,
+				IsPrivate: ch.IsPrivate,
 			})
 		}
 	}
@@ -192,13 +328,10 @@ func (c *Client) GetNewChannels(since time.Time) ([]Channel, error) {
 	return newChannels, nil
 }
 
-func (c *Client) GetNewChannelsWithAllChannels(since time.Time) ([]Channel, []slack.Channel, error) {
+func (c *Client) GetNewChannelsWithAllChannels(ctx context.Context, since time.Time) ([]Channel, []slack.Channel, error) {
 	logger.WithField("since", since.Format("2006-01-02 15:04:05")).Debug("Fetching channels from Slack API")
 
-	channels, _, err := c.api.GetConversations(&slack.GetConversationsParameters{
-		Types: []string{"public_channel"},
-		Limit: 1000,
-	})
+	channels, err := c.getAllConversations(ctx, false)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get conversations: %w", err)
 	}
@@ -216,11 +349,12 @@ func (c *Client) GetNewChannelsWithAllChannels(since time.Time) ([]Channel, []sl
 			}).Debug("Found new channel")
 
 			newChannels = append(newChannels, Channel{
-				ID:      ch.ID,
-				Name:    ch.Name,
-				Created: created,
-				Creator: ch.Creator,
-				Purpose: ch.Purpose.Value,
+				ID:        ch.ID,
+				Name:      ch.Name,
+				Created:   created,
+				Creator:   ch.Creator,
+				Purpose:   ch.Purpose.Value,
+				IsPrivate: ch.IsPrivate,
 			})
 		}
 	}
@@ -236,7 +370,7 @@ func (c *Client) FormatNewChannelAnnouncement(channels []Channel, since time.Tim
 	}).Debug("Formatting announcement message")
 
 	// Calculate days since the search period
-	daysSince := int(time.Since(since).Hours() / 24)
+	daysSince := int(c.Now().Sub(since).Hours() / 24)
 	daysText := textDays
 	if daysSince == 1 {
 		daysText = textDay
@@ -254,7 +388,7 @@ func (c *Client) FormatNewChannelAnnouncement(channels []Channel, since time.Tim
 
 	for i, ch := range channels {
 		// Calculate days since creation
-		daysSinceCreated := int(time.Since(ch.Created).Hours() / 24)
+		daysSinceCreated := int(c.Now().Sub(ch.Created).Hours() / 24)
 		daysText := "days"
 		if daysSinceCreated == 1 {
 			daysText = "day"
@@ -285,16 +419,16 @@ func (c *Client) FormatNewChannelAnnouncement(channels []Channel, since time.Tim
 	return builder.String()
 }
 
-func (c *Client) FormatNewChannelAnnouncementDryRun(channels []Channel, since time.Time) string {
+func (c *Client) FormatNewChannelAnnouncementDryRun(ctx context.Context, channels []Channel, since time.Time) string {
 	// Get user map for resolving creator names
-	userMap, err := c.GetUserMap()
+	userMap, err := c.GetUserMap(ctx)
 	if err != nil {
 		// If we can't get users, fall back to regular format
 		return c.FormatNewChannelAnnouncement(channels, since)
 	}
 
 	// Calculate days since the search period
-	daysSince := int(time.Since(since).Hours() / 24)
+	daysSince := int(c.Now().Sub(since).Hours() / 24)
 	daysText := textDays
 	if daysSince == 1 {
 		daysText = textDay
@@ -312,7 +446,7 @@ func (c *Client) FormatNewChannelAnnouncementDryRun(channels []Channel, since ti
 
 	for i, ch := range channels {
 		// Calculate days since creation
-		daysSinceCreated := int(time.Since(ch.Created).Hours() / 24)
+		daysSinceCreated := int(c.Now().Sub(ch.Created).Hours() / 24)
 		daysText := "days"
 		if daysSinceCreated == 1 {
 			daysText = "day"
@@ -347,44 +481,44 @@ func (c *Client) FormatNewChannelAnnouncementDryRun(channels []Channel, since ti
 	return builder.String()
 }
 
-func (c *Client) CheckForDuplicateAnnouncement(channel, newMessage string, channelNames []string) (bool, error) {
+func (c *Client) CheckForDuplicateAnnouncement(ctx context.Context, channel, newMessage string, channelNames []string) (bool, error) {
 	// Use a default cutoff of 30 days ago for backward compatibility
-	cutoffTime := time.Now().Add(-30 * 24 * time.Hour)
-	isDuplicate, _, err := c.CheckForDuplicateAnnouncementWithDetails(channel, newMessage, channelNames, cutoffTime)
+	cutoffTime := c.Now().Add(-30 * 24 * time.Hour)
+	isDuplicate, _, err := c.CheckForDuplicateAnnouncementWithDetails(ctx, channel, newMessage, channelNames, cutoffTime)
 	return isDuplicate, err
 }
 
-func (c *Client) CheckForDuplicateAnnouncementWithDetails(channel, newMessage string, channelNames []string, cutoffTime time.Time) (bool, []string, error) {
+func (c *Client) CheckForDuplicateAnnouncementWithDetails(ctx context.Context, channel, newMessage string, channelNames []string, cutoffTime time.Time) (bool, []string, error) {
 	// Use empty channel list - will make API call to get channels
-	return c.CheckForDuplicateAnnouncementWithDetailsAndChannels(channel, newMessage, channelNames, cutoffTime, nil)
+	return c.CheckForDuplicateAnnouncementWithDetailsAndChannels(ctx, channel, newMessage, channelNames, cutoffTime, nil)
 }
 
-func (c *Client) CheckForDuplicateAnnouncementWithDetailsAndChannels(channel, newMessage string, channelNames []string, cutoffTime time.Time, allChannels []slack.Channel) (bool, []string, error) {
+func (c *Client) CheckForDuplicateAnnouncementWithDetailsAndChannels(ctx context.Context, channel, newMessage string, channelNames []string, cutoffTime time.Time, allChannels []slack.Channel) (bool, []string, error) {
 	logger.WithFields(logger.LogFields{
 		"channel":      channel,
 		"channel_list": strings.Join(channelNames, ", "),
 	}).Debug("Checking for duplicate announcements")
 
 	// Resolve channel name to channel ID
-	channelID, err := c.ResolveChannelNameToID(channel)
+	channelID, err := c.ResolveChannelNameToID(ctx, channel)
 	if err != nil {
 		return false, nil, fmt.Errorf("failed to find channel %s: %w", channel, err)
 	}
 
 	// Create name-to-ID mapping
-	channelNameToID, err := c.buildChannelNameToIDMapping(allChannels)
+	channelNameToID, err := c.buildChannelNameToIDMapping(ctx, allChannels)
 	if err != nil {
 		return false, nil, err
 	}
 
 	// Get bot authentication info
-	authInfo, err := c.TestAuth()
+	authInfo, err := c.TestAuth(ctx)
 	if err != nil {
 		return false, nil, fmt.Errorf("failed to get auth info: %w", err)
 	}
 
-	// Fetch channel history with retry logic
-	history, err := c.fetchChannelHistoryWithRetry(channelID, channel)
+	// Fetch recent channel history
+	history, err := c.fetchRecentChannelHistory(ctx, channelID, channel)
 	if err != nil {
 		return false, nil, err
 	}
@@ -404,7 +538,7 @@ func (c *Client) CheckForDuplicateAnnouncementWithDetailsAndChannels(channel, ne
 }
 
 // buildChannelNameToIDMapping creates a mapping from channel names to IDs.
-func (c *Client) buildChannelNameToIDMapping(allChannels []slack.Channel) (map[string]string, error) {
+func (c *Client) buildChannelNameToIDMapping(ctx context.Context, allChannels []slack.Channel) (map[string]string, error) {
 	var channelNameToID map[string]string
 	if allChannels != nil {
 		// Use provided channel list
@@ -415,7 +549,7 @@ func (c *Client) buildChannelNameToIDMapping(allChannels []slack.Channel) (map[s
 	} else {
 		// Fallback: get channels via API call
 		var apiErr error
-		channelNameToID, apiErr = c.getAllChannelNameToIDMap()
+		channelNameToID, apiErr = c.getAllChannelNameToIDMap(ctx)
 		if apiErr != nil {
 			logger.WithFields(logger.LogFields{
 				"error": apiErr.Error(),
@@ -426,33 +560,26 @@ func (c *Client) buildChannelNameToIDMapping(allChannels []slack.Channel) (map[s
 	return channelNameToID, nil
 }
 
-// fetchChannelHistoryWithRetry fetches channel history with retry logic for rate limits.
-func (c *Client) fetchChannelHistoryWithRetry(channelID, channel string) (*slack.GetConversationHistoryResponse, error) {
+// fetchRecentChannelHistory fetches the announcement channel's recent history
+// for duplicate detection. Failures other than cancellation are logged and
+// treated as an empty history so the announcement can still be posted.
+func (c *Client) fetchRecentChannelHistory(ctx context.Context, channelID, channel string) (*slack.GetConversationHistoryResponse, error) {
 	params := &slack.GetConversationHistoryParameters{
 		ChannelID: channelID,
 		Limit:     15, // Explicit limit to match API restriction
 	}
 
-	const maxRetries = 3
-	var history *slack.GetConversationHistoryResponse
-
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		var err error
-		history, err = c.api.GetConversationHistory(params)
-		if err != nil {
-			if shouldRetryOnRateLimit(err.Error(), attempt, maxRetries, channel) {
-				continue
-			}
-			// For non-rate-limit errors or final attempt
-			logger.WithFields(logger.LogFields{
-				"channel": channel,
-				"error":   err.Error(),
-			}).Warn("Failed to get channel history for duplicate check")
-			// Return empty history instead of nil to avoid null pointer issues
-			return &slack.GetConversationHistoryResponse{Messages: []slack.Message{}}, nil
+	history, err := c.api.GetConversationHistory(ctx, params)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
 		}
-		// Success - break out of retry loop
-		break
+		logger.WithFields(logger.LogFields{
+			"channel": channel,
+			"error":   err.Error(),
+		}).Warn("Failed to get channel history for duplicate check")
+		// Return empty history instead of nil to avoid null pointer issues
+		return &slack.GetConversationHistoryResponse{Messages: []slack.Message{}}, nil
 	}
 	return history, nil
 }
@@ -522,15 +649,8 @@ func (c *Client) findDuplicateChannelsInMessageWithIDs(messageText string, chann
 	return duplicates
 }
 
-func (c *Client) getAllChannelNameToIDMap() (map[string]string, error) {
-
-	// Get all channels in one API call
-	params := &slack.GetConversationsParameters{
-		Types: []string{"public_channel"},
-		Limit: 1000,
-	}
-
-	channels, _, err := c.api.GetConversations(params)
+func (c *Client) getAllChannelNameToIDMap(ctx context.Context) (map[string]string, error) {
+	channels, err := c.getAllConversations(ctx, false)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get conversations: %w", err)
 	}
@@ -544,7 +664,7 @@ func (c *Client) getAllChannelNameToIDMap() (map[string]string, error) {
 	return nameToID, nil
 }
 
-func (c *Client) PostMessage(channel, message string) error {
+func (c *Client) PostMessage(ctx context.Context, channel, message string) error {
 	logger.WithFields(logger.LogFields{
 		"channel":        channel,
 		"message_length": len(message),
@@ -560,12 +680,13 @@ func (c *Client) PostMessage(channel, message string) error {
 	}
 
 	// Resolve channel name to channel ID
-	channelID, err := c.ResolveChannelNameToID(channel)
+	channelID, err := c.ResolveChannelNameToID(ctx, channel)
 	if err != nil {
 		return fmt.Errorf("failed to find channel %s: %w", channel, err)
 	}
 
-	_, _, err = c.api.PostMessage(channelID, slack.MsgOptionText(message, false))
+	_, _, err = c.api.PostMessage(ctx, channelID, slack.MsgOptionText(message, false))
+	c.audit(AuditEvent{Action: AuditActionPost, ChannelID: channelID, Channel: strings.TrimPrefix(channel, "#")}, err)
 	if err != nil {
 		errStr := err.Error()
 		logger.WithFields(logger.LogFields{
@@ -575,21 +696,21 @@ func (c *Client) PostMessage(channel, message string) error {
 		}).Error("Failed to post message to Slack")
 
 		// Handle rate limiting
-		if strings.Contains(errStr, "rate_limited") {
-			return fmt.Errorf("rate limited by Slack API. Will retry with exponential backoff on next request")
+		if errors.Is(err, ErrRateLimited) {
+			return describe(err, "rate limited by Slack API after repeated retries. Please wait before running again")
 		}
 
-		if strings.Contains(errStr, "missing_scope") {
+		if errors.Is(err, ErrMissingScope) {
 			logger.Error("Missing chat:write OAuth scope")
-			return fmt.Errorf("missing required permission to post messages. Your bot needs the 'chat:write' OAuth scope.\nPlease add this scope in your Slack app settings at https://api.slack.com/apps")
+			return describe(withScope(err, "chat:write"), "missing required permission to post messages. Your bot needs the 'chat:write' OAuth scope.\nPlease add this scope in your Slack app settings at https://api.slack.com/apps")
 		}
-		if strings.Contains(errStr, "channel_not_found") {
+		if errors.Is(err, ErrChannelNotFound) {
 			logger.WithField("channel", channel).Error("Channel not found")
-			return fmt.Errorf("channel '%s' not found. Make sure the bot is added to the channel", channel)
+			return describe(err, "channel '%s' not found. Make sure the bot is added to the channel", channel)
 		}
-		if strings.Contains(errStr, "not_in_channel") {
+		if errors.Is(err, ErrNotInChannel) {
 			logger.WithField("channel", channel).Error("Bot not in channel")
-			return fmt.Errorf("bot is not a member of channel '%s'. Please add the bot to the channel", channel)
+			return describe(err, "bot is not a member of channel '%s'. Please add the bot to the channel", channel)
 		}
 		return fmt.Errorf("failed to post message to %s: %w", channel, err)
 	}
@@ -598,14 +719,14 @@ func (c *Client) PostMessage(channel, message string) error {
 	return nil
 }
 
-func (c *Client) TestAuth() (*AuthInfo, error) {
-	auth, err := c.api.AuthTest()
+func (c *Client) TestAuth(ctx context.Context) (*AuthInfo, error) {
+	auth, err := c.api.AuthTest(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get team info to construct workspace URL
-	teamInfo, err := c.api.GetTeamInfo()
+	teamInfo, err := c.api.GetTeamInfo(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get team info: %w", err)
 	}
@@ -622,114 +743,19 @@ func (c *Client) TestAuth() (*AuthInfo, error) {
 	}, nil
 }
 
-func (c *Client) CheckOAuthScopes() (map[string]bool, error) {
-	_, err := c.api.AuthTest()
-	if err != nil {
-		return nil, fmt.Errorf("failed to authenticate: %w", err)
-	}
-
-	// Test each required scope individually by trying operations that require them
-	// This is more reliable than trying to parse scope information from auth response
-
-	scopeResults := make(map[string]bool)
-
-	// Test each required scope individually by trying operations that require them
-	scopeResults["channels:read"] = c.testChannelsReadScope()
-	scopeResults["channels:join"] = c.testChannelsJoinScope()
-	scopeResults["channels:history"] = c.testChannelsHistoryScope()
-	scopeResults["chat:write"] = c.testChatWriteScope()
-	scopeResults["channels:manage"] = c.testChannelsManageScope()
-	scopeResults["users:read"] = c.testUsersReadScope()
-
-	return scopeResults, nil
-}
-
-func (c *Client) testChannelsReadScope() bool {
-	// Try to get conversations - this requires channels:read
-	_, _, err := c.api.GetConversations(&slack.GetConversationsParameters{
-		Types: []string{"public_channel"},
-		Limit: 1,
-	})
-
-	if err != nil && strings.Contains(err.Error(), "missing_scope") {
-		return false
-	}
-	return true
-}
-
-func (c *Client) testChannelsJoinScope() bool {
-	// We can't easily test this without actually trying to join a channel
-	// For now, we'll assume it's available if channels:read works
-	// In a real scenario, this would be tested when actually trying to join
-	return true
-}
-
-func (c *Client) testChannelsHistoryScope() bool {
-	// Try to get conversation history - this requires channels:history
-	// We'll try to get conversations first to find a channel to test with
-	conversations, _, err := c.api.GetConversations(&slack.GetConversationsParameters{
-		Types: []string{"public_channel"},
-		Limit: 1,
-	})
-
-	if err != nil || len(conversations) == 0 {
-		// If we can't get conversations, assume scope is available
-		// This will be tested when actually reading history
-		return true
-	}
-
-	// Try to get history from the first channel
-	_, err = c.api.GetConversationHistory(&slack.GetConversationHistoryParameters{
-		ChannelID: conversations[0].ID,
-		Limit:     1,
-	})
-
-	if err != nil && strings.Contains(err.Error(), "missing_scope") {
-		return false
-	}
-	return true
-}
-
-func (c *Client) testChatWriteScope() bool {
-	// We can't test this without actually posting a message
-	// For now, we'll assume it's available - it will be tested when actually posting
-	return true
-}
-
-func (c *Client) testChannelsManageScope() bool {
-	// We can't test this without actually trying to archive a channel
-	// For now, we'll assume it's available - it will be tested when actually archiving
-	return true
-}
-
-func (c *Client) testUsersReadScope() bool {
-	// Try to get users list - this requires users:read
-	_, err := c.api.GetUsers()
-
-	if err != nil && strings.Contains(err.Error(), "missing_scope") {
-		return false
-	}
-	return true
-}
-
 func (c *Client) GetChannelInfo(channelID string) (*Channel, error) {
 	// This is used for permission testing in health checks
 	// We'll just return a mock error for permission testing
-	return nil, fmt.Errorf("channel_not_found")
+	return nil, ErrChannelNotFound
 }
 
 // ResolveChannelNameToID converts a channel name (like "#general" or "general") to its Slack channel ID.
-func (c *Client) ResolveChannelNameToID(channelName string) (string, error) {
+func (c *Client) ResolveChannelNameToID(ctx context.Context, channelName string) (string, error) {
 	// Clean the channel name
 	cleanName := strings.TrimPrefix(channelName, "#")
 
 	// Get all channels to find the matching one
-	params := &slack.GetConversationsParameters{
-		Types: []string{"public_channel"},
-		Limit: 1000,
-	}
-
-	channels, _, err := c.api.GetConversations(params)
+	channels, err := c.getAllConversations(ctx, false)
 	if err != nil {
 		return "", fmt.Errorf("failed to get channels: %w", err)
 	}
@@ -744,20 +770,16 @@ func (c *Client) ResolveChannelNameToID(channelName string) (string, error) {
 	return "", fmt.Errorf("channel '%s' not found", channelName)
 }
 
-func (c *Client) GetInactiveChannels(warnSeconds int, archiveSeconds int) (toWarn []Channel, toArchive []Channel, err error) {
+func (c *Client) GetInactiveChannels(ctx context.Context, warnSeconds int, archiveSeconds int) (toWarn []Channel, toArchive []Channel, err error) {
 	logger.WithFields(logger.LogFields{
 		"warn_seconds":    warnSeconds,
 		"archive_seconds": archiveSeconds,
 	}).Debug("Starting inactive channel detection")
 
-	warnCutoff := time.Now().Add(-time.Duration(warnSeconds) * time.Second)
+	warnCutoff := c.Now().Add(-time.Duration(warnSeconds) * time.Second)
 
 	// Get all channels
-	allChannels, _, err := c.api.GetConversations(&slack.GetConversationsParameters{
-		Types:           []string{"public_channel"},
-		Limit:           1000,
-		ExcludeArchived: true,
-	})
+	allChannels, err := c.getAllConversations(ctx, true)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get conversations: %w", err)
 	}
@@ -770,7 +792,7 @@ func (c *Client) GetInactiveChannels(warnSeconds int, archiveSeconds int) (toWar
 	if len(candidateChannels) > 0 {
 		fmt.Printf("🤖 Joining %d public channels for analysis...\n", len(candidateChannels))
 	}
-	joinedCount, err := c.autoJoinPublicChannels(candidateChannels)
+	joinedCount, err := c.autoJoinPublicChannels(ctx, candidateChannels)
 	if err != nil {
 		return toWarn, toArchive, fmt.Errorf("failed to auto-join channels - inactive detection requires channel membership: %w", err)
 	}
@@ -780,7 +802,7 @@ func (c *Client) GetInactiveChannels(warnSeconds int, archiveSeconds int) (toWar
 	logger.WithField("joined_count", joinedCount).Debug("Auto-joined public channels")
 
 	// Analyze channels for inactivity
-	return c.analyzeChannelsForBasicInactivity(candidateChannels, warnCutoff, archiveSeconds)
+	return c.analyzeChannelsForBasicInactivity(ctx, candidateChannels, warnCutoff, archiveSeconds)
 }
 
 // logInactiveChannelsFilteringStats logs filtering statistics for basic inactive channel detection.
@@ -789,22 +811,24 @@ func (c *Client) logInactiveChannelsFilteringStats(totalChannels, candidateChann
 		"total_channels":     totalChannels,
 		"candidate_channels": candidateChannels,
 		"skipped_new":        stats.skippedNew,
-		"skipped_excluded":   stats.skippedExcluded,
+		"skipped_protected":  stats.skippedProtected,
 		"skipped_active":     stats.skippedActive,
 	}).Debug("Pre-filtered channels using metadata")
 }
 
 // analyzeChannelsForBasicInactivity analyzes channels for basic inactivity without detailed reporting.
-func (c *Client) analyzeChannelsForBasicInactivity(candidateChannels []slack.Channel, warnCutoff time.Time, archiveSeconds int) (toWarn []Channel, toArchive []Channel, err error) {
+func (c *Client) analyzeChannelsForBasicInactivity(ctx context.Context, candidateChannels []slack.Channel, warnCutoff time.Time, archiveSeconds int) (toWarn []Channel, toArchive []Channel, err error) {
 	for i, ch := range candidateChannels {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return toWarn, toArchive, ctxErr
+		}
 		logger.WithFields(logger.LogFields{
 			"channel": ch.Name,
 			"index":   i + 1,
 			"total":   len(candidateChannels),
 		}).Debug("Checking message history for candidate channel")
 
-		// Get channel activity with retry for rate limits
-		lastActivity, hasWarning, warningTime, err := c.getChannelActivityWithRetry(ch.ID, ch.Name)
+		lastActivity, hasWarning, warningTime, err := c.getChannelActivity(ctx, ch.ID)
 		if err != nil {
 			if c.handleBasicChannelActivityError(err, ch.Name) {
 				return toWarn, toArchive, fmt.Errorf("rate limited by Slack API while processing channel %s: %w", ch.Name, err)
@@ -841,11 +865,10 @@ func (c *Client) analyzeChannelsForBasicInactivity(candidateChannels []slack.Cha
 
 // handleBasicChannelActivityError handles errors during basic channel activity retrieval.
 func (c *Client) handleBasicChannelActivityError(err error, channelName string) bool {
-	errStr := err.Error()
-	if strings.Contains(errStr, "rate_limited") || strings.Contains(errStr, "rate limit") {
+	if errors.Is(err, ErrRateLimited) {
 		logger.WithFields(logger.LogFields{
 			"channel": channelName,
-			"error":   errStr,
+			"error":   err.Error(),
 		}).Warn("Rate limited by Slack API - this affects all subsequent requests, stopping analysis")
 		return true
 	}
@@ -854,8 +877,7 @@ func (c *Client) handleBasicChannelActivityError(err error, channelName string)
 
 // handleChannelNotInBotError handles the case where bot is not in channel.
 func (c *Client) handleChannelNotInBotError(err error) (time.Time, bool, time.Time) {
-	errStr := err.Error()
-	if strings.Contains(errStr, "not_in_channel") {
+	if errors.Is(err, ErrNotInChannel) {
 		// Bot isn't in channel, treat as potentially inactive
 		return time.Unix(0, 0), false, time.Time{}
 	}
@@ -884,12 +906,13 @@ func (c *Client) createBasicChannel(ch slack.Channel, lastActivity time.Time) Ch
 		LastActivity: lastActivity,
 		MemberCount:  ch.NumMembers,
 		IsArchived:   ch.IsArchived,
+		IsPrivate:    ch.IsPrivate,
 	}
 }
 
 // shouldArchiveBasicChannel determines if a basic channel should be archived.
 func (c *Client) shouldArchiveBasicChannel(lastActivity, warningTime time.Time, archiveSeconds int) bool {
-	gracePeriodExpired := time.Since(warningTime) > time.Duration(archiveSeconds)*time.Second
+	gracePeriodExpired := c.Now().Sub(warningTime) > time.Duration(archiveSeconds)*time.Second
 	return lastActivity.Before(warningTime) && gracePeriodExpired
 }
 
@@ -911,20 +934,16 @@ func (c *Client) logBasicChannelDecision(channelName, decision string, lastActiv
 }
 
 // GetInactiveChannelsWithDetails returns inactive channels with detailed message information and user name resolution.
-func (c *Client) GetInactiveChannelsWithDetails(warnSeconds int, archiveSeconds int, userMap map[string]string, isDebug bool) (toWarn []Channel, toArchive []Channel, err error) {
+func (c *Client) GetInactiveChannelsWithDetails(ctx context.Context, warnSeconds int, archiveSeconds int, userMap map[string]string, isDebug bool) (toWarn []Channel, toArchive []Channel, err error) {
 	logger.WithFields(logger.LogFields{
 		"warn_seconds":    warnSeconds,
 		"archive_seconds": archiveSeconds,
 	}).Debug("Starting inactive channel detection with message details")
 
-	warnCutoff := time.Now().Add(-time.Duration(warnSeconds) * time.Second)
+	warnCutoff := c.Now().Add(-time.Duration(warnSeconds) * time.Second)
 
 	// Get all channels
-	allChannels, _, err := c.api.GetConversations(&slack.GetConversationsParameters{
-		Types:           []string{"public_channel"},
-		Limit:           1000,
-		ExcludeArchived: true,
-	})
+	allChannels, err := c.getAllConversations(ctx, true)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get conversations: %w", err)
 	}
@@ -934,18 +953,18 @@ func (c *Client) GetInactiveChannelsWithDetails(warnSeconds int, archiveSeconds
 	c.logChannelFilteringStatsSimple(len(allChannels), len(candidateChannels), stats, isDebug)
 
 	// Auto-join channels and analyze activity
-	joinedCount, err := c.autoJoinChannelsForAnalysis(candidateChannels, isDebug)
+	joinedCount, err := c.autoJoinChannelsForAnalysis(ctx, candidateChannels, isDebug)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to auto-join channels - inactive detection requires channel membership: %w", err)
 	}
 	logger.WithField("joined_count", joinedCount).Debug("Auto-joined public channels")
 
 	// Analyze each candidate channel for activity (no warn-only mode, no rewarn)
-	return c.analyzeChannelsForInactivity(candidateChannels, userMap, warnCutoff, archiveSeconds, isDebug, false, 0)
+	return c.analyzeChannelsForInactivity(ctx, candidateChannels, userMap, warnCutoff, archiveSeconds, isDebug, false, 0)
 }
 
 // GetInactiveChannelsWithDetailsAndExclusions returns inactive channels with exclusion support.
-func (c *Client) GetInactiveChannelsWithDetailsAndExclusions(warnSeconds int, archiveSeconds int, userMap map[string]string, excludeChannels, excludePrefixes []string, isDebug bool, warnOnlyMode bool, rewarnSeconds int) (toWarn []Channel, toArchive []Channel, totalChannels int, err error) {
+func (c *Client) GetInactiveChannelsWithDetailsAndExclusions(ctx context.Context, warnSeconds int, archiveSeconds int, userMap map[string]string, excludeChannels, excludePrefixes []string, isDebug bool, warnOnlyMode bool, rewarnSeconds int) (toWarn []Channel, toArchive []Channel, totalChannels int, err error) {
 	logger.WithFields(logger.LogFields{
 		"warn_seconds":     warnSeconds,
 		"archive_seconds":  archiveSeconds,
@@ -955,14 +974,10 @@ func (c *Client) GetInactiveChannelsWithDetailsAndExclusions(warnSeconds int, ar
 		"rewarn_seconds":   rewarnSeconds,
 	}).Debug("Starting inactive channel detection with message details and exclusions")
 
-	warnCutoff := time.Now().Add(-time.Duration(warnSeconds) * time.Second)
+	warnCutoff := c.Now().Add(-time.Duration(warnSeconds) * time.Second)
 
 	// Get all channels
-	allChannels, _, err := c.api.GetConversations(&slack.GetConversationsParameters{
-		Types:           []string{"public_channel"},
-		Limit:           1000,
-		ExcludeArchived: true,
-	})
+	allChannels, err := c.getAllConversations(ctx, true)
 	if err != nil {
 		return nil, nil, 0, fmt.Errorf("failed to get conversations: %w", err)
 	}
@@ -972,30 +987,34 @@ func (c *Client) GetInactiveChannelsWithDetailsAndExclusions(warnSeconds int, ar
 	c.logChannelFilteringStats(len(allChannels), len(candidateChannels), stats, isDebug)
 
 	// Auto-join channels and analyze activity
-	joinedCount, err := c.autoJoinChannelsForAnalysis(candidateChannels, isDebug)
+	joinedCount, err := c.autoJoinChannelsForAnalysis(ctx, candidateChannels, isDebug)
 	if err != nil {
 		return nil, nil, 0, fmt.Errorf("failed to auto-join channels - inactive detection requires channel membership: %w", err)
 	}
 	logger.WithField("joined_count", joinedCount).Debug("Auto-joined public channels")
 
 	// Analyze each candidate channel for activity
-	toWarn, toArchive, err = c.analyzeChannelsForInactivity(candidateChannels, userMap, warnCutoff, archiveSeconds, isDebug, warnOnlyMode, rewarnSeconds)
+	toWarn, toArchive, err = c.analyzeChannelsForInactivity(ctx, candidateChannels, userMap, warnCutoff, archiveSeconds, isDebug, warnOnlyMode, rewarnSeconds)
 	return toWarn, toArchive, len(candidateChannels), err
 }
 
 // channelFilterStats holds statistics about channel filtering.
 type channelFilterStats struct {
 	skippedActive       int
-	skippedExcluded     int
+	skippedProtected    int
 	skippedNew          int
 	skippedUserExcluded int
 	skippedExtShared    int
+	skippedPolicy       int
+	// keptByMarker lists the channels opted out with KeepMarker.
+	keptByMarker []string
 }
 
 // preFilterChannelsWithExclusions filters channels using metadata and exclusions to reduce API calls.
 func (c *Client) preFilterChannelsWithExclusions(allChannels []slack.Channel, warnCutoff time.Time, excludeChannels, excludePrefixes []string) ([]slack.Channel, channelFilterStats) {
 	candidateChannels := make([]slack.Channel, 0, len(allChannels))
 	stats := channelFilterStats{}
+	now := c.Now()
 
 	for _, ch := range allChannels {
 		if !c.includeExtShared && (ch.IsExtShared || ch.IsPendingExtShared) {
@@ -1014,17 +1033,31 @@ func (c *Client) preFilterChannelsWithExclusions(allChannels []slack.Channel, wa
 		}
 
 		if c.shouldSkipChannel(ch.Name) {
-			stats.skippedExcluded++
+			stats.skippedProtected++
+			continue
+		}
+
+		params, rule := c.policyParams(ch, channelAnalysisParams{warnCutoff: warnCutoff, now: now})
+		if params == nil {
+			logger.WithFields(logger.LogFields{
+				"channel": ch.Name,
+				"rule":    rule.Describe(),
+			}).Debug("Skipping channel protected by archive policy or marker")
+			if rule.fromMarker {
+				stats.keptByMarker = append(stats.keptByMarker, ch.Name)
+			} else {
+				stats.skippedPolicy++
+			}
 			continue
 		}
 
 		created := time.Unix(int64(ch.Created), 0)
-		if created.After(warnCutoff) {
+		if created.After(params.warnCutoff) {
 			stats.skippedNew++
 			continue
 		}
 
-		if c.seemsActiveFromMetadata(ch, warnCutoff) {
+		if c.seemsActiveFromMetadata(ch, params.warnCutoff) {
 			stats.skippedActive++
 			continue
 		}
@@ -1041,14 +1074,14 @@ func (c *Client) logChannelFilteringStatsSimple(totalChannels, candidateChannels
 		"total_channels":     totalChannels,
 		"candidate_channels": candidateChannels,
 		"skipped_active":     stats.skippedActive,
-		"skipped_excluded":   stats.skippedExcluded,
+		"skipped_protected":  stats.skippedProtected,
 		"skipped_new":        stats.skippedNew,
 	}).Debug("Pre-filtered channels using metadata")
 
 	fmt.Printf("📞 API Call 2: Getting channel list with metadata...\n")
 	fmt.Printf("✅ Got %d channels from API\n", totalChannels)
-	fmt.Printf("   Pre-filtered to %d candidates (skipped %d active, %d excluded, %d too new)\n\n",
-		candidateChannels, stats.skippedActive, stats.skippedExcluded, stats.skippedNew)
+	fmt.Printf("   Pre-filtered to %d candidates (skipped %d active, %d name-protected, %d too new)\n\n",
+		candidateChannels, stats.skippedActive, stats.skippedProtected, stats.skippedNew)
 }
 
 // logChannelFilteringStats logs and optionally prints channel filtering statistics.
@@ -1057,32 +1090,37 @@ func (c *Client) logChannelFilteringStats(totalChannels, candidateChannels int,
 		"total_channels":        totalChannels,
 		"candidate_channels":    candidateChannels,
 		"skipped_active":        stats.skippedActive,
-		"skipped_excluded":      stats.skippedExcluded,
+		"skipped_protected":     stats.skippedProtected,
 		"skipped_new":           stats.skippedNew,
 		"skipped_user_excluded": stats.skippedUserExcluded,
 		"skipped_ext_shared":    stats.skippedExtShared,
+		"skipped_policy":        stats.skippedPolicy,
+		"skipped_marker":        len(stats.keptByMarker),
 	}).Debug("Pre-filtered channels using metadata and exclusions")
 
 	if isDebug {
 		fmt.Printf("📞 API Call 2: Getting channel list with metadata...\n")
 		fmt.Printf("✅ Got %d channels from API\n", totalChannels)
-		fmt.Printf("   Pre-filtered to %d candidates (skipped %d active, %d excluded, %d too new, %d user-excluded, %d ext-shared)\n\n",
-			candidateChannels, stats.skippedActive, stats.skippedExcluded, stats.skippedNew, stats.skippedUserExcluded, stats.skippedExtShared)
+		fmt.Printf("   Pre-filtered to %d candidates (skipped %d active, %d name-protected, %d too new, %d user-excluded, %d ext-shared, %d policy-protected, %d marker-kept)\n\n",
+			candidateChannels, stats.skippedActive, stats.skippedProtected, stats.skippedNew, stats.skippedUserExcluded, stats.skippedExtShared, stats.skippedPolicy, len(stats.keptByMarker))
+	}
+
+	if len(stats.keptByMarker) > 0 {
+		fmt.Printf("🔖 Kept by %s in topic or purpose: #%s\n\n", KeepMarker, strings.Join(stats.keptByMarker, ", #"))
 	}
 }
 
 // autoJoinChannelsForAnalysis auto-joins public channels before analysis.
-func (c *Client) autoJoinChannelsForAnalysis(candidateChannels []slack.Channel, isDebug bool) (int, error) {
+func (c *Client) autoJoinChannelsForAnalysis(ctx context.Context, candidateChannels []slack.Channel, isDebug bool) (int, error) {
 	// Count how many channels need joining vs already member
 	needsJoining := 0
 	alreadyMember := 0
 	for _, ch := range candidateChannels {
-		if !ch.IsPrivate {
-			if ch.IsMember {
-				alreadyMember++
-			} else {
-				needsJoining++
-			}
+		switch {
+		case ch.IsMember:
+			alreadyMember++
+		case !ch.IsPrivate:
+			needsJoining++
 		}
 	}
 
@@ -1096,7 +1134,7 @@ func (c *Client) autoJoinChannelsForAnalysis(candidateChannels []slack.Channel,
 			fmt.Printf("📞 API Calls 3+: Auto-joining public channels for accurate analysis...\n")
 		}
 	}
-	joinedCount, err := c.autoJoinPublicChannels(candidateChannels)
+	joinedCount, err := c.autoJoinPublicChannels(ctx, candidateChannels)
 	if len(candidateChannels) > 0 {
 		if joinedCount > 0 {
 			fmt.Printf("✅ Successfully joined %d channels\n\n", joinedCount)
@@ -1114,6 +1152,7 @@ func (c *Client) autoJoinChannelsForAnalysis(candidateChannels []slack.Channel,
 
 // channelAnalysisParams holds parameters for channel analysis decisions.
 type channelAnalysisParams struct {
+	now            time.Time
 	warnCutoff     time.Time
 	archiveSeconds int
 	warnOnlyMode   bool
@@ -1123,32 +1162,57 @@ type channelAnalysisParams struct {
 // analyzeChannelsForInactivity analyzes each candidate channel for activity and categorizes them.
 // When warnOnlyMode is true, archival is skipped entirely.
 // When rewarnSeconds > 0, channels with warnings older than rewarnSeconds are re-warned.
-func (c *Client) analyzeChannelsForInactivity(candidateChannels []slack.Channel, userMap map[string]string, warnCutoff time.Time, archiveSeconds int, isDebug bool, warnOnlyMode bool, rewarnSeconds int) (toWarn []Channel, toArchive []Channel, err error) {
-	now := time.Now()
+func (c *Client) analyzeChannelsForInactivity(ctx context.Context, candidateChannels []slack.Channel, userMap map[string]string, warnCutoff time.Time, archiveSeconds int, isDebug bool, warnOnlyMode bool, rewarnSeconds int) (toWarn []Channel, toArchive []Channel, err error) {
+	now := c.Now()
 	params := channelAnalysisParams{
+		now:            now,
 		warnCutoff:     warnCutoff,
 		archiveSeconds: archiveSeconds,
 		warnOnlyMode:   warnOnlyMode,
 		rewarnSeconds:  rewarnSeconds,
 	}
 
-	for i, ch := range candidateChannels {
-		lastActivity, hasWarning, warningTime, lastMessage, err := c.GetChannelActivityWithMessageAndUsers(ch.ID, userMap)
-		if err != nil {
+	results := make([]channelActivityResult, len(candidateChannels))
+	runOrdered(c.Concurrency(), len(candidateChannels), func(i int) {
+		results[i] = c.fetchChannelActivity(ctx, candidateChannels[i].ID, userMap)
+	}, func(i int) bool {
+		ch, result := candidateChannels[i], results[i]
+		if err = result.err; err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				err = ctxErr
+				return false
+			}
 			if c.handleChannelAnalysisError(err, ch.Name, isDebug) {
-				return toWarn, toArchive, fmt.Errorf("rate limited by Slack API")
+				err = describe(err, "rate limited by Slack API")
+				return false
 			}
-			continue
+			err = nil
+			return true
 		}
 
 		if isDebug {
 			fmt.Printf("✅ API Call succeeded\n")
 		}
 
-		enhancedChannel := c.createEnhancedChannel(ch, lastActivity, lastMessage)
-		c.displayChannelAnalysis(ch, lastActivity, hasWarning, warningTime, lastMessage, now, i, len(candidateChannels))
+		channelParams, rule := c.policyParams(ch, params)
+		if channelParams == nil {
+			return true
+		}
+		enhancedChannel := c.createEnhancedChannel(ch, result.lastActivity, result.lastMessage)
+		applyPolicyRule(&enhancedChannel, rule)
+		enhancedChannel.WarnedAt = result.warningTime
+		enhancedChannel.SnoozedUntil = result.snoozedUntil
+		enhancedChannel.KeepVoters = result.keepVoters
+		c.displayChannelAnalysis(ch, result.lastActivity, result.hasWarning, result.warningTime, result.lastMessage, now, i, len(candidateChannels))
+		if result.snoozedUntil.After(now) {
+			fmt.Printf("    └─ 💤 Archival snoozed until %s\n", result.snoozedUntil.Local().Format("2006-01-02 15:04"))
+		}
 
-		toWarn, toArchive = c.categorizeChannel(enhancedChannel, hasWarning, warningTime, lastActivity, params, toWarn, toArchive)
+		toWarn, toArchive = c.categorizeChannel(enhancedChannel, result.hasWarning, result.warningTime, result.lastActivity, *channelParams, toWarn, toArchive)
+		return true
+	})
+	if err != nil {
+		return toWarn, toArchive, err
 	}
 
 	logger.WithFields(logger.LogFields{
@@ -1160,8 +1224,46 @@ func (c *Client) analyzeChannelsForInactivity(candidateChannels []slack.Channel,
 	return toWarn, toArchive, nil
 }
 
+// channelActivityResult is the activity of one channel fetched by a worker.
+type channelActivityResult struct {
+	lastActivity time.Time
+	warningTime  time.Time
+	snoozedUntil time.Time
+	lastMessage  *MessageInfo
+	err          error
+	keepVoters   []string
+	hasWarning   bool
+}
+
+// fetchChannelActivity fetches a channel's activity; it is safe to call from
+// several goroutines.
+func (c *Client) fetchChannelActivity(ctx context.Context, channelID string, userMap map[string]string) channelActivityResult {
+	if err := ctx.Err(); err != nil {
+		return channelActivityResult{err: err}
+	}
+	result := c.getChannelActivityResult(ctx, channelID)
+	if result.lastMessage != nil {
+		result.lastMessage = resolveMessageUser(result.lastMessage, userMap)
+	}
+	for i, voter := range result.keepVoters {
+		if name := userMap[voter]; name != "" {
+			result.keepVoters[i] = name
+		}
+	}
+	return result
+}
+
 // categorizeChannel decides whether to warn or archive a channel based on its state.
 func (c *Client) categorizeChannel(channel Channel, hasWarning bool, warningTime, lastActivity time.Time, params channelAnalysisParams, toWarn, toArchive []Channel) ([]Channel, []Channel) {
+	if channel.SnoozedUntil.After(params.now) {
+		c.logChannelDecision(channel.Name, "snoozed", channel.SnoozedUntil)
+		return toWarn, toArchive
+	}
+	// A snooze that ended after the warning restarts the grace period from
+	// its end, so the channel isn't archived the moment the snooze is over.
+	if hasWarning && channel.SnoozedUntil.After(warningTime) {
+		warningTime = channel.SnoozedUntil
+	}
 	if params.warnOnlyMode {
 		return c.categorizeChannelWarnOnly(channel, hasWarning, warningTime, lastActivity, params, toWarn), toArchive
 	}
@@ -1183,6 +1285,12 @@ func (c *Client) categorizeChannelWarnOnly(channel Channel, hasWarning bool, war
 
 // categorizeChannelNormal handles channel categorization in normal (archive) mode.
 func (c *Client) categorizeChannelNormal(channel Channel, hasWarning bool, warningTime, lastActivity time.Time, params channelAnalysisParams, toWarn, toArchive []Channel) ([]Channel, []Channel) {
+	// A keep vote on the warning resets the grace period: warn again
+	// instead of archiving.
+	if hasWarning && len(channel.KeepVoters) > 0 {
+		c.logChannelDecision(channel.Name, "kept", warningTime)
+		return append(toWarn, channel), toArchive
+	}
 	if hasWarning && c.shouldArchiveChannel(warningTime, params.archiveSeconds) {
 		c.logChannelDecision(channel.Name, "archival", warningTime)
 		return toWarn, append(toArchive, channel)
@@ -1197,7 +1305,7 @@ func (c *Client) categorizeChannelNormal(channel Channel, hasWarning bool, warni
 // shouldRewarnChannel determines if a channel should be re-warned based on warning age.
 // Returns true if the warning is older than rewarnSeconds.
 func (c *Client) shouldRewarnChannel(warningTime time.Time, rewarnSeconds int) bool {
-	warningAge := time.Since(warningTime)
+	warningAge := c.Now().Sub(warningTime)
 	return warningAge > time.Duration(rewarnSeconds)*time.Second
 }
 
@@ -1209,7 +1317,7 @@ func (c *Client) handleChannelAnalysisError(err error, channelName string, isDeb
 		"error":   errStr,
 	}).Error("Failed to get channel activity")
 
-	if strings.Contains(errStr, "rate_limited") || strings.Contains(errStr, "rate limit") {
+	if errors.Is(err, ErrRateLimited) {
 		logger.WithFields(logger.LogFields{
 			"channel": channelName,
 			"error":   errStr,
@@ -1238,6 +1346,7 @@ func (c *Client) createEnhancedChannel(ch slack.Channel, lastActivity time.Time,
 		LastActivity: lastActivity,
 		MemberCount:  ch.NumMembers,
 		IsArchived:   ch.IsArchived,
+		IsPrivate:    ch.IsPrivate,
 		LastMessage:  lastMessage,
 	}
 }
@@ -1253,22 +1362,22 @@ func (c *Client) displayChannelAnalysis(ch slack.Channel, lastActivity time.Time
 }
 
 // GetRandomChannels gets all channels and returns a random subset of the specified count.
-func (c *Client) GetRandomChannels(count int) ([]Channel, error) {
+func (c *Client) GetRandomChannels(ctx context.Context, count int) ([]Channel, error) {
 	logger.WithField("count", count).Debug("Fetching all channels for random selection")
 
 	// Get all public channels
-	allSlackChannels, _, err := c.api.GetConversations(&slack.GetConversationsParameters{
-		Types:           []string{"public_channel"},
-		Limit:           1000,
-		ExcludeArchived: true,
-	})
+	allSlackChannels, err := c.getAllConversations(ctx, true)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get conversations: %w", err)
 	}
 
-	// Convert to our Channel type
+	// Convert to our Channel type. Private channels are never highlighted
+	// since the highlight is posted publicly.
 	channels := make([]Channel, 0, len(allSlackChannels))
 	for _, ch := range allSlackChannels {
+		if ch.IsPrivate {
+			continue
+		}
 		channels = append(channels, Channel{
 			ID:          ch.ID,
 			Name:        ch.Name,
@@ -1395,7 +1504,7 @@ func (c *Client) displayMessageDetails(lastMessage *MessageInfo) {
 
 // shouldArchiveChannel determines if a channel should be archived based on warning time.
 func (c *Client) shouldArchiveChannel(warningTime time.Time, archiveSeconds int) bool {
-	return time.Since(warningTime) > time.Duration(archiveSeconds)*time.Second
+	return c.Now().Sub(warningTime) > time.Duration(archiveSeconds)*time.Second
 }
 
 // shouldWarnChannel determines if a channel should receive a warning based on activity.
@@ -1416,8 +1525,18 @@ func (c *Client) logChannelDecision(channelName, decision string, timestamp time
 		logger.WithFields(logger.LogFields{
 			"channel":       channelName,
 			"last_activity": timestamp.Format("2006-01-02 15:04:05"),
-			"inactive_for":  time.Since(timestamp).String(),
+			"inactive_for":  c.Now().Sub(timestamp).String(),
 		}).Debug("Channel marked for warning")
+	case "kept":
+		logger.WithFields(logger.LogFields{
+			"channel":      channelName,
+			"warning_time": timestamp.Format("2006-01-02 15:04:05"),
+		}).Debug("Channel marked for warning - members voted to keep it")
+	case "snoozed":
+		logger.WithFields(logger.LogFields{
+			"channel":       channelName,
+			"snoozed_until": timestamp.Format("2006-01-02 15:04:05"),
+		}).Debug("Channel skipped - archival snoozed")
 	}
 }
 
@@ -1447,6 +1566,17 @@ func (c *Client) shouldSkipChannelWithExclusions(channelName string, excludeChan
 		}
 	}
 
+	// Check pattern exclusions
+	if exclusion, ok := c.matchExclusion(channelName, c.Now()); ok {
+		logger.WithFields(logger.LogFields{
+			"channel": channelName,
+			"reason":  "pattern_match",
+			"pattern": exclusion.Pattern,
+			"note":    exclusion.Reason,
+		}).Debug("Skipping channel due to user pattern exclusion")
+		return true
+	}
+
 	return false
 }
 
@@ -1483,15 +1613,9 @@ func formatDuration(d time.Duration) string {
 	return fmt.Sprintf("%d days", days)
 }
 
-func (c *Client) getChannelActivityWithRetry(channelID, channelName string) (lastActivity time.Time, hasWarning bool, warningTime time.Time, err error) {
-	// Just call the base function - rate limiting should be handled globally
-	// If we get rate limited, that affects the entire API, so we should stop processing
-	return c.getChannelActivity(channelID)
-}
-
-func (c *Client) getChannelActivity(channelID string) (lastActivity time.Time, hasWarning bool, warningTime time.Time, err error) {
+func (c *Client) getChannelActivity(ctx context.Context, channelID string) (lastActivity time.Time, hasWarning bool, warningTime time.Time, err error) {
 	// Fetch initial channel history
-	history, err := c.fetchInitialChannelHistory(channelID)
+	history, err := c.fetchInitialChannelHistory(ctx, channelID)
 	if err != nil {
 		return time.Time{}, false, time.Time{}, err
 	}
@@ -1501,12 +1625,12 @@ func (c *Client) getChannelActivity(channelID string) (lastActivity time.Time, h
 	}
 
 	// Analyze messages for recent activity
-	botUserID := c.getBotUserID()
+	botUserID := c.getBotUserID(ctx)
 	lastRealMsg, lastRealMsgTime := c.findMostRecentRealMessage(history.Messages, botUserID)
 
 	// Determine if we need detailed analysis
 	if c.needsDetailedAnalysis(lastRealMsg, botUserID) {
-		return c.getDetailedChannelActivity(channelID, botUserID)
+		return c.getDetailedChannelActivity(ctx, channelID, botUserID)
 	}
 
 	// If the last real message is from a user, that's our activity time
@@ -1515,35 +1639,19 @@ func (c *Client) getChannelActivity(channelID string) (lastActivity time.Time, h
 	}
 
 	// Need detailed analysis for other cases
-	return c.getDetailedChannelActivity(channelID, botUserID)
+	return c.getDetailedChannelActivity(ctx, channelID, botUserID)
 }
 
-// fetchInitialChannelHistory fetches initial channel history with retry logic.
-func (c *Client) fetchInitialChannelHistory(channelID string) (*slack.GetConversationHistoryResponse, error) {
-	const maxRetries = 3
-	var history *slack.GetConversationHistoryResponse
-
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		params := &slack.GetConversationHistoryParameters{
-			ChannelID: channelID,
-			Limit:     10, // Get enough messages to find real ones past any system messages
-		}
+// fetchInitialChannelHistory fetches the most recent messages in a channel.
+func (c *Client) fetchInitialChannelHistory(ctx context.Context, channelID string) (*slack.GetConversationHistoryResponse, error) {
+	params := &slack.GetConversationHistoryParameters{
+		ChannelID: channelID,
+		Limit:     10, // Get enough messages to find real ones past any system messages
+	}
 
-		var err error
-		history, err = c.api.GetConversationHistory(params)
-		if err != nil {
-			if shouldRetryOnRateLimitSimple(err.Error(), attempt, maxRetries) {
-				continue
-			}
-			errStr := err.Error()
-			if strings.Contains(errStr, "rate_limited") || strings.Contains(errStr, "rate limit") {
-				return nil, fmt.Errorf("failed to get channel history after %d attempts, final Slack error: %s", maxRetries, errStr)
-			}
-			// Non-rate-limit errors - don't retry
-			return nil, fmt.Errorf("failed to get channel history: %w", err)
-		}
-		// Success - break out of retry loop
-		break
+	history, err := c.api.GetConversationHistory(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channel history: %w", err)
 	}
 	return history, nil
 }
@@ -1577,9 +1685,9 @@ func (c *Client) needsDetailedAnalysis(lastRealMsg *slack.Message, botUserID str
 	return false
 }
 
-func (c *Client) getDetailedChannelActivity(channelID, botUserID string) (lastActivity time.Time, hasWarning bool, warningTime time.Time, err error) {
-	// Fetch detailed channel history with retry logic
-	history, err := c.fetchDetailedChannelHistory(channelID)
+func (c *Client) getDetailedChannelActivity(ctx context.Context, channelID, botUserID string) (lastActivity time.Time, hasWarning bool, warningTime time.Time, err error) {
+	// Fetch a deeper slice of channel history
+	history, err := c.fetchDetailedChannelHistory(ctx, channelID)
 	if err != nil {
 		return time.Time{}, false, time.Time{}, err
 	}
@@ -1601,65 +1709,14 @@ func (c *Client) getDetailedChannelActivity(channelID, botUserID string) (lastAc
 	return mostRecentActivity, hasWarningMessage, mostRecentWarning, nil
 }
 
-// fetchDetailedChannelHistory fetches detailed channel history with retry logic.
-func (c *Client) fetchDetailedChannelHistory(channelID string) (*slack.GetConversationHistoryResponse, error) {
-	const maxRetries = 3
-	var history *slack.GetConversationHistoryResponse
-
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		params := &slack.GetConversationHistoryParameters{
-			ChannelID: channelID,
-			Limit:     50, // Reasonable limit to find warnings and user activity
-		}
-
-		var err error
-		history, err = c.api.GetConversationHistory(params)
-		if err != nil {
-			if c.handleDetailedHistoryError(err, attempt, maxRetries, channelID) {
-				continue
-			}
-			return nil, err
-		}
-		// Success - break out of retry loop
-		break
-	}
-	return history, nil
-}
-
-// handleDetailedHistoryError handles errors during detailed history fetch and returns true if should retry.
-func (c *Client) handleDetailedHistoryError(err error, attempt, maxRetries int, channelID string) bool {
-	errStr := err.Error()
-
-	// Handle rate limiting with retry
-	if strings.Contains(errStr, "rate_limited") || strings.Contains(errStr, "rate limit") {
-		logger.WithFields(logger.LogFields{
-			"attempt":     attempt,
-			"max_tries":   maxRetries,
-			"channel":     channelID,
-			"slack_error": errStr,
-		}).Warn("Rate limited on detailed history, will retry after Slack-specified delay")
-
-		fmt.Printf("   🔄 Detailed history retry %d/%d for channel %s (Slack error: %s)\n", attempt, maxRetries, channelID, errStr)
-
-		if attempt < maxRetries {
-			c.handleRateLimitWait(errStr)
-			return true
-		}
-		// Last attempt failed
-		fmt.Printf("   ❌ All %d detailed retry attempts failed\n", maxRetries)
-	}
-	return false
-}
-
-// handleRateLimitWait handles waiting for rate limit recovery.
-func (c *Client) handleRateLimitWait(errStr string) {
-	waitDuration := parseSlackRetryAfter(errStr)
-	if waitDuration > 0 {
-		fmt.Printf("   ⏳ Slack says wait %s (includes 3s buffer) before detailed retry...\n", waitDuration)
-		time.Sleep(waitDuration)
-	} else {
-		fmt.Printf("   ⏳ Using fallback backoff before detailed retry...\n")
+// fetchDetailedChannelHistory fetches enough history to find both the bot's
+// warnings and the latest user activity.
+func (c *Client) fetchDetailedChannelHistory(ctx context.Context, channelID string) (*slack.GetConversationHistoryResponse, error) {
+	params := &slack.GetConversationHistoryParameters{
+		ChannelID: channelID,
+		Limit:     50, // Reasonable limit to find warnings and user activity
 	}
+	return c.api.GetConversationHistory(ctx, params)
 }
 
 // analyzeChannelMessages analyzes messages for user activity and bot warnings.
@@ -1686,37 +1743,36 @@ func (c *Client) analyzeChannelMessages(messages []slack.Message, botUserID stri
 	return mostRecentActivity, hasWarningMessage, mostRecentWarning
 }
 
-func (c *Client) autoJoinPublicChannels(channels []slack.Channel) (int, error) {
+func (c *Client) autoJoinPublicChannels(ctx context.Context, channels []slack.Channel) (int, error) {
 	joinedCount := 0
 	var fatalErrors []string
 	var skippedCount = 0
 	var alreadyMemberCount = 0
+	var fatalErr error
 
-	for _, ch := range channels {
-		if ch.IsPrivate {
-			logger.WithField("channel", ch.Name).Debug("Skipping private channel for auto-join")
-			skippedCount++
-			continue
-		}
-
-		// Skip channels we're already a member of
-		if ch.IsMember {
-			logger.WithField("channel", ch.Name).Debug("Already a member of channel")
-			alreadyMemberCount++
-			continue
-		}
-
-		result := c.joinChannel(ch)
+	results := make([]joinResult, len(channels))
+	runOrdered(c.Concurrency(), len(channels), func(i int) {
+		results[i] = c.joinChannelIfNeeded(ctx, channels[i])
+	}, func(i int) bool {
+		ch, result := channels[i], results[i]
 		switch result.status {
 		case joinSuccess:
 			joinedCount++
+		case joinAlreadyMember:
+			logger.WithField("channel", ch.Name).Debug("Already a member of channel")
+			alreadyMemberCount++
 		case joinSkipped:
 			skippedCount++
 		case joinFatal:
-			return joinedCount, result.err
+			fatalErr = result.err
+			return false
 		case joinError:
 			fatalErrors = append(fatalErrors, fmt.Sprintf("%s: %s", ch.Name, result.err.Error()))
 		}
+		return true
+	})
+	if fatalErr != nil {
+		return joinedCount, fatalErr
 	}
 
 	c.logAutoJoinSummary(joinedCount, skippedCount, alreadyMemberCount, len(channels))
@@ -1728,6 +1784,24 @@ func (c *Client) autoJoinPublicChannels(channels []slack.Channel) (int, error) {
 	return joinedCount, nil
 }
 
+// joinChannelIfNeeded joins ch unless the bot is already a member or it is
+// private; it is safe to call from several goroutines.
+func (c *Client) joinChannelIfNeeded(ctx context.Context, ch slack.Channel) joinResult {
+	if err := ctx.Err(); err != nil {
+		return joinResult{status: joinFatal, err: err}
+	}
+	if ch.IsPrivate && !ch.IsMember {
+		// Private channels can't be joined; they are only listed when the
+		// bot has already been invited.
+		logger.WithField("channel", ch.Name).Debug("Skipping private channel for auto-join")
+		return joinResult{status: joinSkipped}
+	}
+	if ch.IsMember {
+		return joinResult{status: joinAlreadyMember}
+	}
+	return c.joinChannel(ctx, ch)
+}
+
 type joinStatus int
 
 const (
@@ -1735,6 +1809,7 @@ const (
 	joinSkipped
 	joinFatal
 	joinError
+	joinAlreadyMember
 )
 
 type joinResult struct {
@@ -1743,35 +1818,34 @@ type joinResult struct {
 }
 
 // joinChannel attempts to join a single channel and returns the result.
-func (c *Client) joinChannel(ch slack.Channel) joinResult {
-	_, _, _, err := c.api.JoinConversation(ch.ID)
+func (c *Client) joinChannel(ctx context.Context, ch slack.Channel) joinResult {
+	_, _, _, err := c.api.JoinConversation(ctx, ch.ID)
+	c.audit(AuditEvent{Action: AuditActionJoin, ChannelID: ch.ID, Channel: ch.Name}, err)
 	if err == nil {
 		logger.WithField("channel", ch.Name).Debug("Successfully joined channel")
 		return joinResult{status: joinSuccess}
 	}
 
-	errStr := err.Error()
-
 	// Handle rate limiting - this is fatal
-	if strings.Contains(errStr, "rate_limited") {
+	if errors.Is(err, ErrRateLimited) {
 		return joinResult{status: joinFatal, err: fmt.Errorf("rate limited during auto-join: %w", err)}
 	}
 
 	// Already in channel is success
-	if strings.Contains(errStr, "already_in_channel") {
+	if hasErrorCode(err, "already_in_channel") {
 		logger.WithField("channel", ch.Name).Debug("Already in channel")
 		return joinResult{status: joinSuccess}
 	}
 
 	// Handle skippable errors
-	if c.isSkippableJoinError(errStr) {
-		c.logSkippableError(ch.Name, errStr)
+	if c.isSkippableJoinError(err) {
+		c.logSkippableError(ch.Name, err)
 		return joinResult{status: joinSkipped}
 	}
 
 	// Handle fatal errors
-	if c.isFatalJoinError(errStr) {
-		return joinResult{status: joinFatal, err: c.createFatalJoinError(errStr, err)}
+	if c.isFatalJoinError(err) {
+		return joinResult{status: joinFatal, err: c.createFatalJoinError(err)}
 	}
 
 	// Other errors
@@ -1779,33 +1853,33 @@ func (c *Client) joinChannel(ch slack.Channel) joinResult {
 }
 
 // isSkippableJoinError checks if a join error can be safely skipped.
-func (c *Client) isSkippableJoinError(errStr string) bool {
-	return strings.Contains(errStr, "is_archived") || strings.Contains(errStr, "invite_only")
+func (c *Client) isSkippableJoinError(err error) bool {
+	return errors.Is(err, ErrChannelArchived) || hasErrorCode(err, "invite_only")
 }
 
 // isFatalJoinError checks if a join error is fatal for the bot's functionality.
-func (c *Client) isFatalJoinError(errStr string) bool {
-	return strings.Contains(errStr, "missing_scope") || strings.Contains(errStr, "invalid_auth")
+func (c *Client) isFatalJoinError(err error) bool {
+	return errors.Is(err, ErrMissingScope) || errors.Is(err, ErrInvalidAuth)
 }
 
 // logSkippableError logs skippable join errors.
-func (c *Client) logSkippableError(channelName, errStr string) {
-	if strings.Contains(errStr, "is_archived") {
+func (c *Client) logSkippableError(channelName string, err error) {
+	if errors.Is(err, ErrChannelArchived) {
 		logger.WithField("channel", channelName).Debug("Channel is archived, skipping")
-	} else if strings.Contains(errStr, "invite_only") {
+	} else if hasErrorCode(err, "invite_only") {
 		logger.WithField("channel", channelName).Debug("Channel is invite-only, skipping")
 	}
 }
 
 // createFatalJoinError creates appropriate fatal error messages.
-func (c *Client) createFatalJoinError(errStr string, originalErr error) error {
-	if strings.Contains(errStr, "missing_scope") {
-		return fmt.Errorf("missing required OAuth scope to join channels. Your bot needs the 'channels:join' OAuth scope.\nPlease add this scope in your Slack app settings at https://api.slack.com/apps")
+func (c *Client) createFatalJoinError(err error) error {
+	if errors.Is(err, ErrMissingScope) {
+		return describe(withScope(err, "channels:join"), "missing required OAuth scope to join channels. Your bot needs the 'channels:join' OAuth scope.\nPlease add this scope in your Slack app settings at https://api.slack.com/apps")
 	}
-	if strings.Contains(errStr, "invalid_auth") {
-		return fmt.Errorf("invalid authentication token: %w", originalErr)
+	if errors.Is(err, ErrInvalidAuth) {
+		return fmt.Errorf("invalid authentication token: %w", err)
 	}
-	return originalErr
+	return err
 }
 
 // logAutoJoinSummary logs the summary of auto-join operation.
@@ -1818,24 +1892,17 @@ func (c *Client) logAutoJoinSummary(joined, skipped, alreadyMember, total int) {
 	}).Debug("Auto-join summary")
 }
 
+// shouldSkipChannel reports whether the channel's name is protected from
+// archival; see SetProtectedNames.
 func (c *Client) shouldSkipChannel(channelName string) bool {
-	// Skip channels that should never be archived
-	excludePatterns := []string{
-		"general",
-		"random",
-		"announcements",
-		"admin",
-		"hr",
-		"security",
-	}
-
-	lowerName := strings.ToLower(channelName)
-	for _, pattern := range excludePatterns {
-		if strings.Contains(lowerName, pattern) {
-			return true
-		}
+	pattern, ok := c.ProtectedNames().Match(channelName)
+	if ok {
+		logger.WithFields(logger.LogFields{
+			"channel": channelName,
+			"pattern": pattern,
+		}).Debug("Skipping channel with protected name")
 	}
-	return false
+	return ok
 }
 
 func (c *Client) seemsActiveFromMetadata(ch slack.Channel, warnCutoff time.Time) bool {
@@ -1884,9 +1951,9 @@ func (c *Client) seemsActiveFromMetadata(ch slack.Channel, warnCutoff time.Time)
 	return false
 }
 
-func (c *Client) getBotUserID() string {
+func (c *Client) getBotUserID(ctx context.Context) string {
 	// Cache the bot user ID to avoid repeated API calls
-	if auth, err := c.api.AuthTest(); err == nil {
+	if auth, err := c.api.AuthTest(ctx); err == nil {
 		return auth.UserID
 	}
 	return ""
@@ -1907,9 +1974,9 @@ func parseSlackTimestamp(ts string) (time.Time, error) {
 	return time.Unix(seconds, 0), nil
 }
 
-func (c *Client) WarnInactiveChannel(channel Channel, warnSeconds, archiveSeconds int, metaChannelID string) error {
+func (c *Client) WarnInactiveChannel(ctx context.Context, channel Channel, warnSeconds, archiveSeconds int, metaChannelID string) error {
 	// First, try to join the channel if it's public
-	if err := c.ensureBotInChannel(channel); err != nil {
+	if err := c.ensureBotInChannel(ctx, channel); err != nil {
 		logger.WithFields(logger.LogFields{
 			"channel": channel.Name,
 			"error":   err.Error(),
@@ -1924,13 +1991,15 @@ func (c *Client) WarnInactiveChannel(channel Channel, warnSeconds, archiveSecond
 		"archive_seconds": archiveSeconds,
 	}).Debug("Posting inactive channel warning")
 
-	return c.postMessageToChannelID(channel.ID, message)
+	err := c.postMessageToChannelID(ctx, channel.ID, message)
+	c.audit(channelAuditEvent(AuditActionWarn, channel, warnSeconds, archiveSeconds), err)
+	return err
 }
 
 // WarnInactiveChannelWarnOnly sends a warning in warn-only mode (uses archive-days for timeline).
-func (c *Client) WarnInactiveChannelWarnOnly(channel Channel, warnSeconds, archiveSeconds int, metaChannelID string) error {
+func (c *Client) WarnInactiveChannelWarnOnly(ctx context.Context, channel Channel, warnSeconds, archiveSeconds int, metaChannelID string) error {
 	// First, try to join the channel if it's public
-	if err := c.ensureBotInChannel(channel); err != nil {
+	if err := c.ensureBotInChannel(ctx, channel); err != nil {
 		logger.WithFields(logger.LogFields{
 			"channel": channel.Name,
 			"error":   err.Error(),
@@ -1946,85 +2015,99 @@ func (c *Client) WarnInactiveChannelWarnOnly(channel Channel, warnSeconds, archi
 		"archive_seconds": archiveSeconds,
 	}).Debug("Posting inactive channel warning (warn-only mode)")
 
-	return c.postMessageToChannelID(channel.ID, message)
+	err := c.postMessageToChannelID(ctx, channel.ID, message)
+	c.audit(channelAuditEvent(AuditActionWarn, channel, warnSeconds, archiveSeconds), err)
+	return err
 }
 
-func (c *Client) ensureBotInChannel(channel Channel) error {
+func (c *Client) ensureBotInChannel(ctx context.Context, channel Channel) error {
 	logger.WithField("channel", channel.Name).Debug("Ensuring bot is in channel")
 
-	_, _, _, err := c.api.JoinConversation(channel.ID)
+	// conversations.join doesn't support private channels; the bot is
+	// already a member of every private channel it can see.
+	if channel.IsPrivate {
+		return nil
+	}
+
+	_, _, _, err := c.api.JoinConversation(ctx, channel.ID)
+	c.audit(AuditEvent{Action: AuditActionJoin, ChannelID: channel.ID, Channel: channel.Name}, err)
 	if err == nil {
 		logger.WithField("channel", channel.Name).Info("Successfully joined channel")
 		return nil
 	}
 
-	errStr := err.Error()
 	logger.WithFields(logger.LogFields{
 		"channel":   channel.Name,
-		"error":     errStr,
+		"error":     err.Error(),
 		"operation": "join_conversation",
 	}).Debug("Join conversation result")
 
-	return c.handleJoinChannelError(channel, errStr, err)
+	return c.handleJoinChannelError(channel, err)
 }
 
 // handleJoinChannelError handles various join channel errors.
-func (c *Client) handleJoinChannelError(channel Channel, errStr string, originalErr error) error {
+func (c *Client) handleJoinChannelError(channel Channel, err error) error {
 	// Handle rate limiting
-	if strings.Contains(errStr, "rate_limited") {
-		return fmt.Errorf("rate limited by Slack API. Will retry with exponential backoff on next request")
+	if errors.Is(err, ErrRateLimited) {
+		return describe(err, "rate limited by Slack API after repeated retries. Please wait before running again")
 	}
 
 	// Handle expected success cases
-	if strings.Contains(errStr, "already_in_channel") {
+	if hasErrorCode(err, "already_in_channel") {
 		logger.WithField("channel", channel.Name).Debug("Bot already in channel")
 		return nil
 	}
 
 	// Handle fatal permission errors
-	if strings.Contains(errStr, "missing_scope") {
-		return fmt.Errorf("missing required permission to join channels. Your bot needs the 'channels:join' OAuth scope.\nPlease add this scope in your Slack app settings at https://api.slack.com/apps")
+	if errors.Is(err, ErrMissingScope) {
+		return describe(withScope(err, "channels:join"), "missing required permission to join channels. Your bot needs the 'channels:join' OAuth scope.\nPlease add this scope in your Slack app settings at https://api.slack.com/apps")
 	}
 
 	// Handle specific channel errors
-	err := c.handleSpecificChannelErrors(channel, errStr)
-	if err != nil {
-		return err
+	if channelErr := c.handleSpecificChannelErrors(channel, err); channelErr != nil {
+		return channelErr
 	}
 
 	// For other errors, log but don't fail completely
 	logger.WithFields(logger.LogFields{
 		"channel": channel.Name,
-		"error":   errStr,
+		"error":   err.Error(),
 	}).Warn("Unexpected error joining channel")
-	return fmt.Errorf("failed to join channel %s: %w", channel.Name, originalErr)
+	return fmt.Errorf("failed to join channel %s: %w", channel.Name, err)
 }
 
 // handleSpecificChannelErrors handles specific channel-related errors.
-func (c *Client) handleSpecificChannelErrors(channel Channel, errStr string) error {
-	if strings.Contains(errStr, "channel_not_found") {
-		return fmt.Errorf("channel '%s' not found", channel.Name)
+func (c *Client) handleSpecificChannelErrors(channel Channel, err error) error {
+	if errors.Is(err, ErrChannelNotFound) {
+		return describe(err, "channel '%s' not found", channel.Name)
 	}
 
-	if strings.Contains(errStr, "is_archived") {
-		return fmt.Errorf("channel '%s' is archived", channel.Name)
+	if errors.Is(err, ErrChannelArchived) {
+		return describe(err, "channel '%s' is archived", channel.Name)
 	}
 
-	if strings.Contains(errStr, "invite_only") {
+	if hasErrorCode(err, "invite_only") {
 		logger.WithField("channel", channel.Name).Debug("Channel is private/invite-only, cannot join")
-		return fmt.Errorf("channel '%s' is private or invite-only", channel.Name)
+		return describe(err, "channel '%s' is private or invite-only", channel.Name)
 	}
 
 	return nil
 }
 
-func (c *Client) postMessageToChannelID(channelID, message string) error {
+func (c *Client) postMessageToChannelID(ctx context.Context, channelID, message string) error {
+	_, err := c.postMessageWithOptions(ctx, channelID, message)
+	return err
+}
+
+// postMessageWithOptions posts message to a channel by ID with extra
+// options, e.g. to reply in a thread, and returns the message's timestamp.
+func (c *Client) postMessageWithOptions(ctx context.Context, channelID, message string, options ...slack.MsgOption) (string, error) {
 	logger.WithFields(logger.LogFields{
 		"channel_id":     channelID,
 		"message_length": len(message),
 	}).Debug("Posting message to channel by ID")
 
-	_, _, err := c.api.PostMessage(channelID, slack.MsgOptionText(message, false))
+	_, timestamp, err := c.api.PostMessage(ctx, channelID, append([]slack.MsgOption{slack.MsgOptionText(message, false)}, options...)...)
 	if err != nil {
 		errStr := err.Error()
 		logger.WithFields(logger.LogFields{
@@ -2034,34 +2117,39 @@ func (c *Client) postMessageToChannelID(channelID, message string) error {
 		}).Error("Failed to post message to Slack")
 
 		// Handle rate limiting
-		if strings.Contains(errStr, "rate_limited") {
-			return fmt.Errorf("rate limited by Slack API. Will retry with exponential backoff on next request")
+		if errors.Is(err, ErrRateLimited) {
+			return "", describe(err, "rate limited by Slack API after repeated retries. Please wait before running again")
 		}
 
-		if strings.Contains(errStr, "missing_scope") {
+		if errors.Is(err, ErrMissingScope) {
 			logger.Error("Missing chat:write OAuth scope")
-			return fmt.Errorf("missing required permission to post messages. Your bot needs the 'chat:write' OAuth scope.\nPlease add this scope in your Slack app settings at https://api.slack.com/apps")
+			return "", describe(withScope(err, "chat:write"), "missing required permission to post messages. Your bot needs the 'chat:write' OAuth scope.\nPlease add this scope in your Slack app settings at https://api.slack.com/apps")
 		}
-		if strings.Contains(errStr, "channel_not_found") {
+		if errors.Is(err, ErrChannelNotFound) {
 			logger.WithField("channel_id", channelID).Error("Channel not found")
-			return fmt.Errorf("channel with ID '%s' not found. Make sure the bot is added to the channel", channelID)
+			return "", describe(err, "channel with ID '%s' not found. Make sure the bot is added to the channel", channelID)
 		}
-		if strings.Contains(errStr, "not_in_channel") {
+		if errors.Is(err, ErrNotInChannel) {
 			logger.WithField("channel_id", channelID).Error("Bot not in channel")
-			return fmt.Errorf("bot is not a member of channel with ID '%s'. Please add the bot to the channel", channelID)
+			return "", describe(err, "bot is not a member of channel with ID '%s'. Please add the bot to the channel", channelID)
 		}
-		return fmt.Errorf("failed to post message to channel %s: %w", channelID, err)
+		return "", fmt.Errorf("failed to post message to channel %s: %w", channelID, err)
 	}
 
 	logger.WithField("channel_id", channelID).Debug("Message posted successfully")
-	return nil
+	return timestamp, nil
 }
 
 func (c *Client) FormatInactiveChannelWarning(channel Channel, warnSeconds, archiveSeconds int, discussionChannelID string) string {
+	warnSeconds, archiveSeconds = channel.Thresholds(warnSeconds, archiveSeconds)
 	var builder strings.Builder
 
 	builder.WriteString("🚨 Inactive Channel Warning 🚨\n\n")
 
+	if len(channel.KeepVoters) > 0 {
+		builder.WriteString("Members voted to keep this channel, so the grace period starts over.\n\n")
+	}
+
 	warnText := formatDurationSeconds(warnSeconds)
 
 	fmt.Fprintf(&builder, "This channel has been inactive for more than %s.\n\n", warnText)
@@ -2072,7 +2160,11 @@ func (c *Client) FormatInactiveChannelWarning(channel Channel, warnSeconds, arch
 
 	builder.WriteString("To keep this channel active:\n\n")
 	builder.WriteString("• Post a message in this channel or\n")
-	fmt.Fprintf(&builder, "• Discuss in %s if this channel warrants admin intervention\n\n", c.discussionChannelLink(discussionChannelID))
+	if keepReaction := c.KeepReaction(); keepReaction != "" {
+		fmt.Fprintf(&builder, "• React to this message with :%s: to vote to keep it or\n", keepReaction)
+	}
+	fmt.Fprintf(&builder, "• Add %s to the channel topic or purpose to opt out of archival or\n", KeepMarker)
+	fmt.Fprintf(&builder, "• Discuss in %s if this channel warrants admin intervention, such as snoozing archival for a while\n\n", c.discussionChannelLink(discussionChannelID))
 
 	return builder.String()
 }
@@ -2084,6 +2176,7 @@ func (c *Client) FormatInactiveChannelWarningWarnOnly(channel Channel, warnSecon
 }
 
 func (c *Client) FormatChannelArchivalMessage(channel Channel, warnSeconds, archiveSeconds int, discussionChannelID string) string {
+	warnSeconds, archiveSeconds = channel.Thresholds(warnSeconds, archiveSeconds)
 	var builder strings.Builder
 
 	builder.WriteString("📋 Channel Archival Notice 📋\n\n")
@@ -2114,17 +2207,17 @@ func (c *Client) discussionChannelLink(discussionChannelID string) string {
 	return "#" + name
 }
 
-func (c *Client) ArchiveChannel(channel Channel) error {
+func (c *Client) ArchiveChannel(ctx context.Context, channel Channel) error {
 	// Legacy method for backward compatibility - uses default thresholds
-	return c.ArchiveChannelWithThresholds(channel, 300, 60) // 5 minutes warn, 1 minute archive
+	return c.ArchiveChannelWithThresholds(ctx, channel, 300, 60) // 5 minutes warn, 1 minute archive
 }
 
-func (c *Client) ArchiveChannelWithThresholds(channel Channel, warnSeconds, archiveSeconds int) error {
+func (c *Client) ArchiveChannelWithThresholds(ctx context.Context, channel Channel, warnSeconds, archiveSeconds int) error {
 	logger.WithField("channel", channel.Name).Debug("Archiving inactive channel")
 
 	// Look up the discussion channel ID once to enrich the archival message.
 	discussionName := c.DiscussionChannel()
-	discussionChannelID, err := c.ResolveChannelNameToID(discussionName)
+	discussionChannelID, err := c.ResolveChannelNameToID(ctx, discussionName)
 	if err != nil {
 		logger.WithFields(logger.LogFields{
 			"discussion_channel": discussionName,
@@ -2134,7 +2227,7 @@ func (c *Client) ArchiveChannelWithThresholds(channel Channel, warnSeconds, arch
 	}
 
 	// First, ensure the bot is in the channel to post the archival message
-	if joinErr := c.ensureBotInChannel(channel); joinErr != nil {
+	if joinErr := c.ensureBotInChannel(ctx, channel); joinErr != nil {
 		logger.WithFields(logger.LogFields{
 			"channel": channel.Name,
 			"error":   joinErr.Error(),
@@ -2142,9 +2235,18 @@ func (c *Client) ArchiveChannelWithThresholds(channel Channel, warnSeconds, arch
 		// Don't fail here - we can still archive even if we can't post the message
 	}
 
+	// Keep an offline record of the channel before it is archived
+	if c.exportDir != "" {
+		if _, exportErr := c.ExportChannelHistory(ctx, channel, c.exportDir); exportErr != nil {
+			return fmt.Errorf("failed to export history before archiving: %w", exportErr)
+		}
+	}
+
 	// Post archival message explaining why the channel is being archived
 	archivalMessage := c.FormatChannelArchivalMessage(channel, warnSeconds, archiveSeconds, discussionChannelID)
-	if postErr := c.postMessageToChannelID(channel.ID, archivalMessage); postErr != nil {
+	postErr := c.postMessageToChannelID(ctx, channel.ID, archivalMessage)
+	c.audit(channelAuditEvent(AuditActionArchivalMessage, channel, warnSeconds, archiveSeconds), postErr)
+	if postErr != nil {
 		logger.WithFields(logger.LogFields{
 			"channel": channel.Name,
 			"error":   postErr.Error(),
@@ -2156,7 +2258,8 @@ func (c *Client) ArchiveChannelWithThresholds(channel Channel, warnSeconds, arch
 
 	// Rate limit before archival API call
 
-	err = c.api.ArchiveConversation(channel.ID)
+	err = c.api.ArchiveConversation(ctx, channel.ID)
+	c.audit(channelAuditEvent(AuditActionArchive, channel, warnSeconds, archiveSeconds), err)
 	if err != nil {
 		errStr := err.Error()
 		logger.WithFields(logger.LogFields{
@@ -2166,19 +2269,23 @@ func (c *Client) ArchiveChannelWithThresholds(channel Channel, warnSeconds, arch
 		}).Error("Failed to archive channel")
 
 		// Handle rate limiting
-		if strings.Contains(errStr, "rate_limited") {
-			return fmt.Errorf("rate limited by Slack API. Will retry with exponential backoff on next request")
+		if errors.Is(err, ErrRateLimited) {
+			return describe(err, "rate limited by Slack API after repeated retries. Please wait before running again")
 		}
 
-		if strings.Contains(errStr, "missing_scope") {
-			return fmt.Errorf("missing required permission to archive channels. Your bot needs the 'channels:manage' OAuth scope.\nPlease add this scope in your Slack app settings at https://api.slack.com/apps")
+		if errors.Is(err, ErrMissingScope) {
+			scope := "channels:manage"
+			if channel.IsPrivate {
+				scope = "groups:write"
+			}
+			return describe(withScope(err, scope), "missing required permission to archive channels. Your bot needs the '%s' OAuth scope.\nPlease add this scope in your Slack app settings at https://api.slack.com/apps", scope)
 		}
 
-		if strings.Contains(errStr, "channel_not_found") {
-			return fmt.Errorf("channel '%s' not found", channel.Name)
+		if errors.Is(err, ErrChannelNotFound) {
+			return describe(err, "channel '%s' not found", channel.Name)
 		}
 
-		if strings.Contains(errStr, "already_archived") {
+		if errors.Is(err, ErrChannelArchived) {
 			logger.WithField("channel", channel.Name).Info("Channel was already archived")
 			return nil
 		}
@@ -2190,15 +2297,12 @@ func (c *Client) ArchiveChannelWithThresholds(channel Channel, warnSeconds, arch
 	return nil
 }
 
-func (c *Client) GetChannelsWithMetadata() ([]Channel, error) {
+func (c *Client) GetChannelsWithMetadata(ctx context.Context) ([]Channel, error) {
 	logger.Debug("Fetching channels with metadata from Slack API")
 
 	// Rate limit before API call
 
-	channels, _, err := c.api.GetConversations(&slack.GetConversationsParameters{
-		Types: []string{"public_channel"},
-		Limit: 1000,
-	})
+	channels, err := c.getAllConversations(ctx, false)
 	if err != nil {
 		errStr := err.Error()
 		logger.WithFields(logger.LogFields{
@@ -2207,17 +2311,17 @@ func (c *Client) GetChannelsWithMetadata() ([]Channel, error) {
 		}).Error("Slack API error")
 
 		// Handle rate limiting
-		if strings.Contains(errStr, "rate_limited") {
-			return nil, fmt.Errorf("rate limited by Slack API. Please wait before retrying")
+		if errors.Is(err, ErrRateLimited) {
+			return nil, describe(err, "rate limited by Slack API. Please wait before retrying")
 		}
 
-		if strings.Contains(errStr, "missing_scope") {
+		if errors.Is(err, ErrMissingScope) {
 			logger.Error("Missing OAuth scopes for channel access")
-			return nil, fmt.Errorf("missing required permissions. Your bot needs these OAuth scopes:\\n  - channels:read (to list public channels)\\n\\nPlease add these scopes in your Slack app settings at https://api.slack.com/apps")
+			return nil, describe(withScope(err, "channels:read"), "missing required permissions. Your bot needs these OAuth scopes:\\n  - channels:read (to list public channels)\\n\\nPlease add these scopes in your Slack app settings at https://api.slack.com/apps")
 		}
-		if strings.Contains(errStr, "invalid_auth") {
+		if errors.Is(err, ErrInvalidAuth) {
 			logger.Error("Invalid Slack authentication token")
-			return nil, fmt.Errorf("invalid token. Please check your SLACK_TOKEN")
+			return nil, describe(err, "invalid token. Please check your SLACK_TOKEN")
 		}
 		return nil, fmt.Errorf("failed to get conversations: %w", err)
 	}
@@ -2270,6 +2374,7 @@ func (c *Client) GetChannelsWithMetadata() ([]Channel, error) {
 			Creator:     ch.Creator,
 			MemberCount: ch.NumMembers,
 			IsArchived:  ch.IsArchived,
+			IsPrivate:   ch.IsPrivate,
 		})
 	}
 
@@ -2278,8 +2383,8 @@ func (c *Client) GetChannelsWithMetadata() ([]Channel, error) {
 }
 
 // GetChannelActivity returns the last activity time, warning status, and warning time for a channel.
-func (c *Client) GetChannelActivity(channelID string) (lastActivity time.Time, hasWarning bool, warningTime time.Time, err error) {
-	return c.getChannelActivity(channelID)
+func (c *Client) GetChannelActivity(ctx context.Context, channelID string) (lastActivity time.Time, hasWarning bool, warningTime time.Time, err error) {
+	return c.getChannelActivity(ctx, channelID)
 }
 
 // MessageInfo contains details about a message.
@@ -2292,51 +2397,55 @@ type MessageInfo struct {
 }
 
 // GetChannelActivityWithMessage returns activity info plus details about the most recent message.
-func (c *Client) GetChannelActivityWithMessage(channelID string) (lastActivity time.Time, hasWarning bool, warningTime time.Time, lastMessage *MessageInfo, err error) {
-	history, err := c.getChannelHistoryWithRetry(channelID)
+func (c *Client) GetChannelActivityWithMessage(ctx context.Context, channelID string) (lastActivity time.Time, hasWarning bool, warningTime time.Time, lastMessage *MessageInfo, err error) {
+	result := c.getChannelActivityResult(ctx, channelID)
+	return result.lastActivity, result.hasWarning, result.warningTime, result.lastMessage, result.err
+}
+
+// getChannelActivityResult summarizes a channel's recent history: its last
+// real message or thread reply (see SetSkipThreads), any standing warning
+// and any snooze.
+func (c *Client) getChannelActivityResult(ctx context.Context, channelID string) channelActivityResult {
+	history, err := c.getChannelHistory(ctx, channelID)
 	if err != nil {
-		return time.Time{}, false, time.Time{}, nil, err
+		return channelActivityResult{err: err}
 	}
 
 	if len(history.Messages) == 0 {
-		return time.Time{}, false, time.Time{}, nil, nil
+		return channelActivityResult{}
 	}
 
-	botUserID := c.getBotUserID()
+	botUserID := c.getBotUserID(ctx)
+	result := channelActivityResult{snoozedUntil: findSnooze(history.Messages, botUserID)}
 	lastRealMsg, lastRealMsgTime := c.findMostRecentRealMessage(history.Messages, botUserID)
 	if lastRealMsg == nil {
-		return time.Time{}, false, time.Time{}, nil, nil
+		return result
 	}
 
-	msgInfo := c.createMessageInfo(lastRealMsg, lastRealMsgTime, botUserID)
-	hasWarningMessage, mostRecentWarning := c.checkForWarningMessage(lastRealMsg, lastRealMsgTime, botUserID)
-
-	return lastRealMsgTime, hasWarningMessage, mostRecentWarning, msgInfo, nil
+	result.lastActivity = lastRealMsgTime
+	result.lastMessage = c.createMessageInfo(lastRealMsg, lastRealMsgTime, botUserID)
+	result.hasWarning, result.warningTime = c.checkForWarningMessage(lastRealMsg, lastRealMsgTime, botUserID)
+	if result.hasWarning {
+		result.keepVoters = c.keepVoters(lastRealMsg, botUserID)
+	}
+	if !c.skipThreads {
+		c.applyThreadActivity(ctx, &result, channelID, history.Messages, botUserID)
+	}
+	return result
 }
 
-// getChannelHistoryWithRetry handles the API call with retry logic.
-func (c *Client) getChannelHistoryWithRetry(channelID string) (*slack.GetConversationHistoryResponse, error) {
-	const maxRetries = 3
+// getChannelHistory fetches the most recent messages in a channel.
+func (c *Client) getChannelHistory(ctx context.Context, channelID string) (*slack.GetConversationHistoryResponse, error) {
 	params := &slack.GetConversationHistoryParameters{
 		ChannelID: channelID,
 		Limit:     10,
 	}
 
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		history, err := c.api.GetConversationHistory(params)
-		if err != nil {
-			if c.isRateLimitError(err) && attempt < maxRetries {
-				c.handleRateLimit(err, attempt, maxRetries)
-				continue
-			}
-			if c.isRateLimitError(err) {
-				return nil, fmt.Errorf("failed to get channel history after %d attempts, final Slack error: %s", maxRetries, err.Error())
-			}
-			return nil, fmt.Errorf("failed to get channel history: %w", err)
-		}
-		return history, nil
+	history, err := c.api.GetConversationHistory(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channel history: %w", err)
 	}
-	return nil, fmt.Errorf("unexpected end of retry loop")
+	return history, nil
 }
 
 // createMessageInfo creates a MessageInfo struct from a Slack message.
@@ -2358,113 +2467,27 @@ func (c *Client) checkForWarningMessage(msg *slack.Message, msgTime time.Time, b
 	return false, time.Time{}
 }
 
-// isRateLimitError checks if an error is a rate limiting error.
-func (c *Client) isRateLimitError(err error) bool {
-	errStr := err.Error()
-	return strings.Contains(errStr, "rate_limited") || strings.Contains(errStr, "rate limit")
-}
-
-// handleRateLimit handles rate limiting with user feedback.
-func (c *Client) handleRateLimit(err error, attempt, maxRetries int) {
-	waitDuration := parseSlackRetryAfter(err.Error())
-	if waitDuration > 0 {
-		fmt.Printf("⏳ Waiting %s due to Slack API rate limiting...\n", waitDuration.String())
-		showProgressBar(waitDuration)
-	}
-}
-
-// parseSlackRetryAfter parses Slack's "retry after" directive from error messages.
-// Example: "slack rate limit exceeded, retry after 1m0s" -> 1 minute duration.
-func parseSlackRetryAfter(errorStr string) time.Duration {
-	// Look for "retry after" followed by a duration
-	retryAfterIndex := strings.Index(errorStr, "retry after ")
-	if retryAfterIndex == -1 {
-		return 0
-	}
-
-	// Extract the duration part
-	durationStart := retryAfterIndex + len("retry after ")
-	remaining := errorStr[durationStart:]
-
-	// Find the end of the duration (usually end of string or a space)
-	var durationStr string
-	spaceIndex := strings.Index(remaining, " ")
-	if spaceIndex == -1 {
-		durationStr = remaining
-	} else {
-		durationStr = remaining[:spaceIndex]
-	}
-
-	// Parse the duration using Go's time.ParseDuration
-	duration, err := time.ParseDuration(durationStr)
-	if err != nil {
-		logger.WithFields(logger.LogFields{
-			"error_string":  errorStr,
-			"duration_part": durationStr,
-			"parse_error":   err.Error(),
-		}).Warn("Failed to parse Slack retry-after duration")
-		return 0
-	}
-
-	// Add 1 second buffer to avoid hitting the limit again immediately
-	bufferedDuration := duration + (1 * time.Second)
-
-	return bufferedDuration
-}
-
-// showProgressBar displays a text-based progress bar for waiting periods.
-// Shows - for remaining time and * for elapsed time.
-func showProgressBar(duration time.Duration) {
-	totalSeconds := int(duration.Seconds())
-	if totalSeconds <= 0 {
-		return
-	}
-
-	// Limit to reasonable max for display (2 minutes = 120 chars)
-	maxDisplay := 120
-	if totalSeconds > maxDisplay {
-		// Scale down for very long waits
-		scaleFactor := float64(totalSeconds) / float64(maxDisplay)
-		fmt.Printf("   Progress (scaled 1:%d): ", int(scaleFactor))
-		totalSeconds = maxDisplay
-	} else {
-		fmt.Printf("   Progress: ")
-	}
-
-	// Print initial bar (all dashes)
-	for i := 0; i < totalSeconds; i++ {
-		fmt.Printf("-")
-	}
-	fmt.Printf(" [0/%ds]\r", int(duration.Seconds()))
-
-	// Update progress each second
-	for elapsed := 0; elapsed < totalSeconds; elapsed++ {
-		time.Sleep(time.Second)
-
-		// Move cursor to start of progress bar
-		fmt.Printf("   Progress: ")
-		if totalSeconds > maxDisplay {
-			fmt.Printf("(scaled 1:%d): ", int(float64(int(duration.Seconds()))/float64(maxDisplay)))
-		}
-
-		// Print progress: * for completed, - for remaining
-		for i := 0; i < totalSeconds; i++ {
-			if i <= elapsed {
-				fmt.Printf("*")
-			} else {
-				fmt.Printf("-")
-			}
-		}
-		fmt.Printf(" [%d/%ds]\r", elapsed+1, int(duration.Seconds()))
+// sleepContext pauses for d, returning early with the context's error if
+// ctx is cancelled first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
 	}
-
-	// Final newline
-	fmt.Printf("\n   ✅ Wait complete!\n")
 }
 
 // isRealMessage filters out system messages like joins, leaves, topic changes, etc.
 // Returns true for actual user-generated content.
 func isRealMessage(msg slack.Message, botUserID string) bool {
+	// Snooze messages postpone archival without counting as activity
+	if isSnoozeMessage(msg, botUserID) {
+		return false
+	}
+
 	// Filter out messages with system subtypes
 	if msg.SubType != "" {
 		systemSubtypes := []string{
@@ -2530,9 +2553,8 @@ func isRealMessage(msg slack.Message, botUserID string) bool {
 }
 
 // getUserMap fetches all users and builds a map from user ID to display name.
-func (c *Client) getUserMap() (map[string]string, error) {
-
-	users, err := c.api.GetUsers()
+func (c *Client) getUserMap(ctx context.Context) (map[string]string, error) {
+	users, err := c.getAllUsers(ctx)
 	if err != nil {
 		errStr := err.Error()
 		logger.WithFields(logger.LogFields{
@@ -2542,13 +2564,13 @@ func (c *Client) getUserMap() (map[string]string, error) {
 		}).Error("Failed to get users list")
 
 		// Handle rate limiting
-		if strings.Contains(errStr, "rate_limited") || strings.Contains(errStr, "rate limit") {
+		if errors.Is(err, ErrRateLimited) {
 			return nil, fmt.Errorf("rate limited getting users: %w", err)
 		}
 
 		// Handle missing scope with clearer message
-		if strings.Contains(errStr, "missing_scope") {
-			return nil, fmt.Errorf("missing required OAuth scope 'users:read' to get user list: %w", err)
+		if errors.Is(err, ErrMissingScope) {
+			return nil, describe(withScope(err, "users:read"), "missing required OAuth scope 'users:read' to get user list: %v", err)
 		}
 
 		return nil, fmt.Errorf("failed to get users: %w", err)
@@ -2575,34 +2597,15 @@ func (c *Client) getUserMap() (map[string]string, error) {
 }
 
 // GetUserMap is a public wrapper for getUserMap.
-func (c *Client) GetUserMap() (map[string]string, error) {
-	return c.getUserMap()
+func (c *Client) GetUserMap(ctx context.Context) (map[string]string, error) {
+	return c.getUserMap(ctx)
 }
 
-// getUsersForDefaultDetection fetches all workspace users with retry logic.
-func (c *Client) getUsersForDefaultDetection() ([]slack.User, error) {
-	const maxRetries = 3
-	var users []slack.User
-	var err error
-
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		users, err = c.api.GetUsers()
-		if err != nil {
-			if shouldRetryRateLimit(err, attempt, maxRetries) {
-				waitDuration := parseSlackRetryAfter(err.Error())
-				if waitDuration > 0 {
-					logger.WithFields(logger.LogFields{
-						"attempt":       attempt,
-						"max_tries":     maxRetries,
-						"wait_duration": waitDuration,
-					}).Debug("Rate limited while fetching users, waiting before retry")
-					showProgressBar(waitDuration)
-				}
-				continue
-			}
-			return nil, fmt.Errorf("failed to get users after %d attempts: %w", maxRetries, err)
-		}
-		break
+// getUsersForDefaultDetection fetches all workspace users.
+func (c *Client) getUsersForDefaultDetection(ctx context.Context) ([]slack.User, error) {
+	users, err := c.getAllUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
 	}
 	return users, nil
 }
@@ -2634,110 +2637,37 @@ func filterAndSortRealUsers(users []slack.User, sampleSize int) []string {
 	return result
 }
 
-// getConversationsForUserWithRetry fetches user conversations with retry logic.
-func (c *Client) getConversationsForUserWithRetry(userID, cursor string) ([]slack.Channel, string, error) {
-	const maxRetries = 3
-	var channelList []slack.Channel
-	var nextCursor string
-	var err error
-
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		params := &slack.GetConversationsForUserParameters{
-			UserID: userID,
-			Types:  []string{"public_channel"},
-			Cursor: cursor,
-		}
-		channelList, nextCursor, err = c.api.GetConversationsForUser(params)
-		if err != nil {
-			if shouldRetryRateLimit(err, attempt, maxRetries) {
-				waitDuration := parseSlackRetryAfter(err.Error())
-				if waitDuration > 0 {
-					logger.WithFields(logger.LogFields{
-						"user_id":       userID,
-						"attempt":       attempt,
-						"max_tries":     maxRetries,
-						"wait_duration": waitDuration,
-					}).Debug("Rate limited while fetching user channels, waiting before retry")
-					showProgressBar(waitDuration)
-				}
-				continue
-			}
-			logger.WithFields(logger.LogFields{
-				"user_id": userID,
-				"error":   err.Error(),
-			}).Warn("Failed to get channels for user, skipping")
-			return nil, "", err
-		}
-		break
+// getUserChannelMemberships fetches channel memberships for a single user.
+func (c *Client) getUserChannelMemberships(ctx context.Context, userID string) (map[string]bool, error) {
+	channelList, err := c.getAllConversationsForUser(ctx, userID)
+	if err != nil {
+		logger.WithFields(logger.LogFields{
+			"user_id": userID,
+			"error":   err.Error(),
+		}).Warn("Failed to get channels for user, skipping")
+		return nil, err
 	}
 
-	return channelList, nextCursor, err
-}
-
-// getUserChannelMemberships fetches channel memberships for a single user with retry logic.
-func (c *Client) getUserChannelMemberships(userID string) (map[string]bool, error) {
-	channelSet := make(map[string]bool)
-	cursor := ""
-
-	for {
-		channelList, nextCursor, err := c.getConversationsForUserWithRetry(userID, cursor)
-		if err != nil {
-			return channelSet, err
-		}
-
-		for _, ch := range channelList {
-			channelSet[ch.ID] = true
-		}
-
-		if nextCursor == "" {
-			break
-		}
-		cursor = nextCursor
+	channelSet := make(map[string]bool, len(channelList))
+	for _, ch := range channelList {
+		channelSet[ch.ID] = true
 	}
 	return channelSet, nil
 }
 
-// shouldRetryRateLimit checks if we should retry due to rate limiting.
-func shouldRetryRateLimit(err error, attempt, maxRetries int) bool {
-	if err == nil {
-		return false
-	}
-	errStr := err.Error()
-	isRateLimited := strings.Contains(errStr, "rate_limited") || strings.Contains(errStr, "rate limit")
-	return isRateLimited && attempt < maxRetries
-}
-
-// getChannelNameByID fetches a channel's name by ID with retry logic.
-func (c *Client) getChannelNameByID(channelID string) (string, error) {
-	const maxRetries = 3
-
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		channel, err := c.api.GetConversationInfo(&slack.GetConversationInfoInput{
-			ChannelID: channelID,
-		})
-		if err != nil {
-			if shouldRetryRateLimit(err, attempt, maxRetries) {
-				waitDuration := parseSlackRetryAfter(err.Error())
-				if waitDuration > 0 {
-					logger.WithFields(logger.LogFields{
-						"channel_id":    channelID,
-						"attempt":       attempt,
-						"max_tries":     maxRetries,
-						"wait_duration": waitDuration,
-					}).Debug("Rate limited while fetching channel info, waiting before retry")
-					showProgressBar(waitDuration)
-				}
-				continue
-			}
-			logger.WithFields(logger.LogFields{
-				"channel_id": channelID,
-				"error":      err.Error(),
-			}).Warn("Failed to get channel info, skipping")
-			return "", err
-		}
-		return channel.Name, nil
+// getChannelNameByID fetches a channel's name by ID.
+func (c *Client) getChannelNameByID(ctx context.Context, channelID string) (string, error) {
+	channel, err := c.api.GetConversationInfo(ctx, &slack.GetConversationInfoInput{
+		ChannelID: channelID,
+	})
+	if err != nil {
+		logger.WithFields(logger.LogFields{
+			"channel_id": channelID,
+			"error":      err.Error(),
+		}).Warn("Failed to get channel info, skipping")
+		return "", err
 	}
-	return "", fmt.Errorf("failed to get channel info after retries")
+	return channel.Name, nil
 }
 
 // DefaultChannelResult contains the result of default channel detection:
@@ -2755,22 +2685,22 @@ type UserInfo struct {
 	Name     string
 }
 
-func (c *Client) GetDefaultChannels(sampleSize int, threshold float64) ([]string, error) {
-	result, err := c.GetDefaultChannelsWithUsers(sampleSize, threshold)
+func (c *Client) GetDefaultChannels(ctx context.Context, sampleSize int, threshold float64) ([]string, error) {
+	result, err := c.GetDefaultChannelsWithUsers(ctx, sampleSize, threshold)
 	if err != nil {
 		return nil, err
 	}
 	return result.DefaultChannels, nil
 }
 
-func (c *Client) GetDefaultChannelsWithUsers(sampleSize int, threshold float64) (*DefaultChannelResult, error) {
+func (c *Client) GetDefaultChannelsWithUsers(ctx context.Context, sampleSize int, threshold float64) (*DefaultChannelResult, error) {
 	logger.WithFields(logger.LogFields{
 		"sample_size": sampleSize,
 		"threshold":   threshold,
 	}).Debug("Detecting default channels")
 
 	// Get all users
-	users, err := c.getUsersForDefaultDetection()
+	users, err := c.getUsersForDefaultDetection(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -2787,7 +2717,10 @@ func (c *Client) GetDefaultChannelsWithUsers(sampleSize int, threshold float64)
 	logger.WithField("sampled_users", len(recentUserIDs)).Debug("Sampled recent users for default channel detection")
 
 	// Get channel memberships for each user
-	userChannels := c.collectUserChannelMemberships(recentUserIDs)
+	userChannels := c.collectUserChannelMemberships(ctx, recentUserIDs)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	if len(userChannels) == 0 {
 		logger.Debug("No channel data collected")
 		return &DefaultChannelResult{DefaultChannels: []string{}, SampledUsers: sampledUsers}, nil
@@ -2797,7 +2730,10 @@ func (c *Client) GetDefaultChannelsWithUsers(sampleSize int, threshold float64)
 	commonChannelIDs := findCommonChannels(userChannels, threshold)
 
 	// Get channel names for the common channel IDs
-	defaultChannelNames := c.resolveChannelNames(commonChannelIDs)
+	defaultChannelNames := c.resolveChannelNames(ctx, commonChannelIDs)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	logger.WithFields(logger.LogFields{
 		"detected_count": len(defaultChannelNames),
@@ -2830,16 +2766,25 @@ func buildSampledUserInfo(users []slack.User, recentUserIDs []string) []UserInfo
 	return sampledUsers
 }
 
-// collectUserChannelMemberships gets channel memberships for all sampled users.
-func (c *Client) collectUserChannelMemberships(userIDs []string) map[string]map[string]bool {
+// collectUserChannelMemberships gets channel memberships for all sampled
+// users, fetching up to Concurrency users at once.
+func (c *Client) collectUserChannelMemberships(ctx context.Context, userIDs []string) map[string]map[string]bool {
+	channelSets := make([]map[string]bool, len(userIDs))
+	runOrdered(c.Concurrency(), len(userIDs), func(i int) {
+		if ctx.Err() != nil {
+			return
+		}
+		// Errors are logged in the helper; the user is left out
+		channelSets[i], _ = c.getUserChannelMemberships(ctx, userIDs[i]) //nolint:errcheck
+	}, func(int) bool {
+		return ctx.Err() == nil
+	})
+
 	userChannels := make(map[string]map[string]bool)
-	for _, userID := range userIDs {
-		channelSet, err := c.getUserChannelMemberships(userID)
-		if err != nil {
-			// Error already logged in helper, continue with other users
-			continue
+	for i, channelSet := range channelSets {
+		if channelSet != nil {
+			userChannels[userIDs[i]] = channelSet
 		}
-		userChannels[userID] = channelSet
 	}
 	return userChannels
 }
@@ -2864,87 +2809,57 @@ func findCommonChannels(userChannels map[string]map[string]bool, threshold float
 			commonChannelIDs = append(commonChannelIDs, chID)
 		}
 	}
+	// Sort so default channels are always reported in the same order
+	slices.Sort(commonChannelIDs)
 	return commonChannelIDs
 }
 
-// resolveChannelNames converts channel IDs to names.
-func (c *Client) resolveChannelNames(channelIDs []string) []string {
+// resolveChannelNames converts channel IDs to names, looking up to
+// Concurrency channels at once.
+func (c *Client) resolveChannelNames(ctx context.Context, channelIDs []string) []string {
 	channelNames := make([]string, 0, len(channelIDs))
-	for _, chID := range channelIDs {
-		name, err := c.getChannelNameByID(chID)
-		if err != nil {
-			// Error already logged in helper, continue with other channels
-			continue
-		}
-		channelNames = append(channelNames, name)
-	}
+	names := make([]string, len(channelIDs))
+	runOrdered(c.Concurrency(), len(channelIDs), func(i int) {
+		if ctx.Err() != nil {
+			return
+		}
+		// Errors are logged in the helper; the channel is left out
+		names[i], _ = c.getChannelNameByID(ctx, channelIDs[i]) //nolint:errcheck
+	}, func(i int) bool {
+		if names[i] != "" {
+			channelNames = append(channelNames, names[i])
+		}
+		return ctx.Err() == nil
+	})
 	return channelNames
 }
 
 // GetChannelActivityWithMessageAndUsers returns activity info plus message details with resolved user names.
-func (c *Client) GetChannelActivityWithMessageAndUsers(channelID string, userMap map[string]string) (lastActivity time.Time, hasWarning bool, warningTime time.Time, lastMessage *MessageInfo, err error) {
+func (c *Client) GetChannelActivityWithMessageAndUsers(ctx context.Context, channelID string, userMap map[string]string) (lastActivity time.Time, hasWarning bool, warningTime time.Time, lastMessage *MessageInfo, err error) {
 	// Get the basic activity info
-	lastActivity, hasWarning, warningTime, basicMessage, err := c.GetChannelActivityWithMessage(channelID)
+	lastActivity, hasWarning, warningTime, basicMessage, err := c.GetChannelActivityWithMessage(ctx, channelID)
 	if err != nil || basicMessage == nil {
 		return lastActivity, hasWarning, warningTime, basicMessage, err
 	}
+	return lastActivity, hasWarning, warningTime, resolveMessageUser(basicMessage, userMap), nil
+}
 
+// resolveMessageUser returns a copy of msg with the author's name resolved.
+func resolveMessageUser(msg *MessageInfo, userMap map[string]string) *MessageInfo {
 	// Resolve the user name
-	userName := userMap[basicMessage.User]
+	userName := userMap[msg.User]
 	if userName == "" {
-		userName = basicMessage.User // Fallback to ID if not found
+		userName = msg.User // Fallback to ID if not found
 	}
 
 	// Create enhanced message info with resolved name
-	enhancedMessage := &MessageInfo{
-		Timestamp: basicMessage.Timestamp,
-		User:      basicMessage.User,
+	return &MessageInfo{
+		Timestamp: msg.Timestamp,
+		User:      msg.User,
 		UserName:  userName,
-		Text:      basicMessage.Text,
-		IsBot:     basicMessage.IsBot,
-	}
-
-	return lastActivity, hasWarning, warningTime, enhancedMessage, nil
-}
-
-func shouldRetryOnRateLimit(errStr string, attempt, maxRetries int, channel string) bool {
-	if !strings.Contains(errStr, "rate_limited") && !strings.Contains(errStr, "rate limit") {
-		return false
-	}
-	if attempt >= maxRetries {
-		return false
-	}
-
-	waitDuration := parseSlackRetryAfter(errStr)
-	if waitDuration <= 0 {
-		return false
-	}
-
-	logger.WithFields(logger.LogFields{
-		"channel":       channel,
-		"attempt":       attempt,
-		"wait_duration": waitDuration.String(),
-	}).Info("Respecting Slack rate limit for duplicate check")
-	fmt.Printf("⏳ Waiting %s due to Slack rate limit (attempt %d/%d)...\n", waitDuration.String(), attempt, maxRetries)
-	showProgressBar(waitDuration)
-	fmt.Println() // Add newline after progress bar
-	return true
-}
-
-func shouldRetryOnRateLimitSimple(errStr string, attempt, maxRetries int) bool {
-	if !strings.Contains(errStr, "rate_limited") && !strings.Contains(errStr, "rate limit") {
-		return false
-	}
-	if attempt >= maxRetries {
-		return false
-	}
-
-	waitDuration := parseSlackRetryAfter(errStr)
-	if waitDuration > 0 {
-		fmt.Printf("⏳ Waiting %s due to Slack API rate limiting...\n", waitDuration.String())
-		showProgressBar(waitDuration)
+		Text:      msg.Text,
+		IsBot:     msg.IsBot,
 	}
-	return true
 }
 
 func formatDurationSeconds(seconds int) string {
@@ -1,6 +1,7 @@
 package slack
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -31,7 +32,7 @@ func TestAutoJoinPublicChannelsErrorPaths(t *testing.T) {
 			},
 		}
 
-		joinedCount, err := client.autoJoinPublicChannels(channels)
+		joinedCount, err := client.autoJoinPublicChannels(context.Background(), channels)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "rate limited during auto-join")
 		assert.Equal(t, 0, joinedCount)
@@ -57,7 +58,7 @@ func TestAutoJoinPublicChannelsErrorPaths(t *testing.T) {
 			},
 		}
 
-		joinedCount, err := client.autoJoinPublicChannels(channels)
+		joinedCount, err := client.autoJoinPublicChannels(context.Background(), channels)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "missing required OAuth scope")
 		assert.Contains(t, err.Error(), "channels:join")
@@ -84,7 +85,7 @@ func TestAutoJoinPublicChannelsErrorPaths(t *testing.T) {
 			},
 		}
 
-		joinedCount, err := client.autoJoinPublicChannels(channels)
+		joinedCount, err := client.autoJoinPublicChannels(context.Background(), channels)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "invalid authentication token")
 		assert.Equal(t, 0, joinedCount)
@@ -110,7 +111,7 @@ func TestAutoJoinPublicChannelsErrorPaths(t *testing.T) {
 			},
 		}
 
-		joinedCount, err := client.autoJoinPublicChannels(channels)
+		joinedCount, err := client.autoJoinPublicChannels(context.Background(), channels)
 		assert.NoError(t, err)
 		assert.Equal(t, 1, joinedCount) // Should count as successful join
 	})
@@ -135,7 +136,7 @@ func TestAutoJoinPublicChannelsErrorPaths(t *testing.T) {
 			},
 		}
 
-		joinedCount, err := client.autoJoinPublicChannels(channels)
+		joinedCount, err := client.autoJoinPublicChannels(context.Background(), channels)
 		assert.NoError(t, err)
 		assert.Equal(t, 0, joinedCount) // Should be skipped, not counted
 	})
@@ -160,7 +161,7 @@ func TestAutoJoinPublicChannelsErrorPaths(t *testing.T) {
 			},
 		}
 
-		joinedCount, err := client.autoJoinPublicChannels(channels)
+		joinedCount, err := client.autoJoinPublicChannels(context.Background(), channels)
 		assert.NoError(t, err)
 		assert.Equal(t, 0, joinedCount) // Should be skipped, not counted
 	})
@@ -183,7 +184,7 @@ func TestAutoJoinPublicChannelsErrorPaths(t *testing.T) {
 			},
 		}
 
-		joinedCount, err := client.autoJoinPublicChannels(channels)
+		joinedCount, err := client.autoJoinPublicChannels(context.Background(), channels)
 		assert.NoError(t, err)
 		assert.Equal(t, 0, joinedCount) // Should be skipped
 	})
@@ -198,7 +199,7 @@ func TestPostMessageToChannelIDErrorPaths(t *testing.T) {
 		// Set up mock to return rate limit error
 		mockAPI.SetPostMessageError("rate_limited")
 
-		err = client.postMessageToChannelID("C123", "test message")
+		err = client.postMessageToChannelID(context.Background(), "C123", "test message")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "rate limited")
 	})
@@ -211,7 +212,7 @@ func TestPostMessageToChannelIDErrorPaths(t *testing.T) {
 		// Set up mock to return missing scope error
 		mockAPI.SetPostMessageError("missing_scope")
 
-		err = client.postMessageToChannelID("C123", "test message")
+		err = client.postMessageToChannelID(context.Background(), "C123", "test message")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "missing required permission to post messages")
 		assert.Contains(t, err.Error(), "chat:write")
@@ -225,7 +226,7 @@ func TestPostMessageToChannelIDErrorPaths(t *testing.T) {
 		// Set up mock to return channel not found error
 		mockAPI.SetPostMessageError("channel_not_found")
 
-		err = client.postMessageToChannelID("C123", "test message")
+		err = client.postMessageToChannelID(context.Background(), "C123", "test message")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "channel with ID 'C123' not found")
 	})
@@ -238,7 +239,7 @@ func TestPostMessageToChannelIDErrorPaths(t *testing.T) {
 		// Set up mock to return not in channel error
 		mockAPI.SetPostMessageError("not_in_channel")
 
-		err = client.postMessageToChannelID("C123", "test message")
+		err = client.postMessageToChannelID(context.Background(), "C123", "test message")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "bot is not a member of channel")
 		assert.Contains(t, err.Error(), "Please add the bot to the channel")
@@ -252,7 +253,7 @@ func TestPostMessageToChannelIDErrorPaths(t *testing.T) {
 		// Set up mock to return invalid auth error
 		mockAPI.SetPostMessageError("invalid_auth")
 
-		err = client.postMessageToChannelID("C123", "test message")
+		err = client.postMessageToChannelID(context.Background(), "C123", "test message")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to post message to channel")
 	})
@@ -263,7 +264,7 @@ func TestPostMessageToChannelIDErrorPaths(t *testing.T) {
 		require.NoError(t, err)
 
 		// No errors - should succeed
-		err = client.postMessageToChannelID("C123", "test message")
+		err = client.postMessageToChannelID(context.Background(), "C123", "test message")
 		assert.NoError(t, err)
 	})
 }
@@ -306,7 +307,7 @@ func TestGetNewChannels(t *testing.T) {
 
 		// Look for channels created in the last 2 hours
 		since := time.Now().Add(-2 * time.Hour)
-		channels, err := client.GetNewChannels(since)
+		channels, err := client.GetNewChannels(context.Background(), since)
 
 		assert.NoError(t, err)
 		assert.Len(t, channels, 1)
@@ -324,7 +325,7 @@ func TestGetNewChannels(t *testing.T) {
 
 		// Look for channels created in the last 2 hours
 		since := time.Now().Add(-2 * time.Hour)
-		channels, err := client.GetNewChannels(since)
+		channels, err := client.GetNewChannels(context.Background(), since)
 
 		assert.NoError(t, err)
 		assert.Len(t, channels, 0)
@@ -342,7 +343,7 @@ func TestGetNewChannels(t *testing.T) {
 		mockAPI.AddChannel("C1234567890", "boundary-channel", boundaryTime, "Boundary channel")
 
 		// Look for channels created after the boundary (should not include the boundary channel)
-		channels, err := client.GetNewChannels(boundaryTime)
+		channels, err := client.GetNewChannels(context.Background(), boundaryTime)
 
 		assert.NoError(t, err)
 		assert.Len(t, channels, 0) // Channel created AT boundary time should not be included
@@ -361,7 +362,7 @@ func TestGetNewChannels(t *testing.T) {
 		mockAPI.AddChannel("C1234567890", "after-boundary-channel", oneSecondAfter, "After boundary")
 
 		// Look for channels created after the boundary (should include this channel)
-		channels, err := client.GetNewChannels(boundaryTime)
+		channels, err := client.GetNewChannels(context.Background(), boundaryTime)
 
 		assert.NoError(t, err)
 		assert.Len(t, channels, 1)
@@ -376,7 +377,7 @@ func TestGetNewChannels(t *testing.T) {
 		mockAPI.SetGetConversationsError(true)
 
 		since := time.Now().Add(-2 * time.Hour)
-		_, err = client.GetNewChannels(since)
+		_, err = client.GetNewChannels(context.Background(), since)
 
 		assert.Error(t, err)
 	})
@@ -389,7 +390,7 @@ func TestGetNewChannels(t *testing.T) {
 		mockAPI.SetMissingScopeError(true)
 
 		since := time.Now().Add(-2 * time.Hour)
-		_, err = client.GetNewChannels(since)
+		_, err = client.GetNewChannels(context.Background(), since)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "missing required permissions")
@@ -404,7 +405,7 @@ func TestGetNewChannels(t *testing.T) {
 		mockAPI.SetInvalidAuthError(true)
 
 		since := time.Now().Add(-2 * time.Hour)
-		_, err = client.GetNewChannels(since)
+		_, err = client.GetNewChannels(context.Background(), since)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "invalid token")
@@ -503,7 +504,7 @@ func TestGetNewChannelsErrorHandling(t *testing.T) {
 		mockAPI.SetGetConversationsErrorWithMessage(true, "rate_limited")
 
 		since := time.Now().Add(-1 * time.Hour)
-		channels, err := client.GetNewChannels(since)
+		channels, err := client.GetNewChannels(context.Background(), since)
 
 		assert.Error(t, err)
 		assert.Nil(t, channels)
@@ -521,7 +522,7 @@ func TestGetNewChannelsErrorHandling(t *testing.T) {
 		mockAPI.SetGetConversationsErrorWithMessage(true, "missing_scope")
 
 		since := time.Now().Add(-1 * time.Hour)
-		channels, err := client.GetNewChannels(since)
+		channels, err := client.GetNewChannels(context.Background(), since)
 
 		assert.Error(t, err)
 		assert.Nil(t, channels)
@@ -538,7 +539,7 @@ func TestGetNewChannelsErrorHandling(t *testing.T) {
 		mockAPI.SetGetConversationsErrorWithMessage(true, "invalid_auth")
 
 		since := time.Now().Add(-1 * time.Hour)
-		channels, err := client.GetNewChannels(since)
+		channels, err := client.GetNewChannels(context.Background(), since)
 
 		assert.Error(t, err)
 		assert.Nil(t, channels)
@@ -555,7 +556,7 @@ func TestGetNewChannelsErrorHandling(t *testing.T) {
 		mockAPI.SetGetConversationsErrorWithMessage(true, "network_error")
 
 		since := time.Now().Add(-1 * time.Hour)
-		channels, err := client.GetNewChannels(since)
+		channels, err := client.GetNewChannels(context.Background(), since)
 
 		assert.Error(t, err)
 		assert.Nil(t, channels)
@@ -570,7 +571,7 @@ func TestPostMessageErrorHandling(t *testing.T) {
 		require.NoError(t, err)
 
 		// Don't add the channel to mock - this will cause the resolve to fail
-		err = client.PostMessage("#nonexistent", "Test message")
+		err = client.PostMessage(context.Background(), "#nonexistent", "Test message")
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to find channel #nonexistent")
@@ -588,7 +589,7 @@ func TestPostMessageErrorHandling(t *testing.T) {
 		// Configure mock to return rate limit error at PostMessage level
 		mockAPI.SetPostMessageError("rate_limited")
 
-		err = client.PostMessage("#general", "Test message")
+		err = client.PostMessage(context.Background(), "#general", "Test message")
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "rate limited")
@@ -605,7 +606,7 @@ func TestPostMessageErrorHandling(t *testing.T) {
 		// Configure mock to return missing scope error
 		mockAPI.SetPostMessageError("missing_scope")
 
-		err = client.PostMessage("#general", "Test message")
+		err = client.PostMessage(context.Background(), "#general", "Test message")
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "missing required permission")
@@ -691,7 +692,7 @@ func TestPostMessage(t *testing.T) {
 		client, err := NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		err = client.PostMessage("#general", "Test message")
+		err = client.PostMessage(context.Background(), "#general", "Test message")
 		assert.NoError(t, err)
 
 		messages := mockAPI.GetPostedMessages()
@@ -704,7 +705,7 @@ func TestPostMessage(t *testing.T) {
 		client, err := NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		err = client.PostMessage("", "Test message")
+		err = client.PostMessage(context.Background(), "", "Test message")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "invalid channel name")
 	})
@@ -718,7 +719,7 @@ func TestPostMessage(t *testing.T) {
 
 		mockAPI.SetPostMessageError("missing_scope")
 
-		err = client.PostMessage("#general", "Test message")
+		err = client.PostMessage(context.Background(), "#general", "Test message")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "missing required permission")
 		assert.Contains(t, err.Error(), "chat:write")
@@ -731,7 +732,7 @@ func TestPostMessage(t *testing.T) {
 
 		mockAPI.SetPostMessageError("channel_not_found")
 
-		err = client.PostMessage("#nonexistent", "Test message")
+		err = client.PostMessage(context.Background(), "#nonexistent", "Test message")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "channel '#nonexistent' not found")
 	})
@@ -745,7 +746,7 @@ func TestPostMessage(t *testing.T) {
 
 		mockAPI.SetPostMessageError("not_in_channel")
 
-		err = client.PostMessage("#private", "Test message")
+		err = client.PostMessage(context.Background(), "#private", "Test message")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "bot is not a member")
 	})
@@ -759,7 +760,7 @@ func TestPostMessage(t *testing.T) {
 
 		mockAPI.SetPostMessageError("some_other_error")
 
-		err = client.PostMessage("#general", "Test message")
+		err = client.PostMessage(context.Background(), "#general", "Test message")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to post message to #general")
 		assert.Contains(t, err.Error(), "some_other_error")
@@ -832,7 +833,7 @@ func TestTestAuth(t *testing.T) {
 			assert.NoError(t, err)
 			assert.NotNil(t, client)
 
-			auth, err := client.TestAuth()
+			auth, err := client.TestAuth(context.Background())
 			assert.NoError(t, err)
 			tt.checkResult(t, auth)
 		})
@@ -1023,7 +1024,7 @@ func TestExtSharedChannelFiltering(t *testing.T) {
 	// End-to-end through the public entry point.
 	t.Run("End-to-end protection via GetInactiveChannels", func(t *testing.T) {
 		client.SetIncludeExtShared(false)
-		toWarn, _, _, err := client.GetInactiveChannelsWithDetailsAndExclusions(
+		toWarn, _, _, err := client.GetInactiveChannelsWithDetailsAndExclusions(context.Background(),
 			warnSeconds, archiveSeconds, map[string]string{}, nil, nil, false, true, 0,
 		)
 		require.NoError(t, err)
@@ -1033,6 +1034,90 @@ func TestExtSharedChannelFiltering(t *testing.T) {
 	})
 }
 
+func TestPrivateChannelSupport(t *testing.T) {
+	oldEnough := time.Now().Add(-90 * 24 * time.Hour)
+	warnSeconds := 30 * 24 * 60 * 60
+	archiveSeconds := 30 * 24 * 60 * 60
+
+	setup := func(t *testing.T) (*MockSlackAPI, *Client) {
+		mockAPI := NewMockSlackAPI()
+		mockAPI.AddChannel("C-PUBLIC", "public-stale", oldEnough, "")
+		mockAPI.AddPrivateChannel("G-MEMBER", "private-stale", oldEnough, "", true)
+		mockAPI.AddPrivateChannel("G-OTHER", "private-uninvited", oldEnough, "", false)
+		client, err := NewClientWithAPI(mockAPI)
+		require.NoError(t, err)
+		return mockAPI, client
+	}
+
+	t.Run("Private channels excluded by default", func(t *testing.T) {
+		_, client := setup(t)
+		assert.False(t, client.IncludePrivate())
+		assert.Equal(t, []string{"public_channel"}, client.conversationTypes())
+
+		toWarn, _, _, err := client.GetInactiveChannelsWithDetailsAndExclusions(context.Background(),
+			warnSeconds, archiveSeconds, map[string]string{}, nil, nil, false, false, 0,
+		)
+		require.NoError(t, err)
+		require.Len(t, toWarn, 1)
+		assert.Equal(t, "public-stale", toWarn[0].Name)
+	})
+
+	t.Run("Include private analyzes member channels only", func(t *testing.T) {
+		mockAPI, client := setup(t)
+		client.SetIncludePrivate(true)
+		assert.Equal(t, []string{"public_channel", "private_channel"}, client.conversationTypes())
+
+		toWarn, _, _, err := client.GetInactiveChannelsWithDetailsAndExclusions(context.Background(),
+			warnSeconds, archiveSeconds, map[string]string{}, nil, nil, false, false, 0,
+		)
+		require.NoError(t, err)
+		require.Len(t, toWarn, 2)
+		names := []string{toWarn[0].Name, toWarn[1].Name}
+		assert.Contains(t, names, "private-stale")
+		assert.NotContains(t, names, "private-uninvited")
+		assert.NotContains(t, mockAPI.JoinedChannels, "G-MEMBER", "private channels are never joined")
+	})
+
+	t.Run("Warning a private channel skips join", func(t *testing.T) {
+		mockAPI, client := setup(t)
+		client.SetIncludePrivate(true)
+
+		err := client.WarnInactiveChannel(context.Background(), Channel{ID: "G-MEMBER", Name: "private-stale", IsPrivate: true}, warnSeconds, archiveSeconds, "")
+		require.NoError(t, err)
+		assert.Empty(t, mockAPI.JoinedChannels)
+		require.Len(t, mockAPI.PostedMessages, 1)
+		assert.Equal(t, "G-MEMBER", mockAPI.PostedMessages[0].ChannelID)
+	})
+
+	t.Run("Private channels are never highlighted", func(t *testing.T) {
+		_, client := setup(t)
+		client.SetIncludePrivate(true)
+
+		channels, err := client.GetRandomChannels(context.Background(), 10)
+		require.NoError(t, err)
+		require.Len(t, channels, 1)
+		assert.Equal(t, "public-stale", channels[0].Name)
+	})
+
+	t.Run("Archive missing scope names groups:write for private channels", func(t *testing.T) {
+		mockAPI, client := setup(t)
+		mockAPI.SetArchiveConversationError(missingScope)
+
+		err := client.ArchiveChannelWithThresholds(context.Background(), Channel{ID: "G-MEMBER", Name: "private-stale", IsPrivate: true}, warnSeconds, archiveSeconds)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "groups:write")
+	})
+
+	t.Run("CheckOAuthScopes reports private channel scopes", func(t *testing.T) {
+		_, client := setup(t)
+		scopes, err := client.CheckOAuthScopes(context.Background())
+		require.NoError(t, err)
+		assert.True(t, scopes["groups:read"])
+		assert.True(t, scopes["groups:history"])
+		assert.True(t, scopes["groups:write"])
+	})
+}
+
 func TestSetDiscussionChannel(t *testing.T) {
 	mockAPI := NewMockSlackAPI()
 	client, err := NewClientWithAPI(mockAPI)
@@ -1107,7 +1192,7 @@ func TestCheckOAuthScopes(t *testing.T) {
 		client, err := NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		scopes, err := client.CheckOAuthScopes()
+		scopes, err := client.CheckOAuthScopes(context.Background())
 		assert.NoError(t, err)
 		assert.NotNil(t, scopes)
 
@@ -1131,47 +1216,6 @@ func TestCheckOAuthScopes(t *testing.T) {
 	})
 }
 
-func TestScopeTestFunctions(t *testing.T) {
-	mockAPI := NewMockSlackAPI()
-	client, err := NewClientWithAPI(mockAPI)
-	require.NoError(t, err)
-
-	t.Run("testChannelsReadScope - success", func(t *testing.T) {
-		result := client.testChannelsReadScope()
-		assert.True(t, result)
-	})
-
-	t.Run("testChannelsReadScope - missing scope", func(t *testing.T) {
-		mockAPI.SetGetConversationsErrorWithMessage(true, "missing_scope")
-		result := client.testChannelsReadScope()
-		assert.False(t, result)
-
-		// Reset for other tests
-		mockAPI.SetGetConversationsErrorWithMessage(false, "")
-	})
-
-	t.Run("testChannelsJoinScope - always returns true", func(t *testing.T) {
-		result := client.testChannelsJoinScope()
-		assert.True(t, result)
-	})
-
-	t.Run("testChatWriteScope - always returns true", func(t *testing.T) {
-		result := client.testChatWriteScope()
-		assert.True(t, result)
-	})
-
-	t.Run("testChannelsManageScope - always returns true", func(t *testing.T) {
-		result := client.testChannelsManageScope()
-		assert.True(t, result)
-	})
-
-	t.Run("testUsersReadScope - always returns true", func(t *testing.T) {
-		result := client.testUsersReadScope()
-		assert.True(t, result)
-	})
-
-}
-
 func TestGetInactiveChannels(t *testing.T) {
 	t.Run("No inactive channels", func(t *testing.T) {
 		mockAPI := NewMockSlackAPI()
@@ -1184,7 +1228,7 @@ func TestGetInactiveChannels(t *testing.T) {
 		mockAPI.AddChannel("C1234567890", "active-channel", recentTime, "Active channel")
 
 		// Check for inactive channels (warn after 2 hours, archive after 1 hour)
-		toWarn, toArchive, err := client.GetInactiveChannels(7200, 3600) // 2 hours warn, 1 hour archive
+		toWarn, toArchive, err := client.GetInactiveChannels(context.Background(), 7200, 3600) // 2 hours warn, 1 hour archive
 
 		assert.NoError(t, err)
 		assert.Len(t, toWarn, 0)
@@ -1211,7 +1255,7 @@ func TestGetInactiveChannels(t *testing.T) {
 		})
 
 		// Check for inactive channels (warn after 2 hours, archive after 1 hour)
-		toWarn, toArchive, err := client.GetInactiveChannels(7200, 3600) // 2 hours warn, 1 hour archive
+		toWarn, toArchive, err := client.GetInactiveChannels(context.Background(), 7200, 3600) // 2 hours warn, 1 hour archive
 
 		assert.NoError(t, err)
 		assert.Len(t, toWarn, 1)
@@ -1254,7 +1298,7 @@ func TestGetInactiveChannels(t *testing.T) {
 
 		// Check for inactive channels (warn after 3 hours, archive after 1 hour from warning)
 		// Since warning was 2 hours ago, and archive threshold is 1 hour, this should be archived
-		toWarn, toArchive, err := client.GetInactiveChannels(10800, 3600) // 3 hours warn, 1 hour archive
+		toWarn, toArchive, err := client.GetInactiveChannels(context.Background(), 10800, 3600) // 3 hours warn, 1 hour archive
 
 		assert.NoError(t, err)
 		assert.Len(t, toWarn, 0)
@@ -1292,7 +1336,7 @@ func TestGetInactiveChannels(t *testing.T) {
 		}
 
 		// Check for inactive channels (warn after 3 hours, archive after 1 hour)
-		toWarn, _, err := client.GetInactiveChannels(10800, 3600)
+		toWarn, _, err := client.GetInactiveChannels(context.Background(), 10800, 3600)
 
 		assert.NoError(t, err)
 		assert.Len(t, toWarn, 1) // Only old-project should be warned
@@ -1307,7 +1351,7 @@ func TestGetInactiveChannels(t *testing.T) {
 		// Configure mock to return error on GetConversations
 		mockAPI.SetGetConversationsError(true)
 
-		toWarn, toArchive, err := client.GetInactiveChannels(7200, 3600)
+		toWarn, toArchive, err := client.GetInactiveChannels(context.Background(), 7200, 3600)
 
 		assert.Error(t, err)
 		assert.Nil(t, toWarn)
@@ -1334,7 +1378,7 @@ func TestGetChannelActivity(t *testing.T) {
 			},
 		})
 
-		lastActivity, hasWarning, warningTime, err := client.GetChannelActivity("C1234567890")
+		lastActivity, hasWarning, warningTime, err := client.GetChannelActivity(context.Background(), "C1234567890")
 
 		assert.NoError(t, err)
 		assert.False(t, hasWarning)
@@ -1372,7 +1416,7 @@ func TestGetChannelActivity(t *testing.T) {
 
 		mockAPI.SetBotUserID("UBOT123456")
 
-		lastActivity, hasWarning, actualWarningTime, err := client.GetChannelActivity("C1234567890")
+		lastActivity, hasWarning, actualWarningTime, err := client.GetChannelActivity(context.Background(), "C1234567890")
 
 		assert.NoError(t, err)
 		assert.True(t, hasWarning)
@@ -1388,7 +1432,7 @@ func TestGetChannelActivity(t *testing.T) {
 		// Mock empty conversation history
 		mockAPI.SetChannelHistory("C1234567890", []MockHistoryMessage{})
 
-		lastActivity, hasWarning, warningTime, err := client.GetChannelActivity("C1234567890")
+		lastActivity, hasWarning, warningTime, err := client.GetChannelActivity(context.Background(), "C1234567890")
 
 		assert.NoError(t, err)
 		assert.False(t, hasWarning)
@@ -1415,7 +1459,7 @@ func TestGetChannelActivity(t *testing.T) {
 			},
 		})
 
-		lastActivity, hasWarning, warningTime, err := client.GetChannelActivity("C1234567890")
+		lastActivity, hasWarning, warningTime, err := client.GetChannelActivity(context.Background(), "C1234567890")
 
 		assert.NoError(t, err)
 		assert.False(t, hasWarning)
@@ -1432,7 +1476,7 @@ func TestGetChannelActivity(t *testing.T) {
 		// Configure mock to return error on GetConversationHistory
 		mockAPI.SetGetConversationHistoryError("C1234567890", true)
 
-		_, _, _, err = client.GetChannelActivity("C1234567890")
+		_, _, _, err = client.GetChannelActivity(context.Background(), "C1234567890")
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to get channel history")
@@ -1560,7 +1604,7 @@ func TestWarnInactiveChannel(t *testing.T) {
 			Name: "inactive-channel",
 		}
 
-		err = client.WarnInactiveChannel(channel, 7200, 3600, "") // 2 hours warn, 1 hour archive
+		err = client.WarnInactiveChannel(context.Background(), channel, 7200, 3600, "") // 2 hours warn, 1 hour archive
 
 		assert.NoError(t, err)
 
@@ -1586,7 +1630,7 @@ func TestWarnInactiveChannel(t *testing.T) {
 			Name: "private-channel",
 		}
 
-		err = client.WarnInactiveChannel(channel, 7200, 3600, "")
+		err = client.WarnInactiveChannel(context.Background(), channel, 7200, 3600, "")
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to join channel")
@@ -1608,7 +1652,7 @@ func TestArchiveChannel(t *testing.T) {
 			Name: "to-archive",
 		}
 
-		err = client.ArchiveChannelWithThresholds(channel, 7200, 3600)
+		err = client.ArchiveChannelWithThresholds(context.Background(), channel, 7200, 3600)
 
 		assert.NoError(t, err)
 
@@ -1637,7 +1681,7 @@ func TestArchiveChannel(t *testing.T) {
 			Name: "private-to-archive",
 		}
 
-		err = client.ArchiveChannelWithThresholds(channel, 7200, 3600)
+		err = client.ArchiveChannelWithThresholds(context.Background(), channel, 7200, 3600)
 
 		assert.NoError(t, err)
 
@@ -1658,7 +1702,7 @@ func TestArchiveChannel(t *testing.T) {
 			Name: "test-channel",
 		}
 
-		err = client.ArchiveChannelWithThresholds(channel, 7200, 3600)
+		err = client.ArchiveChannelWithThresholds(context.Background(), channel, 7200, 3600)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "missing required permission to archive channels")
@@ -1678,7 +1722,7 @@ func TestArchiveChannel(t *testing.T) {
 			Name: "test-channel",
 		}
 
-		err = client.ArchiveChannelWithThresholds(channel, 7200, 3600)
+		err = client.ArchiveChannelWithThresholds(context.Background(), channel, 7200, 3600)
 
 		assert.NoError(t, err) // Should not error for already archived
 	})
@@ -1713,71 +1757,36 @@ func TestFormatDurationDirect(t *testing.T) {
 	}
 }
 
-// Test parseSlackRetryAfter function.
-func TestParseSlackRetryAfter(t *testing.T) {
-	tests := []struct {
-		name     string
-		errorStr string
-		expected time.Duration
-	}{
-		{"Valid retry after seconds", "Error: retry after 30s", 31 * time.Second}, // 30s + 1s buffer
-		{"Valid retry after minutes", "Error: retry after 2m", 2*time.Minute + 1*time.Second},
-		{"Valid retry after with text", "Rate limited, retry after 1m30s", 1*time.Minute + 30*time.Second + 1*time.Second},
-		{"No retry after in string", "Generic error message", 0},
-		{"Empty string", "", 0},
-		{"Invalid duration format", "retry after invalid", 0},
-		{"Multiple retry after (first wins)", "retry after 10s and retry after 20s", 11 * time.Second},
-		{"Retry after with extra text", "API error: retry after 45s due to rate limiting", 46 * time.Second},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := parseSlackRetryAfter(tt.errorStr)
-			assert.Equal(t, tt.expected, result)
-		})
-	}
-}
+func TestContextCancellation(t *testing.T) {
+	t.Run("Cancelled context aborts API calls", func(t *testing.T) {
+		mockAPI := NewMockSlackAPI()
+		mockAPI.AddChannel("C1", "general", time.Now().Add(-time.Hour), "")
+		client, err := NewClientWithAPI(mockAPI)
+		require.NoError(t, err)
 
-// Test showProgressBar function.
-func TestShowProgressBar(t *testing.T) {
-	// Capture output for testing - this is a UI function, so we test basic behavior
-	// without mocking complex console output since it's primarily for user feedback
-	t.Run("Zero duration returns immediately", func(t *testing.T) {
-		// Should return immediately without any output or panic
-		start := time.Now()
-		showProgressBar(0)
-		elapsed := time.Since(start)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
 
-		// Should complete almost instantly (within 10ms)
-		assert.Less(t, elapsed, 10*time.Millisecond)
+		_, err = client.GetNewChannels(ctx, time.Now().Add(-2*time.Hour))
+		assert.ErrorIs(t, err, context.Canceled)
 	})
 
-	t.Run("Negative duration returns immediately", func(t *testing.T) {
-		// Should return immediately without any output or panic
-		start := time.Now()
-		showProgressBar(-5 * time.Second)
-		elapsed := time.Since(start)
+	t.Run("Cancelled context stops analysis before joining", func(t *testing.T) {
+		mockAPI := NewMockSlackAPI()
+		client, err := NewClientWithAPI(mockAPI)
+		require.NoError(t, err)
 
-		// Should complete almost instantly (within 10ms)
-		assert.Less(t, elapsed, 10*time.Millisecond)
-	})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
 
-	t.Run("Very short duration works without panic", func(t *testing.T) {
-		// Test with 1 millisecond - should not panic and complete quickly
-		// since it gets rounded to 0 seconds in the function
-		start := time.Now()
-		showProgressBar(1 * time.Millisecond)
-		elapsed := time.Since(start)
+		candidates := []slack.Channel{{GroupConversation: slack.GroupConversation{Conversation: slack.Conversation{ID: "C1"}, Name: "stale"}}}
+		_, err = client.autoJoinPublicChannels(ctx, candidates)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Empty(t, mockAPI.JoinedChannels)
 
-		// Should complete almost instantly since duration < 1 second
-		assert.Less(t, elapsed, 10*time.Millisecond)
+		_, _, err = client.analyzeChannelsForInactivity(ctx, candidates, map[string]string{}, time.Now(), 60, false, false, 0)
+		assert.ErrorIs(t, err, context.Canceled)
 	})
-
-	// Note: We don't test actual progress bar output since it involves:
-	// - Real-time sleep operations (would slow down tests significantly)
-	// - Complex console output formatting that's hard to capture reliably
-	// - User interface behavior that's better verified through manual testing
-	// The function's core logic (duration handling, bounds checking) is covered above
 }
 
 // Test getUserMap function with mock API.
@@ -1793,7 +1802,7 @@ func TestGetUserMapUtility(t *testing.T) {
 		mockAPI.AddUser("U789", "", "")                 // No RealName or Name, DisplayName will be "" - should use ID
 		mockAPI.AddUser("U999", "", "")                 // Only ID available - should use "U999"
 
-		userMap, err := client.GetUserMap()
+		userMap, err := client.GetUserMap(context.Background())
 		assert.NoError(t, err)
 		assert.Len(t, userMap, 4)
 
@@ -1810,7 +1819,7 @@ func TestGetUserMapUtility(t *testing.T) {
 		client, err := NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		userMap, err := client.GetUserMap()
+		userMap, err := client.GetUserMap(context.Background())
 		assert.Error(t, err)
 		assert.Nil(t, userMap)
 		assert.Contains(t, err.Error(), "missing_scope")
@@ -1822,7 +1831,7 @@ func TestGetUserMapUtility(t *testing.T) {
 		require.NoError(t, err)
 		// Users slice is empty by default
 
-		userMap, err := client.GetUserMap()
+		userMap, err := client.GetUserMap(context.Background())
 		assert.NoError(t, err)
 		assert.Empty(t, userMap)
 	})
@@ -1833,7 +1842,7 @@ func TestGetUserMapUtility(t *testing.T) {
 		client, err := NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		userMap, err := client.GetUserMap()
+		userMap, err := client.GetUserMap(context.Background())
 		assert.Error(t, err)
 		assert.Nil(t, userMap)
 		assert.Contains(t, err.Error(), "api_error")
@@ -1865,7 +1874,7 @@ func TestGetInactiveChannelsWithDetails(t *testing.T) {
 
 		// Test with 10 minute warn threshold, 5 minute archive threshold
 		userMap := map[string]string{"U123": "testuser"}
-		toWarn, toArchive, err := client.GetInactiveChannelsWithDetails(600, 300, userMap, false) // 10min warn, 5min archive
+		toWarn, toArchive, err := client.GetInactiveChannelsWithDetails(context.Background(), 600, 300, userMap, false) // 10min warn, 5min archive
 
 		assert.NoError(t, err)
 		// inactive-channel should be in toWarn (30 min > 10 min threshold)
@@ -1882,7 +1891,7 @@ func TestGetInactiveChannelsWithDetails(t *testing.T) {
 		require.NoError(t, err)
 
 		userMap := map[string]string{}
-		toWarn, toArchive, err := client.GetInactiveChannelsWithDetails(600, 300, userMap, false)
+		toWarn, toArchive, err := client.GetInactiveChannelsWithDetails(context.Background(), 600, 300, userMap, false)
 
 		assert.Error(t, err)
 		assert.Nil(t, toWarn)
@@ -1917,7 +1926,7 @@ func TestGetInactiveChannelsWithDetailsAndExclusions(t *testing.T) {
 		excludeChannels := []string{"general"}
 		excludePrefixes := []string{"prod-"}
 
-		toWarn, toArchive, _, err := client.GetInactiveChannelsWithDetailsAndExclusions(600, 300, userMap, excludeChannels, excludePrefixes, false, false, 0)
+		toWarn, toArchive, _, err := client.GetInactiveChannelsWithDetailsAndExclusions(context.Background(), 600, 300, userMap, excludeChannels, excludePrefixes, false, false, 0)
 
 		assert.NoError(t, err)
 		// Only test-channel should remain (general excluded by name, prod-alerts excluded by prefix)
@@ -1940,7 +1949,7 @@ func TestArchiveChannelLegacyMethod(t *testing.T) {
 		}
 
 		// Test the legacy method (should call ArchiveChannelWithThresholds with default values)
-		err = client.ArchiveChannel(channel)
+		err = client.ArchiveChannel(context.Background(), channel)
 
 		assert.NoError(t, err)
 
@@ -1967,7 +1976,7 @@ func TestGetChannelsWithMetadata(t *testing.T) {
 		client, err := NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		channels, err := client.GetChannelsWithMetadata()
+		channels, err := client.GetChannelsWithMetadata(context.Background())
 
 		assert.NoError(t, err)
 		assert.Len(t, channels, 2)
@@ -1987,7 +1996,7 @@ func TestGetChannelsWithMetadata(t *testing.T) {
 		client, err := NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		channels, err := client.GetChannelsWithMetadata()
+		channels, err := client.GetChannelsWithMetadata(context.Background())
 
 		assert.Error(t, err)
 		assert.Nil(t, channels)
@@ -1999,7 +2008,7 @@ func TestGetChannelsWithMetadata(t *testing.T) {
 		client, err := NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		channels, err := client.GetChannelsWithMetadata()
+		channels, err := client.GetChannelsWithMetadata(context.Background())
 
 		assert.NoError(t, err)
 		assert.Len(t, channels, 0)
@@ -2013,7 +2022,7 @@ func TestCheckForDuplicateAnnouncement(t *testing.T) {
 		client, err := NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		isDuplicate, err := client.CheckForDuplicateAnnouncement("#general", "New channel alert! #test-channel", []string{"test-channel"})
+		isDuplicate, err := client.CheckForDuplicateAnnouncement(context.Background(), "#general", "New channel alert! #test-channel", []string{"test-channel"})
 
 		assert.NoError(t, err)
 		assert.False(t, isDuplicate)
@@ -2029,7 +2038,7 @@ func TestCheckForDuplicateAnnouncement(t *testing.T) {
 		client, err := NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		isDuplicate, err := client.CheckForDuplicateAnnouncement("#general", "New channel alert! #test-channel", []string{"test-channel"})
+		isDuplicate, err := client.CheckForDuplicateAnnouncement(context.Background(), "#general", "New channel alert! #test-channel", []string{"test-channel"})
 
 		assert.NoError(t, err)
 		assert.True(t, isDuplicate)
@@ -2045,7 +2054,7 @@ func TestCheckForDuplicateAnnouncement(t *testing.T) {
 		client, err := NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		isDuplicate, err := client.CheckForDuplicateAnnouncement("#general", "New channel alert! #test-channel", []string{"test-channel"})
+		isDuplicate, err := client.CheckForDuplicateAnnouncement(context.Background(), "#general", "New channel alert! #test-channel", []string{"test-channel"})
 
 		assert.NoError(t, err)
 		assert.False(t, isDuplicate)
@@ -2061,7 +2070,7 @@ func TestCheckForDuplicateAnnouncement(t *testing.T) {
 		client, err := NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		isDuplicate, err := client.CheckForDuplicateAnnouncement("#general", "New channel alert! #test-channel", []string{"test-channel"})
+		isDuplicate, err := client.CheckForDuplicateAnnouncement(context.Background(), "#general", "New channel alert! #test-channel", []string{"test-channel"})
 
 		assert.NoError(t, err)
 		assert.False(t, isDuplicate)
@@ -2072,7 +2081,7 @@ func TestCheckForDuplicateAnnouncement(t *testing.T) {
 		client, err := NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		isDuplicate, err := client.CheckForDuplicateAnnouncement("#nonexistent", "New channel alert! #test-channel", []string{"test-channel"})
+		isDuplicate, err := client.CheckForDuplicateAnnouncement(context.Background(), "#nonexistent", "New channel alert! #test-channel", []string{"test-channel"})
 
 		assert.Error(t, err)
 		assert.False(t, isDuplicate)
@@ -2087,7 +2096,7 @@ func TestCheckForDuplicateAnnouncement(t *testing.T) {
 		client, err := NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		isDuplicate, err := client.CheckForDuplicateAnnouncement("#general", "New channel alert! #test-channel", []string{"test-channel"})
+		isDuplicate, err := client.CheckForDuplicateAnnouncement(context.Background(), "#general", "New channel alert! #test-channel", []string{"test-channel"})
 
 		assert.NoError(t, err)
 		assert.False(t, isDuplicate)
@@ -2107,7 +2116,7 @@ func TestCheckForDuplicateAnnouncementWithDetails(t *testing.T) {
 		require.NoError(t, err)
 		cutoffTime := time.Now().Add(-24 * time.Hour)
 
-		isDuplicate, duplicateChannels, err := client.CheckForDuplicateAnnouncementWithDetails("#general", "New channel alert! #channel1 #channel4", []string{"channel1", "channel4"}, cutoffTime)
+		isDuplicate, duplicateChannels, err := client.CheckForDuplicateAnnouncementWithDetails(context.Background(), "#general", "New channel alert! #channel1 #channel4", []string{"channel1", "channel4"}, cutoffTime)
 
 		assert.NoError(t, err)
 		assert.True(t, isDuplicate)
@@ -2127,7 +2136,7 @@ func TestCheckForDuplicateAnnouncementWithDetails(t *testing.T) {
 		require.NoError(t, err)
 		cutoffTime := time.Now().Add(-24 * time.Hour)
 
-		isDuplicate, duplicateChannels, err := client.CheckForDuplicateAnnouncementWithDetails("#general", "New channel alert! #test-channel", []string{"test-channel"}, cutoffTime)
+		isDuplicate, duplicateChannels, err := client.CheckForDuplicateAnnouncementWithDetails(context.Background(), "#general", "New channel alert! #test-channel", []string{"test-channel"}, cutoffTime)
 
 		assert.NoError(t, err)
 		// NOTE: Current implementation does not filter by cutoff time - this is a known limitation
@@ -2159,7 +2168,7 @@ func TestCheckForDuplicateAnnouncementWithDetailsAndChannels(t *testing.T) {
 			},
 		}
 
-		isDuplicate, duplicateChannels, err := client.CheckForDuplicateAnnouncementWithDetailsAndChannels("#general", "New channel alert! #test-channel", []string{"test-channel"}, cutoffTime, allChannels)
+		isDuplicate, duplicateChannels, err := client.CheckForDuplicateAnnouncementWithDetailsAndChannels(context.Background(), "#general", "New channel alert! #test-channel", []string{"test-channel"}, cutoffTime, allChannels)
 
 		assert.NoError(t, err)
 		assert.True(t, isDuplicate)
@@ -2178,7 +2187,7 @@ func TestCheckForDuplicateAnnouncementWithDetailsAndChannels(t *testing.T) {
 		require.NoError(t, err)
 		cutoffTime := time.Now().Add(-24 * time.Hour)
 
-		isDuplicate, duplicateChannels, err := client.CheckForDuplicateAnnouncementWithDetailsAndChannels("#general", "New channel alert! #test-channel", []string{"test-channel"}, cutoffTime, nil)
+		isDuplicate, duplicateChannels, err := client.CheckForDuplicateAnnouncementWithDetailsAndChannels(context.Background(), "#general", "New channel alert! #test-channel", []string{"test-channel"}, cutoffTime, nil)
 
 		assert.NoError(t, err)
 		assert.True(t, isDuplicate)
@@ -2195,7 +2204,7 @@ func TestGetAllChannelNameToIDMap(t *testing.T) {
 		client, err := NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		channelMap, err := client.getAllChannelNameToIDMap()
+		channelMap, err := client.getAllChannelNameToIDMap(context.Background())
 
 		assert.NoError(t, err)
 		assert.Equal(t, "C123", channelMap["general"])
@@ -2210,7 +2219,7 @@ func TestGetAllChannelNameToIDMap(t *testing.T) {
 		client, err := NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		channelMap, err := client.getAllChannelNameToIDMap()
+		channelMap, err := client.getAllChannelNameToIDMap(context.Background())
 
 		assert.Error(t, err)
 		assert.Nil(t, channelMap)
@@ -2221,38 +2230,13 @@ func TestGetAllChannelNameToIDMap(t *testing.T) {
 		client, err := NewClientWithAPI(mockAPI)
 		require.NoError(t, err)
 
-		channelMap, err := client.getAllChannelNameToIDMap()
+		channelMap, err := client.getAllChannelNameToIDMap(context.Background())
 
 		assert.NoError(t, err)
 		assert.Len(t, channelMap, 0)
 	})
 }
 
-func TestIsRateLimitError(t *testing.T) {
-	mockAPI := NewMockSlackAPI()
-	client, err := NewClientWithAPI(mockAPI)
-	require.NoError(t, err)
-
-	tests := []struct {
-		name     string
-		errStr   string
-		expected bool
-	}{
-		{"Rate limited error", "rate_limited", true},
-		{"Rate limit error", "rate limit exceeded", true},
-		{"Generic error", "something else", false},
-		{"Empty error", "", false},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			err := fmt.Errorf("%s", tt.errStr)
-			result := client.isRateLimitError(err)
-			assert.Equal(t, tt.expected, result)
-		})
-	}
-}
-
 func TestFormatNewChannelAnnouncementDryRun(t *testing.T) {
 	t.Run("Single channel with user resolution", func(t *testing.T) {
 		mockAPI := NewMockSlackAPI()
@@ -2274,7 +2258,7 @@ func TestFormatNewChannelAnnouncementDryRun(t *testing.T) {
 		}
 
 		cutoffTime := time.Now().Add(-2 * time.Hour)
-		result := client.FormatNewChannelAnnouncementDryRun(channels, cutoffTime)
+		result := client.FormatNewChannelAnnouncementDryRun(context.Background(), channels, cutoffTime)
 
 		assert.Contains(t, result, "New channel created in the last")
 		assert.Contains(t, result, "#test-channel")
@@ -2311,7 +2295,7 @@ func TestFormatNewChannelAnnouncementDryRun(t *testing.T) {
 		}
 
 		cutoffTime := time.Now().Add(-2 * time.Hour)
-		result := client.FormatNewChannelAnnouncementDryRun(channels, cutoffTime)
+		result := client.FormatNewChannelAnnouncementDryRun(context.Background(), channels, cutoffTime)
 
 		assert.Contains(t, result, "2 new channels created in the last")
 		assert.Contains(t, result, "#test-channel-1")
@@ -2340,7 +2324,7 @@ func TestFormatNewChannelAnnouncementDryRun(t *testing.T) {
 		}
 
 		cutoffTime := time.Now().Add(-2 * time.Hour)
-		result := client.FormatNewChannelAnnouncementDryRun(channels, cutoffTime)
+		result := client.FormatNewChannelAnnouncementDryRun(context.Background(), channels, cutoffTime)
 
 		// Should contain the expected content from fallback format
 		assert.Contains(t, result, "New channel created in the last")
@@ -2409,141 +2393,6 @@ func TestShouldArchiveChannel(t *testing.T) {
 	}
 }
 
-func TestShouldRetryOnRateLimit(t *testing.T) {
-	tests := []struct {
-		name       string
-		errStr     string
-		channel    string
-		attempt    int
-		maxRetries int
-		expected   bool
-	}{
-		{
-			name:       "Should not retry on rate_limited error with invalid duration",
-			errStr:     "rate_limited: retry after invalid",
-			attempt:    1,
-			maxRetries: 3,
-			channel:    "test-channel",
-			expected:   false,
-		},
-		{
-			name:       "Should not retry on rate limit error with no duration",
-			errStr:     "rate limit exceeded",
-			attempt:    2,
-			maxRetries: 3,
-			channel:    "test-channel",
-			expected:   false,
-		},
-		{
-			name:       "Should not retry on max attempts reached",
-			errStr:     "rate_limited: retry after invalid",
-			attempt:    3,
-			maxRetries: 3,
-			channel:    "test-channel",
-			expected:   false,
-		},
-		{
-			name:       "Should not retry on non-rate-limit error",
-			errStr:     "invalid_auth",
-			attempt:    1,
-			maxRetries: 3,
-			channel:    "test-channel",
-			expected:   false,
-		},
-		{
-			name:       "Should not retry on rate limit error without duration",
-			errStr:     "rate_limited",
-			attempt:    1,
-			maxRetries: 3,
-			channel:    "test-channel",
-			expected:   false,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := shouldRetryOnRateLimit(tt.errStr, tt.attempt, tt.maxRetries, tt.channel)
-			assert.Equal(t, tt.expected, result)
-		})
-	}
-}
-
-func TestShouldRetryOnRateLimitSimple(t *testing.T) {
-	tests := []struct {
-		name       string
-		errStr     string
-		attempt    int
-		maxRetries int
-		expected   bool
-	}{
-		{
-			name:       "Should retry on rate_limited error without duration",
-			errStr:     "rate_limited",
-			attempt:    1,
-			maxRetries: 3,
-			expected:   true,
-		},
-		{
-			name:       "Should retry on rate limit error without duration",
-			errStr:     "rate limit exceeded",
-			attempt:    2,
-			maxRetries: 3,
-			expected:   true,
-		},
-		{
-			name:       "Should not retry on max attempts reached",
-			errStr:     "rate_limited: retry after invalid",
-			attempt:    3,
-			maxRetries: 3,
-			expected:   false,
-		},
-		{
-			name:       "Should not retry on non-rate-limit error",
-			errStr:     "invalid_auth",
-			attempt:    1,
-			maxRetries: 3,
-			expected:   false,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := shouldRetryOnRateLimitSimple(tt.errStr, tt.attempt, tt.maxRetries)
-			assert.Equal(t, tt.expected, result)
-		})
-	}
-}
-
-func TestHandleRateLimit(t *testing.T) {
-	t.Run("Handles rate limit with retry after duration", func(t *testing.T) {
-		mockAPI := NewMockSlackAPI()
-		client, err := NewClientWithAPI(mockAPI)
-		require.NoError(t, err)
-
-		// Test with a rate limit error that contains retry-after but parsing fails
-		err = fmt.Errorf("rate_limited: retry after invalid")
-
-		// This should not panic and should handle the error gracefully
-		assert.NotPanics(t, func() {
-			client.handleRateLimit(err, 1, 3)
-		})
-	})
-
-	t.Run("Handles rate limit without duration", func(t *testing.T) {
-		mockAPI := NewMockSlackAPI()
-		client, err := NewClientWithAPI(mockAPI)
-		require.NoError(t, err)
-
-		// Test with a rate limit error without duration
-		err = fmt.Errorf("rate_limited")
-
-		// This should not panic and should handle the error gracefully
-		assert.NotPanics(t, func() {
-			client.handleRateLimit(err, 1, 3)
-		})
-	})
-}
-
 func TestGetNewChannelsWithAllChannels(t *testing.T) {
 	t.Run("Success with new channels", func(t *testing.T) {
 		mockAPI := NewMockSlackAPI()
@@ -2558,7 +2407,7 @@ func TestGetNewChannelsWithAllChannels(t *testing.T) {
 		mockAPI.AddChannelWithCreator("new-channel", "New Channel", newTime, "New Purpose", "U456")
 
 		since := time.Now().Add(-24 * time.Hour)
-		newChannels, allChannels, err := client.GetNewChannelsWithAllChannels(since)
+		newChannels, allChannels, err := client.GetNewChannelsWithAllChannels(context.Background(), since)
 
 		assert.NoError(t, err)
 		assert.Len(t, newChannels, 1)
@@ -2576,7 +2425,7 @@ func TestGetNewChannelsWithAllChannels(t *testing.T) {
 		mockAPI.AddChannelWithCreator("old-channel", "Old Channel", oldTime, "Old Purpose", "U123")
 
 		since := time.Now().Add(-24 * time.Hour)
-		newChannels, allChannels, err := client.GetNewChannelsWithAllChannels(since)
+		newChannels, allChannels, err := client.GetNewChannelsWithAllChannels(context.Background(), since)
 
 		assert.NoError(t, err)
 		assert.Len(t, newChannels, 0)
@@ -2592,7 +2441,7 @@ func TestGetNewChannelsWithAllChannels(t *testing.T) {
 		mockAPI.SetGetConversationsError(true)
 
 		since := time.Now().Add(-24 * time.Hour)
-		newChannels, allChannels, err := client.GetNewChannelsWithAllChannels(since)
+		newChannels, allChannels, err := client.GetNewChannelsWithAllChannels(context.Background(), since)
 
 		assert.Error(t, err)
 		assert.Nil(t, newChannels)
@@ -2870,49 +2719,6 @@ func TestParseSlackTimestamp(t *testing.T) {
 	})
 }
 
-// TestParseSlackRetryAfterEdgeCases tests additional edge cases in retry-after parsing.
-func TestParseSlackRetryAfterEdgeCases(t *testing.T) {
-	t.Run("Valid Go duration format", func(t *testing.T) {
-		// The function expects Go duration format like "30s", not "30 seconds"
-		testCases := []struct {
-			errorString string
-			expected    time.Duration
-		}{
-			{"rate_limited, retry after 30s", 31 * time.Second},          // 30s + 1s buffer
-			{"rate_limited, retry after 1m", 61 * time.Second},           // 1m + 1s buffer
-			{"rate_limited, retry after 500ms", 1500 * time.Millisecond}, // 500ms + 1s buffer
-		}
-
-		for _, tc := range testCases {
-			result := parseSlackRetryAfter(tc.errorString)
-			assert.Equal(t, tc.expected, result, "Should parse: %s", tc.errorString)
-		}
-	})
-
-	t.Run("Invalid duration formats return zero", func(t *testing.T) {
-		testCases := []string{
-			"rate_limited, retry after 30 seconds", // not Go format
-			"retry after 30seconds",                // not Go format
-			"retry after 30 second",                // not Go format
-			"retry after abc",                      // invalid
-			"retry after",                          // missing duration
-			"no retry directive",                   // no directive
-		}
-
-		for _, errorString := range testCases {
-			result := parseSlackRetryAfter(errorString)
-			assert.Equal(t, time.Duration(0), result, "Should return 0 for invalid format: %s", errorString)
-		}
-	})
-
-	t.Run("Multiple retry-after uses first", func(t *testing.T) {
-		// Should use the first valid one found - function splits on space so "45s," is parsed
-		errorString := "rate_limited, retry after 45s also retry after 60s"
-		result := parseSlackRetryAfter(errorString)
-		assert.Equal(t, 46*time.Second, result, "Should use first retry-after directive with buffer")
-	})
-}
-
 // TestSeemsActiveFromMetadataEdgeCases tests additional edge cases in metadata-based activity detection.
 func TestSeemsActiveFromMetadataEdgeCases(t *testing.T) {
 	mockAPI := NewMockSlackAPI()
@@ -2996,7 +2802,7 @@ func TestGetRandomChannels(t *testing.T) {
 		mockAPI.AddChannel("C1234567891", "channel2", now, "Channel 2")
 
 		// Request 5 channels (more than available)
-		channels, err := client.GetRandomChannels(5)
+		channels, err := client.GetRandomChannels(context.Background(), 5)
 		assert.NoError(t, err)
 		assert.Len(t, channels, 2)
 
@@ -3021,7 +2827,7 @@ func TestGetRandomChannels(t *testing.T) {
 		}
 
 		// Request 3 channels
-		channels, err := client.GetRandomChannels(3)
+		channels, err := client.GetRandomChannels(context.Background(), 3)
 		assert.NoError(t, err)
 		assert.Len(t, channels, 3)
 
@@ -3038,7 +2844,7 @@ func TestGetRandomChannels(t *testing.T) {
 		require.NoError(t, err)
 
 		// Don't add any channels
-		channels, err := client.GetRandomChannels(3)
+		channels, err := client.GetRandomChannels(context.Background(), 3)
 		assert.NoError(t, err)
 		assert.Len(t, channels, 0)
 	})
@@ -3051,7 +2857,7 @@ func TestGetRandomChannels(t *testing.T) {
 		// Set up API to return error
 		mockAPI.SetGetConversationsErrorWithMessage(true, "rate_limited")
 
-		channels, err := client.GetRandomChannels(3)
+		channels, err := client.GetRandomChannels(context.Background(), 3)
 		assert.Error(t, err)
 		assert.Nil(t, channels)
 		assert.Contains(t, err.Error(), "failed to get conversations")
@@ -3203,7 +3009,7 @@ func TestGetDefaultChannels(t *testing.T) {
 		// Note: Mock returns all channels for all users by default
 		// With 100% threshold (1.0) and 3 users, all 3 channels meet the requirement
 
-		defaultChannels, err := client.GetDefaultChannels(10, 1.0) // 100% threshold
+		defaultChannels, err := client.GetDefaultChannels(context.Background(), 10, 1.0) // 100% threshold
 		assert.NoError(t, err)
 		assert.Len(t, defaultChannels, 3) // All channels returned by mock
 		assert.Contains(t, defaultChannels, "general")
@@ -3227,7 +3033,7 @@ func TestGetDefaultChannels(t *testing.T) {
 		mockAPI.AddChannel("C003", "optional", time.Now().Add(-10*24*time.Hour), "Optional channel")
 
 		// With 90% threshold and 10 users, need at least 9 users in a channel
-		defaultChannels, err := client.GetDefaultChannels(10, 0.9)
+		defaultChannels, err := client.GetDefaultChannels(context.Background(), 10, 0.9)
 		assert.NoError(t, err)
 		// All channels returned by mock since it returns all channels for all users
 		assert.GreaterOrEqual(t, len(defaultChannels), 2)
@@ -3247,7 +3053,7 @@ func TestGetDefaultChannels(t *testing.T) {
 		mockAPI.AddChannel("C002", "channel2", time.Now().Add(-30*24*time.Hour), "Channel 2")
 
 		// With very high threshold (requiring more members than exist)
-		defaultChannels, err := client.GetDefaultChannels(10, 0.99)
+		defaultChannels, err := client.GetDefaultChannels(context.Background(), 10, 0.99)
 		assert.NoError(t, err)
 		// May or may not find channels depending on mock behavior
 		assert.NotNil(t, defaultChannels)
@@ -3261,7 +3067,7 @@ func TestGetDefaultChannels(t *testing.T) {
 		// Add only 1 user (insufficient for detection)
 		mockAPI.AddUser("U001", "user1", "User One")
 
-		defaultChannels, err := client.GetDefaultChannels(10, 0.9)
+		defaultChannels, err := client.GetDefaultChannels(context.Background(), 10, 0.9)
 		assert.NoError(t, err)
 		assert.Empty(t, defaultChannels)
 	})
@@ -3293,7 +3099,7 @@ func TestGetDefaultChannels(t *testing.T) {
 
 		mockAPI.AddChannel("C001", "general", time.Now().Add(-30*24*time.Hour), "General")
 
-		defaultChannels, err := client.GetDefaultChannels(10, 0.9)
+		defaultChannels, err := client.GetDefaultChannels(context.Background(), 10, 0.9)
 		assert.NoError(t, err)
 		// Should work with only real users
 		assert.NotNil(t, defaultChannels)
@@ -3307,7 +3113,7 @@ func TestGetDefaultChannels(t *testing.T) {
 		// Set up error for GetUsers
 		mockAPI.SetGetUsersError("API error")
 
-		defaultChannels, err := client.GetDefaultChannels(10, 0.9)
+		defaultChannels, err := client.GetDefaultChannels(context.Background(), 10, 0.9)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to get users")
 		assert.Nil(t, defaultChannels)
@@ -3326,7 +3132,7 @@ func TestGetDefaultChannels(t *testing.T) {
 		mockAPI.AddChannel("C001", "general", time.Now().Add(-30*24*time.Hour), "General")
 
 		// Request sample size larger than available users
-		defaultChannels, err := client.GetDefaultChannels(10, 0.8)
+		defaultChannels, err := client.GetDefaultChannels(context.Background(), 10, 0.8)
 		assert.NoError(t, err)
 		// Should adapt to available users
 		assert.NotNil(t, defaultChannels)
@@ -3407,7 +3213,7 @@ func TestGetDefaultChannelsWithUsers(t *testing.T) {
 		mockAPI.AddChannel("C001", "general", time.Now().Add(-30*24*time.Hour), "General discussion")
 		mockAPI.AddChannel("C002", "random", time.Now().Add(-30*24*time.Hour), "Random chat")
 
-		result, err := client.GetDefaultChannelsWithUsers(10, 1.0)
+		result, err := client.GetDefaultChannelsWithUsers(context.Background(), 10, 1.0)
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		assert.Len(t, result.DefaultChannels, 2)
@@ -3425,7 +3231,7 @@ func TestGetDefaultChannelsWithUsers(t *testing.T) {
 		require.NoError(t, err)
 
 		// No users added
-		result, err := client.GetDefaultChannelsWithUsers(10, 0.9)
+		result, err := client.GetDefaultChannelsWithUsers(context.Background(), 10, 0.9)
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		assert.Empty(t, result.DefaultChannels)
@@ -3439,7 +3245,7 @@ func TestGetDefaultChannelsWithUsers(t *testing.T) {
 
 		mockAPI.SetGetUsersError("API error")
 
-		result, err := client.GetDefaultChannelsWithUsers(10, 0.9)
+		result, err := client.GetDefaultChannelsWithUsers(context.Background(), 10, 0.9)
 		assert.Error(t, err)
 		assert.Nil(t, result)
 	})
@@ -3460,7 +3266,7 @@ func TestGetDefaultChannelsWithUsers(t *testing.T) {
 
 		mockAPI.AddChannel("C001", "general", time.Now().Add(-30*24*time.Hour), "General")
 
-		result, err := client.GetDefaultChannelsWithUsers(10, 1.0)
+		result, err := client.GetDefaultChannelsWithUsers(context.Background(), 10, 1.0)
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		assert.Len(t, result.SampledUsers, 2)
@@ -3471,34 +3277,3 @@ func TestGetDefaultChannelsWithUsers(t *testing.T) {
 		assert.Equal(t, "", result.SampledUsers[0].RealName)
 	})
 }
-
-func TestShouldRetryRateLimit(t *testing.T) {
-	t.Run("Should retry on rate limit with attempts remaining", func(t *testing.T) {
-		err := fmt.Errorf("rate_limited")
-		result := shouldRetryRateLimit(err, 1, 3)
-		assert.True(t, result)
-	})
-
-	t.Run("Should retry on rate limit error with attempts remaining", func(t *testing.T) {
-		err := fmt.Errorf("error: rate limit exceeded")
-		result := shouldRetryRateLimit(err, 2, 3)
-		assert.True(t, result)
-	})
-
-	t.Run("Should not retry on last attempt", func(t *testing.T) {
-		err := fmt.Errorf("rate_limited")
-		result := shouldRetryRateLimit(err, 3, 3)
-		assert.False(t, result)
-	})
-
-	t.Run("Should not retry on non-rate-limit error", func(t *testing.T) {
-		err := fmt.Errorf("some other error")
-		result := shouldRetryRateLimit(err, 1, 3)
-		assert.False(t, result)
-	})
-
-	t.Run("Should not retry when no error", func(t *testing.T) {
-		result := shouldRetryRateLimit(nil, 1, 3)
-		assert.False(t, result)
-	})
-}
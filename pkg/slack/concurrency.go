@@ -0,0 +1,61 @@
+package slack
+
+import "sync"
+
+// DefaultConcurrency is the number of channels processed at once unless
+// SetConcurrency says otherwise.
+const DefaultConcurrency = 1
+
+// runOrdered calls work(i) for every i in [0, n) on up to workers goroutines,
+// and calls emit(i) on the calling goroutine in index order as soon as item i
+// and every item before it are done, so output stays deterministic however
+// the work interleaves. When emit returns false no further work is started;
+// runOrdered returns once in-flight work has finished.
+//
+// work runs concurrently with other work calls and must only write state
+// owned by its index; emit runs sequentially and may touch shared state.
+func runOrdered(workers, n int, work func(i int), emit func(i int) bool) {
+	if n <= 0 {
+		return
+	}
+	workers = max(1, min(workers, n))
+
+	done := make([]chan struct{}, n)
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+	next := make(chan int)
+	stop := make(chan struct{})
+
+	go func() {
+		defer close(next)
+		for i := range n {
+			select {
+			case next <- i:
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				work(i)
+				close(done[i])
+			}
+		}()
+	}
+
+	for i := range n {
+		<-done[i]
+		if !emit(i) {
+			break
+		}
+	}
+	close(stop)
+	wg.Wait()
+}
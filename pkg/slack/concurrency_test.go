@@ -0,0 +1,113 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunOrdered(t *testing.T) {
+	t.Run("Emits in index order regardless of completion order", func(t *testing.T) {
+		results := make([]int, 10)
+		var emitted []int
+		runOrdered(4, len(results), func(i int) {
+			// Later items finish first
+			time.Sleep(time.Duration(len(results)-i) * time.Millisecond)
+			results[i] = i * i
+		}, func(i int) bool {
+			emitted = append(emitted, results[i])
+			return true
+		})
+		assert.Equal(t, []int{0, 1, 4, 9, 16, 25, 36, 49, 64, 81}, emitted)
+	})
+
+	t.Run("Never runs more than workers at once", func(t *testing.T) {
+		var running, peak atomic.Int32
+		runOrdered(3, 20, func(int) {
+			now := running.Add(1)
+			for {
+				old := peak.Load()
+				if now <= old || peak.CompareAndSwap(old, now) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			running.Add(-1)
+		}, func(int) bool { return true })
+		assert.LessOrEqual(t, peak.Load(), int32(3))
+		assert.Greater(t, peak.Load(), int32(1))
+	})
+
+	t.Run("Stopping early starts no further work", func(t *testing.T) {
+		var started atomic.Int32
+		var emitted []int
+		runOrdered(2, 100, func(int) {
+			started.Add(1)
+			time.Sleep(time.Millisecond)
+		}, func(i int) bool {
+			emitted = append(emitted, i)
+			return i < 4
+		})
+		assert.Equal(t, []int{0, 1, 2, 3, 4}, emitted)
+		assert.Less(t, started.Load(), int32(100))
+	})
+
+	t.Run("Workers below one run sequentially", func(t *testing.T) {
+		var order []int
+		runOrdered(0, 3, func(i int) { order = append(order, i) }, func(int) bool { return true })
+		assert.Equal(t, []int{0, 1, 2}, order)
+	})
+
+	t.Run("No items", func(t *testing.T) {
+		runOrdered(4, 0, func(int) { t.Fatal("unexpected work") }, func(int) bool { return true })
+	})
+}
+
+func TestConcurrentInactivityAnalysis(t *testing.T) {
+	newClient := func(concurrency int) (*Client, *MockSlackAPI) {
+		mockAPI := NewMockSlackAPI()
+		old := time.Now().Add(-time.Hour)
+		for i := range 12 {
+			id := fmt.Sprintf("C%03d", i)
+			mockAPI.AddChannel(id, fmt.Sprintf("stale-%02d", i), old.Add(-time.Hour), "")
+			mockAPI.SetChannelHistory(id, []MockHistoryMessage{
+				{Timestamp: fmt.Sprintf("%d.000000", old.Unix()), User: "U123", Text: "Old message"},
+			})
+		}
+		client, err := NewClientWithAPI(mockAPI)
+		require.NoError(t, err)
+		client.SetConcurrency(concurrency)
+		return client, mockAPI
+	}
+
+	sequential, _ := newClient(1)
+	wantWarn, wantArchive, _, err := sequential.GetInactiveChannelsWithDetailsAndExclusions(context.Background(), 600, 300, map[string]string{}, nil, nil, false, false, 0)
+	require.NoError(t, err)
+	require.Len(t, wantWarn, 12)
+
+	concurrent, mockAPI := newClient(5)
+	assert.Equal(t, 5, concurrent.Concurrency())
+	gotWarn, gotArchive, _, err := concurrent.GetInactiveChannelsWithDetailsAndExclusions(context.Background(), 600, 300, map[string]string{}, nil, nil, false, false, 0)
+	require.NoError(t, err)
+	assert.Len(t, mockAPI.GetJoinedChannels(), 12)
+
+	names := func(channels []Channel) []string {
+		result := make([]string, 0, len(channels))
+		for _, ch := range channels {
+			result = append(result, ch.Name)
+		}
+		return result
+	}
+	assert.Equal(t, names(wantWarn), names(gotWarn))
+	assert.Equal(t, names(wantArchive), names(gotArchive))
+
+	t.Run("Default concurrency", func(t *testing.T) {
+		concurrent.SetConcurrency(0)
+		assert.Equal(t, DefaultConcurrency, concurrent.Concurrency())
+	})
+}
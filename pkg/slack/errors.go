@@ -0,0 +1,163 @@
+package slack
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// Sentinel errors for the Slack API failures callers commonly need to tell
+// apart. Match them with errors.Is; MissingScopeError, RateLimitError and
+// APIError carry the details for errors.As.
+var (
+	ErrMissingScope    = errors.New("missing_scope")
+	ErrRateLimited     = errors.New("rate_limited")
+	ErrChannelNotFound = errors.New("channel_not_found")
+	ErrNotInChannel    = errors.New("not_in_channel")
+	ErrChannelArchived = errors.New("channel is archived")
+	ErrNotArchived     = errors.New("channel is not archived")
+	ErrInvalidAuth     = errors.New("invalid_auth")
+)
+
+// slackErrorCodes maps Slack Web API error codes to the sentinel they match.
+var slackErrorCodes = map[string]error{
+	"missing_scope":     ErrMissingScope,
+	"rate_limited":      ErrRateLimited,
+	"ratelimited":       ErrRateLimited,
+	"channel_not_found": ErrChannelNotFound,
+	"not_in_channel":    ErrNotInChannel,
+	"is_archived":       ErrChannelArchived,
+	"already_archived":  ErrChannelArchived,
+	"not_archived":      ErrNotArchived,
+	"invalid_auth":      ErrInvalidAuth,
+	"not_authed":        ErrInvalidAuth,
+	"token_revoked":     ErrInvalidAuth,
+	"token_expired":     ErrInvalidAuth,
+	"account_inactive":  ErrInvalidAuth,
+}
+
+// APIError is an error response ("ok": false) from the Slack Web API.
+type APIError struct {
+	Err  error
+	Code string // Slack error code, e.g. "channel_not_found"
+}
+
+func (e *APIError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the error's Slack code corresponds to target.
+func (e *APIError) Is(target error) bool {
+	sentinel, ok := slackErrorCodes[e.Code]
+	return ok && sentinel == target
+}
+
+// MissingScopeError reports a call rejected because the token lacks an OAuth
+// scope. Scope names the scope the failed operation needs, when known.
+type MissingScopeError struct {
+	Err   error
+	Scope string
+}
+
+func (e *MissingScopeError) Error() string {
+	if e.Scope == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("missing required OAuth scope '%s'", e.Scope)
+}
+
+func (e *MissingScopeError) Unwrap() error {
+	return e.Err
+}
+
+// Is matches ErrMissingScope.
+func (e *MissingScopeError) Is(target error) bool {
+	return target == ErrMissingScope
+}
+
+// RateLimitError reports a call Slack rejected with HTTP 429, after any
+// retries have been exhausted. RetryAfter is Slack's requested delay.
+type RateLimitError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limited by Slack API, retry after %s", e.RetryAfter)
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return e.Err
+}
+
+// Is matches ErrRateLimited.
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// classifyError converts errors from slack-go into the typed errors above.
+// Errors that are already classified, and non-Slack errors such as network
+// failures or context cancellation, are returned unchanged.
+func classifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var (
+		apiErr      *APIError
+		scopeErr    *MissingScopeError
+		rateErr     *RateLimitError
+		rateLimited *slack.RateLimitedError
+		response    slack.SlackErrorResponse
+	)
+	switch {
+	case errors.As(err, &apiErr), errors.As(err, &scopeErr), errors.As(err, &rateErr):
+		return err
+	case errors.As(err, &rateLimited):
+		return &RateLimitError{RetryAfter: rateLimited.RetryAfter, Err: err}
+	case errors.As(err, &response):
+		if response.Err == "missing_scope" {
+			return &MissingScopeError{Err: err}
+		}
+		return &APIError{Code: response.Err, Err: err}
+	}
+	return err
+}
+
+// withScope records which OAuth scope a failed operation needed.
+func withScope(err error, scope string) error {
+	return &MissingScopeError{Scope: scope, Err: err}
+}
+
+// describedError replaces an error's message with user-facing guidance
+// while keeping the original error available to errors.Is and errors.As.
+type describedError struct {
+	err error
+	msg string
+}
+
+func (e *describedError) Error() string {
+	return e.msg
+}
+
+func (e *describedError) Unwrap() error {
+	return e.err
+}
+
+// describe wraps err with a formatted user-facing message.
+func describe(err error, format string, args ...any) error {
+	return &describedError{err: err, msg: fmt.Sprintf(format, args...)}
+}
+
+// hasErrorCode reports whether err is a Slack error response with the given
+// code, for codes that have no sentinel of their own.
+func hasErrorCode(err error, code string) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Code == code
+}
@@ -0,0 +1,156 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyError(t *testing.T) {
+	t.Run("Nil stays nil", func(t *testing.T) {
+		assert.NoError(t, classifyError(nil))
+	})
+
+	t.Run("Slack error codes match their sentinels", func(t *testing.T) {
+		tests := []struct {
+			sentinel error
+			code     string
+		}{
+			{code: "channel_not_found", sentinel: ErrChannelNotFound},
+			{code: "not_in_channel", sentinel: ErrNotInChannel},
+			{code: "is_archived", sentinel: ErrChannelArchived},
+			{code: "already_archived", sentinel: ErrChannelArchived},
+			{code: "not_archived", sentinel: ErrNotArchived},
+			{code: "invalid_auth", sentinel: ErrInvalidAuth},
+			{code: "token_revoked", sentinel: ErrInvalidAuth},
+			{code: "ratelimited", sentinel: ErrRateLimited},
+		}
+		for _, tt := range tests {
+			err := classifyError(slack.SlackErrorResponse{Err: tt.code})
+
+			assert.ErrorIs(t, err, tt.sentinel, tt.code)
+			assert.Equal(t, tt.code, err.Error())
+			var apiErr *APIError
+			require.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, tt.code, apiErr.Code)
+		}
+	})
+
+	t.Run("Unknown codes are APIErrors without a sentinel", func(t *testing.T) {
+		err := classifyError(slack.SlackErrorResponse{Err: "invite_only"})
+
+		assert.True(t, hasErrorCode(err, "invite_only"))
+		assert.NotErrorIs(t, err, ErrChannelNotFound)
+		assert.NotErrorIs(t, err, ErrMissingScope)
+	})
+
+	t.Run("Missing scope becomes MissingScopeError", func(t *testing.T) {
+		err := classifyError(slack.SlackErrorResponse{Err: "missing_scope"})
+
+		assert.ErrorIs(t, err, ErrMissingScope)
+		var scopeErr *MissingScopeError
+		require.ErrorAs(t, err, &scopeErr)
+		assert.Empty(t, scopeErr.Scope)
+		assert.Equal(t, "missing_scope", err.Error())
+	})
+
+	t.Run("HTTP 429 becomes RateLimitError with RetryAfter", func(t *testing.T) {
+		err := classifyError(&slack.RateLimitedError{RetryAfter: 30 * time.Second})
+
+		assert.ErrorIs(t, err, ErrRateLimited)
+		var rateErr *RateLimitError
+		require.ErrorAs(t, err, &rateErr)
+		assert.Equal(t, 30*time.Second, rateErr.RetryAfter)
+		// The original slack-go error stays reachable for RateLimitedAPI
+		var rateLimited *slack.RateLimitedError
+		assert.ErrorAs(t, err, &rateLimited)
+	})
+
+	t.Run("Other errors are unchanged", func(t *testing.T) {
+		networkErr := errors.New("connection reset")
+		assert.Same(t, networkErr, classifyError(networkErr))
+		assert.Equal(t, context.Canceled, classifyError(context.Canceled))
+	})
+
+	t.Run("Classified errors are not wrapped twice", func(t *testing.T) {
+		err := classifyError(slack.SlackErrorResponse{Err: "channel_not_found"})
+		wrapped := fmt.Errorf("archiving: %w", err)
+		assert.Same(t, wrapped, classifyError(wrapped))
+	})
+}
+
+func TestTypedErrorWrappers(t *testing.T) {
+	t.Run("withScope names the scope", func(t *testing.T) {
+		err := withScope(classifyError(slack.SlackErrorResponse{Err: "missing_scope"}), "chat:write")
+
+		assert.Equal(t, "missing required OAuth scope 'chat:write'", err.Error())
+		var scopeErr *MissingScopeError
+		require.ErrorAs(t, err, &scopeErr)
+		assert.Equal(t, "chat:write", scopeErr.Scope)
+	})
+
+	t.Run("describe keeps the cause matchable", func(t *testing.T) {
+		cause := classifyError(slack.SlackErrorResponse{Err: "channel_not_found"})
+		err := describe(cause, "channel '%s' not found", "general")
+
+		assert.Equal(t, "channel 'general' not found", err.Error())
+		assert.ErrorIs(t, err, ErrChannelNotFound)
+	})
+}
+
+func TestClientReturnsTypedErrors(t *testing.T) {
+	t.Run("PostMessage missing scope carries chat:write", func(t *testing.T) {
+		mockAPI := NewMockSlackAPI()
+		mockAPI.AddChannel("C1234567", "general", time.Now(), "General")
+		mockAPI.SetPostMessageError("missing_scope")
+		client, err := NewClientWithAPI(mockAPI)
+		require.NoError(t, err)
+
+		err = client.PostMessage(context.Background(), "#general", "hello")
+
+		var scopeErr *MissingScopeError
+		require.ErrorAs(t, err, &scopeErr)
+		assert.Equal(t, "chat:write", scopeErr.Scope)
+		assert.Contains(t, err.Error(), "chat:write")
+	})
+
+	t.Run("Archive of private channel without groups:write", func(t *testing.T) {
+		mockAPI := NewMockSlackAPI()
+		mockAPI.SetArchiveConversationError(missingScope)
+		client, err := NewClientWithAPI(mockAPI)
+		require.NoError(t, err)
+
+		err = client.ArchiveChannelWithThresholds(context.Background(), Channel{ID: "G1", Name: "secret", IsPrivate: true}, 60, 120)
+
+		var scopeErr *MissingScopeError
+		require.ErrorAs(t, err, &scopeErr)
+		assert.Equal(t, "groups:write", scopeErr.Scope)
+	})
+
+	t.Run("Already archived channel is not an error", func(t *testing.T) {
+		mockAPI := NewMockSlackAPI()
+		mockAPI.SetArchiveConversationError("already_archived")
+		client, err := NewClientWithAPI(mockAPI)
+		require.NoError(t, err)
+
+		assert.NoError(t, client.ArchiveChannelWithThresholds(context.Background(), Channel{ID: "C1", Name: "old"}, 60, 120))
+	})
+
+	t.Run("GetNewChannels invalid auth", func(t *testing.T) {
+		mockAPI := NewMockSlackAPI()
+		mockAPI.SetGetConversationsErrorWithMessage(true, "invalid_auth")
+		client, err := NewClientWithAPI(mockAPI)
+		require.NoError(t, err)
+
+		_, err = client.GetNewChannels(context.Background(), time.Now().Add(-time.Hour))
+
+		assert.ErrorIs(t, err, ErrInvalidAuth)
+		assert.Equal(t, "invalid token. Please check your SLACK_TOKEN", err.Error())
+	})
+}
@@ -0,0 +1,67 @@
+package slack
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Exclusion excludes channels whose names match Pattern from archival. The
+// pattern is an exact channel name, a glob or a /regex/, as for
+// ProtectedNames. An exclusion with an Expires date stops applying once
+// that day is over, so temporary exclusions don't live forever.
+type Exclusion struct {
+	// Expires is the last day the exclusion applies; zero never expires.
+	Expires time.Time
+	Pattern string
+	// Reason documents why the channel is excluded.
+	Reason string
+
+	pattern namePattern
+}
+
+// NewExclusion compiles pattern into an exclusion.
+func NewExclusion(pattern, reason string, expires time.Time) (Exclusion, error) {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return Exclusion{}, errors.New("empty exclusion pattern")
+	}
+	compiled, err := compileNamePattern(pattern)
+	if err != nil {
+		return Exclusion{}, fmt.Errorf("invalid exclusion pattern: %w", err)
+	}
+	return Exclusion{Pattern: pattern, Reason: reason, Expires: expires, pattern: compiled}, nil
+}
+
+// Expired reports whether the exclusion's last day is before now.
+func (e Exclusion) Expired(now time.Time) bool {
+	if e.Expires.IsZero() {
+		return false
+	}
+	return !now.Before(e.Expires.AddDate(0, 0, 1))
+}
+
+// SetExclusions sets the pattern exclusions applied during inactivity
+// analysis, in addition to the excluded channel names and prefixes.
+func (c *Client) SetExclusions(exclusions []Exclusion) {
+	c.exclusions = exclusions
+}
+
+// Exclusions returns the configured pattern exclusions, including expired
+// ones.
+func (c *Client) Exclusions() []Exclusion {
+	return c.exclusions
+}
+
+// matchExclusion returns the first unexpired exclusion matching channelName.
+func (c *Client) matchExclusion(channelName string, now time.Time) (*Exclusion, bool) {
+	lowerName := strings.ToLower(channelName)
+	for i := range c.exclusions {
+		exclusion := &c.exclusions[i]
+		if !exclusion.Expired(now) && exclusion.pattern.matches(lowerName) {
+			return exclusion, true
+		}
+	}
+	return nil, false
+}
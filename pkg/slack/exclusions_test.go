@@ -0,0 +1,66 @@
+package slack
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewExclusion(t *testing.T) {
+	_, err := NewExclusion("tmp-[", "", time.Time{})
+	assert.ErrorContains(t, err, "invalid exclusion pattern: glob")
+
+	_, err = NewExclusion("/inc-(/", "", time.Time{})
+	assert.ErrorContains(t, err, "invalid exclusion pattern: regex")
+
+	_, err = NewExclusion("  ", "", time.Time{})
+	assert.ErrorContains(t, err, "empty exclusion pattern")
+}
+
+func TestExclusionExpired(t *testing.T) {
+	expires := time.Date(2026, 3, 31, 0, 0, 0, 0, time.Local)
+	exclusion, err := NewExclusion("offsite-*", "event planning", expires)
+	require.NoError(t, err)
+
+	assert.False(t, exclusion.Expired(expires.Add(23*time.Hour)), "applies through its last day")
+	assert.True(t, exclusion.Expired(expires.AddDate(0, 0, 1)))
+
+	forever, err := NewExclusion("legal-hold", "", time.Time{})
+	require.NoError(t, err)
+	assert.False(t, forever.Expired(time.Now().AddDate(100, 0, 0)))
+}
+
+func TestShouldSkipChannelWithPatternExclusions(t *testing.T) {
+	client, err := NewClientWithAPI(NewMockSlackAPI())
+	require.NoError(t, err)
+
+	newExclusion := func(pattern string, expires time.Time) Exclusion {
+		exclusion, err := NewExclusion(pattern, "", expires)
+		require.NoError(t, err)
+		return exclusion
+	}
+	client.SetExclusions([]Exclusion{
+		newExclusion("tmp-*", time.Time{}),
+		newExclusion(`/^inc-\d+$/`, time.Time{}),
+		newExclusion("old-*", time.Now().AddDate(0, 0, -2)),
+	})
+
+	tests := []struct {
+		channelName string
+		expected    bool
+	}{
+		{"tmp-spike", true},
+		{"TMP-Upper", true},
+		{"inc-42", true},
+		{"inc-outage", false},
+		{"old-project", false},
+		{"general-chat", false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.channelName, func(t *testing.T) {
+			assert.Equal(t, tc.expected, client.shouldSkipChannelWithExclusions(tc.channelName, nil, nil))
+		})
+	}
+}
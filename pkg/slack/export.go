@@ -0,0 +1,275 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/astrostl/slack-butler/pkg/logger"
+
+	"github.com/slack-go/slack"
+)
+
+// mentionPattern matches user mentions in message text, e.g. <@U012AB3CD>
+// or <@U012AB3CD|alice>.
+var mentionPattern = regexp.MustCompile(`<@([UW][A-Z0-9]+)(?:\|[^>]*)?>`)
+
+// Transcript is the full history of a channel as exported before archiving
+// it, oldest message first.
+type Transcript struct {
+	ExportedAt time.Time           `json:"exported_at"`
+	Created    time.Time           `json:"created"`
+	ChannelID  string              `json:"channel_id"`
+	Channel    string              `json:"channel"`
+	Purpose    string              `json:"purpose,omitempty"`
+	IsPrivate  bool                `json:"is_private,omitempty"`
+	Messages   []TranscriptMessage `json:"messages"`
+}
+
+// TranscriptMessage is one message of a Transcript. Text is kept as Slack
+// returned it; User is the author's name resolved from UserID.
+type TranscriptMessage struct {
+	Time      time.Time           `json:"time"`
+	Timestamp string              `json:"ts"`
+	UserID    string              `json:"user_id,omitempty"`
+	User      string              `json:"user,omitempty"`
+	SubType   string              `json:"subtype,omitempty"`
+	Text      string              `json:"text"`
+	Files     []string            `json:"files,omitempty"`
+	Replies   []TranscriptMessage `json:"replies,omitempty"`
+}
+
+// SetExportDir makes ArchiveChannelWithThresholds write a JSON and a
+// Markdown transcript of each channel's full history, thread replies
+// included, to dir before archiving it. A channel whose history can't be
+// exported isn't archived. Empty input disables exports.
+func (c *Client) SetExportDir(dir string) {
+	c.exportDir = strings.TrimSpace(dir)
+}
+
+// ExportDir returns the directory transcripts are written to before
+// archiving, or "" when exports are disabled.
+func (c *Client) ExportDir() string {
+	return c.exportDir
+}
+
+// ExportChannelHistory writes the full history of channel to dir as
+// <name>-<id>-<time>.json and .md files, readable by the owner only, and
+// returns their paths.
+func (c *Client) ExportChannelHistory(ctx context.Context, channel Channel, dir string) ([]string, error) {
+	userMap, err := c.transcriptUserMap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	transcript, err := c.channelTranscript(ctx, channel, userMap)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Clean(dir), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+	base := filepath.Join(filepath.Clean(dir), fmt.Sprintf("%s-%s-%s", filepath.Base(channel.Name), channel.ID, transcript.ExportedAt.Format("20060102T150405Z")))
+
+	data, err := json.MarshalIndent(transcript, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode transcript: %w", err)
+	}
+	paths := []string{base + ".json", base + ".md"}
+	if err := os.WriteFile(paths[0], append(data, '\n'), 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write transcript: %w", err)
+	}
+	if err := os.WriteFile(paths[1], []byte(FormatTranscriptMarkdown(transcript, userMap)), 0o600); err != nil {
+		return nil, fmt.Errorf("failed to write transcript: %w", err)
+	}
+
+	logger.WithFields(logger.LogFields{
+		"channel":  channel.Name,
+		"messages": len(transcript.Messages),
+		"path":     base,
+	}).Info("Exported channel history")
+	return paths, nil
+}
+
+// transcriptUserMap returns the user names transcripts are written with,
+// fetching them on first use. Channels are archived one at a time, so the
+// map is shared by every export of a run.
+func (c *Client) transcriptUserMap(ctx context.Context) (map[string]string, error) {
+	if c.exportUsers == nil {
+		userMap, err := c.getUserMap(ctx)
+		if err != nil {
+			return nil, err
+		}
+		c.exportUsers = userMap
+	}
+	return c.exportUsers, nil
+}
+
+// channelTranscript pages through the full history of channel and the
+// replies to each of its threads.
+func (c *Client) channelTranscript(ctx context.Context, channel Channel, userMap map[string]string) (*Transcript, error) {
+	params := &slack.GetConversationHistoryParameters{
+		ChannelID: channel.ID,
+		Limit:     historyPageLimit,
+	}
+	messages, err := paginate(ctx, "conversations.history", func(cursor string) ([]slack.Message, string, error) {
+		params.Cursor = cursor
+		history, err := c.api.GetConversationHistory(ctx, params)
+		if err != nil {
+			return nil, "", err
+		}
+		if !history.HasMore {
+			return history.Messages, "", nil
+		}
+		return history.Messages, history.ResponseMetaData.NextCursor, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history of %s: %w", channel.Name, err)
+	}
+	// Slack returns the newest messages first.
+	slices.Reverse(messages)
+
+	transcript := &Transcript{
+		ExportedAt: time.Now().UTC(),
+		Created:    channel.Created,
+		ChannelID:  channel.ID,
+		Channel:    channel.Name,
+		Purpose:    channel.Purpose,
+		IsPrivate:  channel.IsPrivate,
+		Messages:   make([]TranscriptMessage, 0, len(messages)),
+	}
+	for i := range messages {
+		message := transcriptMessage(&messages[i], userMap)
+		if messages[i].ReplyCount > 0 {
+			if message.Replies, err = c.threadTranscript(ctx, channel, messages[i].Timestamp, userMap); err != nil {
+				return nil, err
+			}
+		}
+		transcript.Messages = append(transcript.Messages, message)
+	}
+	return transcript, nil
+}
+
+// threadTranscript returns the replies to the thread at threadTS, oldest
+// first.
+func (c *Client) threadTranscript(ctx context.Context, channel Channel, threadTS string, userMap map[string]string) ([]TranscriptMessage, error) {
+	replies, err := paginate(ctx, "conversations.replies", func(cursor string) ([]slack.Message, string, error) {
+		return c.api.GetConversationReplies(ctx, &slack.GetConversationRepliesParameters{
+			ChannelID: channel.ID,
+			Timestamp: threadTS,
+			Cursor:    cursor,
+			Limit:     historyPageLimit,
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read thread replies in %s: %w", channel.Name, err)
+	}
+
+	var messages []TranscriptMessage
+	for i := range replies {
+		// Every page of conversations.replies starts with the parent.
+		if replies[i].Timestamp == threadTS {
+			continue
+		}
+		messages = append(messages, transcriptMessage(&replies[i], userMap))
+	}
+	return messages, nil
+}
+
+// transcriptMessage converts msg, naming its author from userMap. Messages
+// from bots without a user fall back to the bot's name.
+func transcriptMessage(msg *slack.Message, userMap map[string]string) TranscriptMessage {
+	// Unparseable timestamps leave Time zero; Timestamp keeps the original.
+	msgTime, _ := parseSlackTimestamp(msg.Timestamp)
+	message := TranscriptMessage{
+		Time:      msgTime.UTC(),
+		Timestamp: msg.Timestamp,
+		UserID:    msg.User,
+		User:      userMap[msg.User],
+		SubType:   msg.SubType,
+		Text:      msg.Text,
+	}
+	if message.User == "" {
+		message.User = msg.Username
+	}
+	if message.User == "" {
+		message.User = msg.User
+	}
+	for _, file := range msg.Files {
+		message.Files = append(message.Files, file.Name)
+	}
+	return message
+}
+
+// FormatTranscriptMarkdown formats transcript as a Markdown document, one
+// section per day, with user mentions replaced by names from userMap.
+func FormatTranscriptMarkdown(transcript *Transcript, userMap map[string]string) string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "# #%s\n\n", transcript.Channel)
+	fmt.Fprintf(&builder, "- Channel ID: %s\n", transcript.ChannelID)
+	if !transcript.Created.IsZero() {
+		fmt.Fprintf(&builder, "- Created: %s\n", transcript.Created.UTC().Format("2006-01-02"))
+	}
+	if transcript.Purpose != "" {
+		fmt.Fprintf(&builder, "- Purpose: %s\n", resolveMentions(transcript.Purpose, userMap))
+	}
+	fmt.Fprintf(&builder, "- Exported: %s, before archiving\n", transcript.ExportedAt.UTC().Format("2006-01-02 15:04:05 UTC"))
+	fmt.Fprintf(&builder, "- Messages: %d\n", len(transcript.Messages))
+
+	day := ""
+	for _, message := range transcript.Messages {
+		if messageDay := message.Time.Format("2006-01-02"); messageDay != day {
+			day = messageDay
+			fmt.Fprintf(&builder, "\n## %s\n", day)
+		}
+		builder.WriteString("\n")
+		writeTranscriptMessage(&builder, message, userMap, "")
+		for _, reply := range message.Replies {
+			builder.WriteString(">\n")
+			writeTranscriptMessage(&builder, reply, userMap, "> ")
+		}
+	}
+
+	return builder.String()
+}
+
+// writeTranscriptMessage writes message to builder with each line prefixed
+// by prefix.
+func writeTranscriptMessage(builder *strings.Builder, message TranscriptMessage, userMap map[string]string, prefix string) {
+	author := message.User
+	if author == "" {
+		author = "unknown"
+	}
+	fmt.Fprintf(builder, "%s**%s** %s", prefix, author, message.Time.Format("15:04 UTC"))
+	if message.SubType != "" {
+		fmt.Fprintf(builder, " _(%s)_", message.SubType)
+	}
+	builder.WriteString("\n")
+	if text := strings.TrimSpace(resolveMentions(message.Text, userMap)); text != "" {
+		fmt.Fprintf(builder, "%s\n", strings.TrimSpace(prefix))
+		for _, line := range strings.Split(text, "\n") {
+			fmt.Fprintf(builder, "%s%s\n", prefix, line)
+		}
+	}
+	for _, file := range message.Files {
+		fmt.Fprintf(builder, "%s📎 %s\n", prefix, file)
+	}
+}
+
+// resolveMentions replaces user mentions in text with @name where the user
+// is known.
+func resolveMentions(text string, userMap map[string]string) string {
+	return mentionPattern.ReplaceAllStringFunc(text, func(mention string) string {
+		if name := userMap[mentionPattern.FindStringSubmatch(mention)[1]]; name != "" {
+			return "@" + name
+		}
+		return mention
+	})
+}
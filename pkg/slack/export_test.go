@@ -0,0 +1,91 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportChannelHistory(t *testing.T) {
+	created := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	setup := func() (*MockSlackAPI, *Client, Channel) {
+		mockAPI := NewMockSlackAPI()
+		mockAPI.AddUser("U100", "alice", "Alice Admin")
+		mockAPI.AddUser("U200", "bob", "Bob Builder")
+		mockAPI.AddChannel("C1", "old-project", created, "Planning the old project")
+		mockAPI.SetChannelHistory("C1", []MockHistoryMessage{
+			{Timestamp: "1754049600.000100", User: "U100", Text: "Kickoff, <@U200> please take notes"},
+			{Timestamp: "1754136000.000200", User: "U200", Text: "Notes are up", Replies: []MockHistoryMessage{
+				{Timestamp: "1754139600.000300", User: "U100", Text: "Thanks!\nLooks good"},
+			}},
+			{Timestamp: "1754222400.000400", User: "U300", SubType: "channel_join", Text: "<@U300> has joined the channel"},
+		})
+		client, err := NewClientWithAPI(mockAPI)
+		require.NoError(t, err)
+		return mockAPI, client, Channel{ID: "C1", Name: "old-project", Purpose: "Planning the old project", Created: created}
+	}
+
+	t.Run("Writes JSON and Markdown transcripts", func(t *testing.T) {
+		_, client, channel := setup()
+		dir := filepath.Join(t.TempDir(), "exports")
+		paths, err := client.ExportChannelHistory(context.Background(), channel, dir)
+		require.NoError(t, err)
+		require.Len(t, paths, 2)
+		assert.Regexp(t, `old-project-C1-\d{8}T\d{6}Z\.json$`, paths[0])
+
+		info, err := os.Stat(paths[0])
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+		data, err := os.ReadFile(paths[0])
+		require.NoError(t, err)
+		var transcript Transcript
+		require.NoError(t, json.Unmarshal(data, &transcript))
+		assert.Equal(t, "C1", transcript.ChannelID)
+		require.Len(t, transcript.Messages, 3)
+		assert.Equal(t, "Alice Admin", transcript.Messages[0].User)
+		assert.Equal(t, "Kickoff, <@U200> please take notes", transcript.Messages[0].Text)
+		assert.Equal(t, time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC), transcript.Messages[0].Time)
+		require.Len(t, transcript.Messages[1].Replies, 1)
+		assert.Equal(t, "Alice Admin", transcript.Messages[1].Replies[0].User)
+		assert.Equal(t, "U300", transcript.Messages[2].User, "unknown users keep their ID")
+
+		markdown, err := os.ReadFile(paths[1])
+		require.NoError(t, err)
+		assert.Contains(t, string(markdown), "# #old-project")
+		assert.Contains(t, string(markdown), "- Purpose: Planning the old project")
+		assert.Contains(t, string(markdown), "## 2025-08-01")
+		assert.Contains(t, string(markdown), "**Alice Admin** 12:00 UTC\n\nKickoff, @Bob Builder please take notes\n")
+		assert.Contains(t, string(markdown), "> **Alice Admin** 13:00 UTC\n>\n> Thanks!\n> Looks good\n")
+		assert.Contains(t, string(markdown), "**U300** 12:00 UTC _(channel_join)_")
+	})
+
+	t.Run("Archival exports the channel first", func(t *testing.T) {
+		mockAPI, client, channel := setup()
+		dir := t.TempDir()
+		client.SetExportDir(dir)
+		require.NoError(t, client.ArchiveChannelWithThresholds(context.Background(), channel, 300, 60))
+		assert.Equal(t, []string{"C1"}, mockAPI.ArchivedChannels)
+
+		files, err := filepath.Glob(filepath.Join(dir, "old-project-C1-*"))
+		require.NoError(t, err)
+		assert.Len(t, files, 2)
+	})
+
+	t.Run("Channels that can't be exported aren't archived", func(t *testing.T) {
+		mockAPI, client, channel := setup()
+		mockAPI.GetConversationRepliesError = errors.New("ratelimited")
+		client.SetExportDir(t.TempDir())
+		err := client.ArchiveChannelWithThresholds(context.Background(), channel, 300, 60)
+		assert.ErrorContains(t, err, "failed to export history before archiving")
+		assert.Empty(t, mockAPI.ArchivedChannels)
+		assert.Empty(t, mockAPI.PostedMessages)
+	})
+}
@@ -0,0 +1,812 @@
+// Package fakeslack runs an in-memory Slack Web API server for end-to-end
+// tests. It implements the methods slack-butler calls, speaks the same JSON
+// as Slack so requests go through slack-go's real decoding, paginates with
+// cursors, and can be told to answer with HTTP 429 or a Slack error code.
+//
+// Point a client at it with slack.OptionAPIURL(server.URL()).
+package fakeslack
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// Identity of the bot the server authenticates every request as.
+const (
+	BotUserID = "UBOT000001"
+	BotID     = "BBOT000001"
+	BotName   = "slack-butler"
+	TeamID    = "T00000001"
+	TeamName  = "Fake Workspace"
+	TeamURL   = "https://fake-workspace.slack.com/"
+)
+
+// DefaultScopes are the OAuth scopes the server reports as granted until
+// SetScopes says otherwise.
+var DefaultScopes = []string{
+	"channels:read", "channels:join", "channels:history", "channels:manage",
+	"chat:write", "users:read", "groups:read", "groups:history", "groups:write",
+	"reactions:read",
+}
+
+// defaultPageLimit is used when a request doesn't send a limit.
+const defaultPageLimit = 100
+
+// PostedMessage is a message sent through chat.postMessage.
+type PostedMessage struct {
+	ChannelID string
+	Text      string
+	Timestamp string
+}
+
+// rateLimit makes the next remaining calls to a method fail with HTTP 429.
+type rateLimit struct {
+	remaining  int
+	retryAfter time.Duration
+}
+
+// Server is a fake Slack Web API. All methods are safe for concurrent use.
+type Server struct {
+	server     *httptest.Server
+	history    map[string][]slack.Message
+	replies    map[string][]slack.Message
+	members    map[string]map[string]bool
+	rateLimits map[string]*rateLimit
+	errors     map[string]string
+	calls      map[string]int
+	token      string
+	scopes     []string
+	channels   []slack.Channel
+	users      []slack.User
+	posted     []PostedMessage
+	archived   []string
+	unarchived []string
+	joined     []string
+	pageSize   int
+	lastTS     int64
+	mu         sync.Mutex
+}
+
+// New starts a fake Slack server. Callers must Close it when done.
+func New() *Server {
+	s := &Server{
+		history:    make(map[string][]slack.Message),
+		replies:    make(map[string][]slack.Message),
+		members:    make(map[string]map[string]bool),
+		rateLimits: make(map[string]*rateLimit),
+		errors:     make(map[string]string),
+		calls:      make(map[string]int),
+		scopes:     DefaultScopes,
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// URL returns the API base URL, suitable for slack.OptionAPIURL.
+func (s *Server) URL() string {
+	return s.server.URL + "/api/"
+}
+
+// SetToken makes the server reject requests that don't carry token with
+// invalid_auth. By default any token is accepted.
+func (s *Server) SetToken(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.token = token
+}
+
+// SetScopes sets the OAuth scopes reported in the X-OAuth-Scopes header
+// of every response.
+func (s *Server) SetScopes(scopes ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scopes = scopes
+}
+
+// SetPageSize caps how many items paginated methods return per page,
+// regardless of the limit the client asks for. Zero removes the cap.
+func (s *Server) SetPageSize(size int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pageSize = size
+}
+
+// RateLimit makes the next times calls to method (e.g. "conversations.list")
+// fail with HTTP 429 and the given Retry-After, rounded up to whole seconds.
+func (s *Server) RateLimit(method string, times int, retryAfter time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rateLimits[method] = &rateLimit{remaining: times, retryAfter: retryAfter}
+}
+
+// FailWith makes every call to method fail with the Slack error code, e.g.
+// "missing_scope". An empty code clears the failure.
+func (s *Server) FailWith(method, code string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if code == "" {
+		delete(s.errors, method)
+		return
+	}
+	s.errors[method] = code
+}
+
+// AddChannel adds a public channel the bot is not a member of.
+func (s *Server) AddChannel(id, name string, created time.Time, purpose string) {
+	s.SetChannel(newChannel(id, name, created, purpose))
+}
+
+// AddPrivateChannel adds a private channel. Like Slack, conversations.list
+// only returns it to the bot when the bot is a member.
+func (s *Server) AddPrivateChannel(id, name string, created time.Time, purpose string, isMember bool) {
+	ch := newChannel(id, name, created, purpose)
+	ch.IsPrivate = true
+	s.SetChannel(ch)
+	if isMember {
+		s.AddMember(id, BotUserID)
+	}
+}
+
+// SetChannel adds ch, or replaces the channel with the same ID. Membership
+// (IsMember, NumMembers) is derived from AddMember and joins.
+func (s *Server) SetChannel(ch slack.Channel) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.channels {
+		if s.channels[i].ID == ch.ID {
+			s.channels[i] = ch
+			return
+		}
+	}
+	s.channels = append(s.channels, ch)
+}
+
+// Channel returns the current state of a channel.
+func (s *Server) Channel(id string) (slack.Channel, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ch := s.findChannel(id)
+	if ch == nil {
+		return slack.Channel{}, false
+	}
+	return s.withMembership(*ch), true
+}
+
+// AddMember adds a user to a channel. Use BotUserID for the bot.
+func (s *Server) AddMember(channelID, userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addMember(channelID, userID)
+}
+
+func (s *Server) addMember(channelID, userID string) {
+	if s.members[channelID] == nil {
+		s.members[channelID] = make(map[string]bool)
+	}
+	s.members[channelID][userID] = true
+}
+
+// AddUser adds a workspace member.
+func (s *Server) AddUser(id, name, realName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users = append(s.users, slack.User{
+		ID:       id,
+		TeamID:   TeamID,
+		Name:     name,
+		RealName: realName,
+		Profile:  slack.UserProfile{RealName: realName, DisplayName: name},
+	})
+}
+
+// AddMessage adds a message from userID to a channel's history.
+func (s *Server) AddMessage(channelID, userID, text string, at time.Time) {
+	s.AddRawMessage(channelID, slack.Message{Msg: slack.Msg{
+		Type:      "message",
+		User:      userID,
+		Text:      text,
+		Timestamp: formatTimestamp(at),
+	}})
+}
+
+// AddRawMessage adds msg to a channel's history as is, e.g. to add a
+// message with a subtype or a bot_id.
+func (s *Server) AddRawMessage(channelID string, msg slack.Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.history[channelID] = append(s.history[channelID], msg)
+}
+
+// AddReply adds a reply from userID to the thread started by the message at
+// parentTS and updates the parent's reply_count and latest_reply.
+func (s *Server) AddReply(channelID, parentTS, userID, text string, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addReply(channelID, parentTS, slack.Message{Msg: slack.Msg{
+		Type:      "message",
+		User:      userID,
+		Text:      text,
+		Timestamp: formatTimestamp(at),
+	}})
+}
+
+func (s *Server) addReply(channelID, parentTS string, reply slack.Message) {
+	reply.ThreadTimestamp = parentTS
+	key := threadKey(channelID, parentTS)
+	s.replies[key] = append(s.replies[key], reply)
+	for i := range s.history[channelID] {
+		parent := &s.history[channelID][i]
+		if parent.Timestamp == parentTS {
+			parent.ThreadTimestamp = parentTS
+			parent.ReplyCount++
+			if parseTimestamp(reply.Timestamp) > parseTimestamp(parent.LatestReply) {
+				parent.LatestReply = reply.Timestamp
+			}
+		}
+	}
+}
+
+// AddReaction adds userID's reaction name to the message or thread reply at
+// ts in a channel.
+func (s *Server) AddReaction(channelID, ts, name, userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	msg := s.findMessage(channelID, ts)
+	if msg == nil {
+		return
+	}
+	for i := range msg.Reactions {
+		if msg.Reactions[i].Name == name {
+			msg.Reactions[i].Users = append(msg.Reactions[i].Users, userID)
+			msg.Reactions[i].Count++
+			return
+		}
+	}
+	msg.Reactions = append(msg.Reactions, slack.ItemReaction{Name: name, Count: 1, Users: []string{userID}})
+}
+
+// PostedMessages returns the messages sent through chat.postMessage.
+func (s *Server) PostedMessages() []PostedMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]PostedMessage(nil), s.posted...)
+}
+
+// ArchivedChannels returns the IDs archived through conversations.archive.
+func (s *Server) ArchivedChannels() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.archived...)
+}
+
+// UnarchivedChannels returns the IDs unarchived through
+// conversations.unarchive.
+func (s *Server) UnarchivedChannels() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.unarchived...)
+}
+
+// JoinedChannels returns the IDs the bot joined through conversations.join.
+func (s *Server) JoinedChannels() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.joined...)
+}
+
+// Calls returns how many requests were made to method, including rejected ones.
+func (s *Server) Calls(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[method]
+}
+
+func newChannel(id, name string, created time.Time, purpose string) slack.Channel {
+	ch := slack.Channel{}
+	ch.ID = id
+	ch.Name = name
+	ch.Created = slack.JSONTime(created.Unix())
+	ch.Purpose = slack.Purpose{Value: purpose}
+	ch.IsChannel = true
+	return ch
+}
+
+// handle authenticates and dispatches a Web API request.
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	method := strings.TrimPrefix(r.URL.Path, "/api/")
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls[method]++
+
+	if limit := s.rateLimits[method]; limit != nil && limit.remaining > 0 {
+		limit.remaining--
+		seconds := int((limit.retryAfter + time.Second - 1) / time.Second)
+		w.Header().Set("Retry-After", strconv.Itoa(seconds))
+		w.WriteHeader(http.StatusTooManyRequests)
+		return
+	}
+	if s.token != "" && requestToken(r) != s.token {
+		writeError(w, "invalid_auth")
+		return
+	}
+	w.Header().Set("X-OAuth-Scopes", strings.Join(s.scopes, ","))
+	if code, failing := s.errors[method]; failing {
+		writeError(w, code)
+		return
+	}
+
+	handler, ok := s.handlers()[method]
+	if !ok {
+		writeError(w, "unknown_method")
+		return
+	}
+	handler(w, r)
+}
+
+func (s *Server) handlers() map[string]http.HandlerFunc {
+	return map[string]http.HandlerFunc{
+		"auth.test":               s.authTest,
+		"team.info":               s.teamInfo,
+		"conversations.list":      s.conversationsList,
+		"conversations.history":   s.conversationsHistory,
+		"conversations.info":      s.conversationsInfo,
+		"conversations.replies":   s.conversationsReplies,
+		"conversations.join":      s.conversationsJoin,
+		"conversations.archive":   s.conversationsArchive,
+		"conversations.unarchive": s.conversationsUnarchive,
+		"chat.postMessage":        s.chatPostMessage,
+		"reactions.get":           s.reactionsGet,
+		"users.list":              s.usersList,
+		"users.conversations":     s.usersConversations,
+	}
+}
+
+// requestToken returns the token from the form body or Authorization header.
+func requestToken(r *http.Request) string {
+	if token := r.FormValue("token"); token != "" {
+		return token
+	}
+	return strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+}
+
+func (s *Server) authTest(w http.ResponseWriter, _ *http.Request) {
+	writeOK(w, map[string]any{
+		"url":     TeamURL,
+		"team":    TeamName,
+		"user":    BotName,
+		"team_id": TeamID,
+		"user_id": BotUserID,
+		"bot_id":  BotID,
+	})
+}
+
+func (s *Server) teamInfo(w http.ResponseWriter, _ *http.Request) {
+	writeOK(w, map[string]any{
+		"team": slack.TeamInfo{
+			ID:     TeamID,
+			Name:   TeamName,
+			Domain: "fake-workspace",
+		},
+	})
+}
+
+func (s *Server) conversationsList(w http.ResponseWriter, r *http.Request) {
+	types := requestTypes(r)
+	excludeArchived := r.FormValue("exclude_archived") == "true"
+
+	var visible []slack.Channel
+	for _, ch := range s.channels {
+		ch = s.withMembership(ch)
+		if !types[channelType(ch)] || (excludeArchived && ch.IsArchived) {
+			continue
+		}
+		if ch.IsPrivate && !ch.IsMember {
+			continue
+		}
+		visible = append(visible, ch)
+	}
+	writePage(s, w, r, "channels", visible)
+}
+
+func (s *Server) conversationsHistory(w http.ResponseWriter, r *http.Request) {
+	ch := s.findChannel(r.FormValue("channel"))
+	if ch == nil || (ch.IsPrivate && !s.isMember(ch.ID, BotUserID)) {
+		writeError(w, "channel_not_found")
+		return
+	}
+	if !s.isMember(ch.ID, BotUserID) {
+		writeError(w, "not_in_channel")
+		return
+	}
+
+	oldest := parseTimestamp(r.FormValue("oldest"))
+	latest := parseTimestamp(r.FormValue("latest"))
+	var messages []slack.Message
+	for _, msg := range s.history[ch.ID] {
+		ts := parseTimestamp(msg.Timestamp)
+		if (oldest > 0 && ts < oldest) || (latest > 0 && ts > latest) {
+			continue
+		}
+		messages = append(messages, msg)
+	}
+	// Slack returns history newest first
+	sort.SliceStable(messages, func(i, j int) bool {
+		return parseTimestamp(messages[i].Timestamp) > parseTimestamp(messages[j].Timestamp)
+	})
+
+	page, next, ok := s.page(w, r, len(messages))
+	if !ok {
+		return
+	}
+	writeOK(w, map[string]any{
+		"messages":          messages[page.start:page.end],
+		"has_more":          next != "",
+		"response_metadata": map[string]string{"next_cursor": next},
+	})
+}
+
+func (s *Server) conversationsReplies(w http.ResponseWriter, r *http.Request) {
+	ch := s.findChannel(r.FormValue("channel"))
+	if ch == nil || (ch.IsPrivate && !s.isMember(ch.ID, BotUserID)) {
+		writeError(w, "channel_not_found")
+		return
+	}
+	if !s.isMember(ch.ID, BotUserID) {
+		writeError(w, "not_in_channel")
+		return
+	}
+
+	threadTS := r.FormValue("ts")
+	var messages []slack.Message
+	for _, msg := range s.history[ch.ID] {
+		if msg.Timestamp == threadTS {
+			messages = append(messages, msg)
+		}
+	}
+	if len(messages) == 0 {
+		writeError(w, "thread_not_found")
+		return
+	}
+	// Slack returns the parent first, then replies oldest first
+	oldest := parseTimestamp(r.FormValue("oldest"))
+	latest := parseTimestamp(r.FormValue("latest"))
+	for _, reply := range s.replies[threadKey(ch.ID, threadTS)] {
+		ts := parseTimestamp(reply.Timestamp)
+		if (oldest > 0 && ts < oldest) || (latest > 0 && ts > latest) {
+			continue
+		}
+		messages = append(messages, reply)
+	}
+
+	page, next, ok := s.page(w, r, len(messages))
+	if !ok {
+		return
+	}
+	writeOK(w, map[string]any{
+		"messages":          messages[page.start:page.end],
+		"has_more":          next != "",
+		"response_metadata": map[string]string{"next_cursor": next},
+	})
+}
+
+func (s *Server) conversationsInfo(w http.ResponseWriter, r *http.Request) {
+	ch := s.findChannel(r.FormValue("channel"))
+	if ch == nil || (ch.IsPrivate && !s.isMember(ch.ID, BotUserID)) {
+		writeError(w, "channel_not_found")
+		return
+	}
+	writeOK(w, map[string]any{"channel": s.withMembership(*ch)})
+}
+
+func (s *Server) conversationsJoin(w http.ResponseWriter, r *http.Request) {
+	ch := s.findChannel(r.FormValue("channel"))
+	switch {
+	case ch == nil:
+		writeError(w, "channel_not_found")
+		return
+	case ch.IsPrivate:
+		writeError(w, "method_not_supported_for_channel_type")
+		return
+	case ch.IsArchived:
+		writeError(w, "is_archived")
+		return
+	}
+
+	if s.isMember(ch.ID, BotUserID) {
+		writeOK(w, map[string]any{
+			"channel": s.withMembership(*ch),
+			"warning": "already_in_channel",
+		})
+		return
+	}
+	s.addMember(ch.ID, BotUserID)
+	s.joined = append(s.joined, ch.ID)
+	writeOK(w, map[string]any{"channel": s.withMembership(*ch)})
+}
+
+func (s *Server) conversationsArchive(w http.ResponseWriter, r *http.Request) {
+	ch := s.findChannel(r.FormValue("channel"))
+	switch {
+	case ch == nil || (ch.IsPrivate && !s.isMember(ch.ID, BotUserID)):
+		writeError(w, "channel_not_found")
+		return
+	case ch.IsArchived:
+		writeError(w, "already_archived")
+		return
+	case !s.isMember(ch.ID, BotUserID):
+		writeError(w, "not_in_channel")
+		return
+	}
+
+	// Archiving a channel removes the bot from it.
+	ch.IsArchived = true
+	delete(s.members[ch.ID], BotUserID)
+	s.archived = append(s.archived, ch.ID)
+	writeOK(w, nil)
+}
+
+func (s *Server) conversationsUnarchive(w http.ResponseWriter, r *http.Request) {
+	ch := s.findChannel(r.FormValue("channel"))
+	switch {
+	case ch == nil || (ch.IsPrivate && !s.isMember(ch.ID, BotUserID)):
+		writeError(w, "channel_not_found")
+		return
+	case !ch.IsArchived:
+		writeError(w, "not_archived")
+		return
+	}
+
+	ch.IsArchived = false
+	s.unarchived = append(s.unarchived, ch.ID)
+	writeOK(w, nil)
+}
+
+func (s *Server) chatPostMessage(w http.ResponseWriter, r *http.Request) {
+	ch := s.findChannel(r.FormValue("channel"))
+	switch {
+	case ch == nil || (ch.IsPrivate && !s.isMember(ch.ID, BotUserID)):
+		writeError(w, "channel_not_found")
+		return
+	case ch.IsArchived:
+		writeError(w, "is_archived")
+		return
+	case !s.isMember(ch.ID, BotUserID):
+		writeError(w, "not_in_channel")
+		return
+	}
+
+	ts := s.nextTimestamp()
+	text := r.FormValue("text")
+	msg := slack.Message{Msg: slack.Msg{
+		Type:      "message",
+		User:      BotUserID,
+		BotID:     BotID,
+		Text:      text,
+		Timestamp: ts,
+	}}
+	if threadTS := r.FormValue("thread_ts"); threadTS != "" {
+		if s.findMessage(ch.ID, threadTS) == nil {
+			writeError(w, "thread_not_found")
+			return
+		}
+		s.addReply(ch.ID, threadTS, msg)
+	} else {
+		s.history[ch.ID] = append(s.history[ch.ID], msg)
+	}
+	s.posted = append(s.posted, PostedMessage{ChannelID: ch.ID, Text: text, Timestamp: ts})
+	writeOK(w, map[string]any{"channel": ch.ID, "ts": ts})
+}
+
+func (s *Server) reactionsGet(w http.ResponseWriter, r *http.Request) {
+	ch := s.findChannel(r.FormValue("channel"))
+	if ch == nil || (ch.IsPrivate && !s.isMember(ch.ID, BotUserID)) {
+		writeError(w, "channel_not_found")
+		return
+	}
+	msg := s.findMessage(ch.ID, r.FormValue("timestamp"))
+	if msg == nil {
+		writeError(w, "message_not_found")
+		return
+	}
+	writeOK(w, map[string]any{"type": "message", "channel": ch.ID, "message": msg})
+}
+
+func (s *Server) usersList(w http.ResponseWriter, r *http.Request) {
+	writePage(s, w, r, "members", s.users)
+}
+
+func (s *Server) usersConversations(w http.ResponseWriter, r *http.Request) {
+	userID := r.FormValue("user")
+	if userID == "" {
+		userID = BotUserID
+	}
+	types := requestTypes(r)
+	excludeArchived := r.FormValue("exclude_archived") == "true"
+
+	var channels []slack.Channel
+	for _, ch := range s.channels {
+		if !s.isMember(ch.ID, userID) || !types[channelType(ch)] || (excludeArchived && ch.IsArchived) {
+			continue
+		}
+		if ch.IsPrivate && !s.isMember(ch.ID, BotUserID) {
+			continue
+		}
+		channels = append(channels, s.withMembership(ch))
+	}
+	writePage(s, w, r, "channels", channels)
+}
+
+// findChannel looks a channel up by ID, or by name with or without "#".
+func (s *Server) findChannel(idOrName string) *slack.Channel {
+	name := strings.TrimPrefix(idOrName, "#")
+	for i := range s.channels {
+		if s.channels[i].ID == idOrName || s.channels[i].Name == name {
+			return &s.channels[i]
+		}
+	}
+	return nil
+}
+
+// findMessage returns the message or thread reply at ts in a channel, or nil.
+func (s *Server) findMessage(channelID, ts string) *slack.Message {
+	for i := range s.history[channelID] {
+		if s.history[channelID][i].Timestamp == ts {
+			return &s.history[channelID][i]
+		}
+	}
+	prefix := threadKey(channelID, "")
+	for key, replies := range s.replies {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		for i := range replies {
+			if replies[i].Timestamp == ts {
+				return &replies[i]
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Server) isMember(channelID, userID string) bool {
+	return s.members[channelID][userID]
+}
+
+// withMembership fills in the bot-relative membership fields of ch.
+func (s *Server) withMembership(ch slack.Channel) slack.Channel {
+	ch.IsMember = s.isMember(ch.ID, BotUserID)
+	ch.NumMembers = len(s.members[ch.ID])
+	return ch
+}
+
+// threadKey keys replies by channel and thread parent timestamp.
+func threadKey(channelID, threadTS string) string {
+	return channelID + "/" + threadTS
+}
+
+// nextTimestamp returns a unique, increasing message timestamp.
+func (s *Server) nextTimestamp() string {
+	now := time.Now().UnixMicro()
+	if now <= s.lastTS {
+		now = s.lastTS + 1
+	}
+	s.lastTS = now
+	return fmt.Sprintf("%d.%06d", now/1e6, now%1e6)
+}
+
+type pageBounds struct {
+	start, end int
+}
+
+// page works out which slice of total items a cursor-paginated request
+// covers and the cursor for the page after it. Cursors are decimal offsets.
+func (s *Server) page(w http.ResponseWriter, r *http.Request, total int) (pageBounds, string, bool) {
+	start := 0
+	if cursor := r.FormValue("cursor"); cursor != "" {
+		offset, err := strconv.Atoi(cursor)
+		if err != nil || offset < 0 || offset > total {
+			writeError(w, "invalid_cursor")
+			return pageBounds{}, "", false
+		}
+		start = offset
+	}
+
+	limit, err := strconv.Atoi(r.FormValue("limit"))
+	if err != nil || limit <= 0 {
+		limit = defaultPageLimit
+	}
+	if s.pageSize > 0 && s.pageSize < limit {
+		limit = s.pageSize
+	}
+
+	end := start + limit
+	if end >= total {
+		return pageBounds{start: start, end: total}, "", true
+	}
+	return pageBounds{start: start, end: end}, strconv.Itoa(end), true
+}
+
+// writePage writes one page of items under key with response_metadata.
+func writePage[T any](s *Server, w http.ResponseWriter, r *http.Request, key string, items []T) {
+	page, next, ok := s.page(w, r, len(items))
+	if !ok {
+		return
+	}
+	writeOK(w, map[string]any{
+		key:                 items[page.start:page.end],
+		"response_metadata": map[string]string{"next_cursor": next},
+	})
+}
+
+// requestTypes parses the "types" parameter, defaulting to public channels.
+func requestTypes(r *http.Request) map[string]bool {
+	types := map[string]bool{}
+	for _, t := range strings.Split(r.FormValue("types"), ",") {
+		if t != "" {
+			types[t] = true
+		}
+	}
+	if len(types) == 0 {
+		types["public_channel"] = true
+	}
+	return types
+}
+
+func channelType(ch slack.Channel) string {
+	if ch.IsPrivate {
+		return "private_channel"
+	}
+	return "public_channel"
+}
+
+// formatTimestamp formats t as a Slack message timestamp.
+func formatTimestamp(t time.Time) string {
+	return fmt.Sprintf("%d.%06d", t.Unix(), t.Nanosecond()/1000)
+}
+
+// parseTimestamp parses a Slack timestamp, returning 0 when empty or invalid.
+func parseTimestamp(ts string) float64 {
+	f, err := strconv.ParseFloat(ts, 64)
+	if err != nil {
+		return 0
+	}
+	return f
+}
+
+func writeOK(w http.ResponseWriter, fields map[string]any) {
+	body := map[string]any{"ok": true}
+	for k, v := range fields {
+		body[k] = v
+	}
+	writeJSON(w, body)
+}
+
+func writeError(w http.ResponseWriter, code string) {
+	writeJSON(w, map[string]any{"ok": false, "error": code})
+}
+
+func writeJSON(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,268 @@
+package fakeslack
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T) (*Server, *slack.Client) {
+	t.Helper()
+	server := New()
+	t.Cleanup(server.Close)
+	return server, slack.New("xoxb-fake", slack.OptionAPIURL(server.URL()))
+}
+
+func TestAuthAndTeam(t *testing.T) {
+	server, api := newTestServer(t)
+	ctx := context.Background()
+
+	t.Run("auth.test returns the bot identity", func(t *testing.T) {
+		auth, err := api.AuthTestContext(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, BotUserID, auth.UserID)
+		assert.Equal(t, TeamID, auth.TeamID)
+		assert.Equal(t, TeamURL, auth.URL)
+	})
+
+	t.Run("auth.test reports granted scopes", func(t *testing.T) {
+		server.SetScopes("channels:read", "chat:write")
+		defer server.SetScopes(DefaultScopes...)
+
+		auth, err := api.AuthTestContext(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "channels:read,chat:write", auth.Header.Get("X-OAuth-Scopes"))
+	})
+
+	t.Run("team.info", func(t *testing.T) {
+		team, err := api.GetTeamInfoContext(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, TeamName, team.Name)
+	})
+
+	t.Run("Wrong token is rejected", func(t *testing.T) {
+		server.SetToken("xoxb-expected")
+		defer server.SetToken("")
+
+		_, err := api.AuthTestContext(ctx)
+		var response slack.SlackErrorResponse
+		require.ErrorAs(t, err, &response)
+		assert.Equal(t, "invalid_auth", response.Err)
+	})
+}
+
+func TestConversationsList(t *testing.T) {
+	server, api := newTestServer(t)
+	ctx := context.Background()
+	created := time.Now().Add(-48 * time.Hour)
+	server.AddChannel("C001", "general", created, "General chat")
+	server.AddChannel("C002", "random", created, "")
+	server.AddChannel("C003", "dev", created, "")
+	server.AddPrivateChannel("G001", "secret", created, "", true)
+	server.AddPrivateChannel("G002", "hidden", created, "", false)
+
+	t.Run("Pages follow next_cursor", func(t *testing.T) {
+		server.SetPageSize(2)
+		defer server.SetPageSize(0)
+
+		params := &slack.GetConversationsParameters{Types: []string{"public_channel"}, Limit: 1000}
+		first, cursor, err := api.GetConversationsContext(ctx, params)
+		require.NoError(t, err)
+		assert.Len(t, first, 2)
+		require.NotEmpty(t, cursor)
+
+		params.Cursor = cursor
+		second, cursor, err := api.GetConversationsContext(ctx, params)
+		require.NoError(t, err)
+		assert.Len(t, second, 1)
+		assert.Empty(t, cursor)
+		assert.Equal(t, "dev", second[0].Name)
+	})
+
+	t.Run("Private channels are listed only when the bot is a member", func(t *testing.T) {
+		channels, _, err := api.GetConversationsContext(ctx, &slack.GetConversationsParameters{
+			Types: []string{"private_channel"},
+		})
+		require.NoError(t, err)
+		require.Len(t, channels, 1)
+		assert.Equal(t, "secret", channels[0].Name)
+		assert.True(t, channels[0].IsPrivate)
+		assert.True(t, channels[0].IsMember)
+	})
+
+	t.Run("Channel fields survive JSON decoding", func(t *testing.T) {
+		channels, _, err := api.GetConversationsContext(ctx, &slack.GetConversationsParameters{})
+		require.NoError(t, err)
+		require.NotEmpty(t, channels)
+		assert.Equal(t, "General chat", channels[0].Purpose.Value)
+		assert.Equal(t, created.Unix(), int64(channels[0].Created))
+	})
+}
+
+func TestRateLimitAndErrors(t *testing.T) {
+	server, api := newTestServer(t)
+	ctx := context.Background()
+
+	t.Run("429 with Retry-After", func(t *testing.T) {
+		server.RateLimit("conversations.list", 1, 1500*time.Millisecond)
+
+		_, _, err := api.GetConversationsContext(ctx, &slack.GetConversationsParameters{})
+		var rateLimited *slack.RateLimitedError
+		require.ErrorAs(t, err, &rateLimited)
+		assert.Equal(t, 2*time.Second, rateLimited.RetryAfter)
+
+		_, _, err = api.GetConversationsContext(ctx, &slack.GetConversationsParameters{})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, server.Calls("conversations.list"))
+	})
+
+	t.Run("FailWith returns the Slack error code", func(t *testing.T) {
+		server.FailWith("users.list", "missing_scope")
+
+		_, err := api.GetUsersContext(ctx)
+		var response slack.SlackErrorResponse
+		require.True(t, errors.As(err, &response))
+		assert.Equal(t, "missing_scope", response.Err)
+
+		server.FailWith("users.list", "")
+		_, err = api.GetUsersContext(ctx)
+		assert.NoError(t, err)
+	})
+}
+
+func TestMessagesAndMembership(t *testing.T) {
+	server, api := newTestServer(t)
+	ctx := context.Background()
+	server.AddChannel("C001", "general", time.Now().Add(-time.Hour), "")
+	server.AddUser("U001", "alice", "Alice Example")
+	server.AddMember("C001", "U001")
+
+	t.Run("History requires membership", func(t *testing.T) {
+		_, err := api.GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{ChannelID: "C001"})
+		var response slack.SlackErrorResponse
+		require.ErrorAs(t, err, &response)
+		assert.Equal(t, "not_in_channel", response.Err)
+	})
+
+	t.Run("Join then read history newest first", func(t *testing.T) {
+		_, _, _, err := api.JoinConversationContext(ctx, "C001")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"C001"}, server.JoinedChannels())
+
+		now := time.Now()
+		server.AddMessage("C001", "U001", "older", now.Add(-2*time.Hour))
+		server.AddMessage("C001", "U001", "newer", now.Add(-time.Hour))
+
+		history, err := api.GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{ChannelID: "C001", Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, history.Messages, 2)
+		assert.Equal(t, "newer", history.Messages[0].Text)
+		assert.False(t, history.HasMore)
+	})
+
+	t.Run("Replies follow their thread's parent", func(t *testing.T) {
+		history, err := api.GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{ChannelID: "C001", Limit: 10})
+		require.NoError(t, err)
+		parent := history.Messages[1]
+		replyTime := time.Now().Add(-time.Minute)
+		server.AddReply("C001", parent.Timestamp, "U001", "reply", replyTime)
+
+		history, err = api.GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{ChannelID: "C001", Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 1, history.Messages[1].ReplyCount)
+		assert.Equal(t, formatTimestamp(replyTime), history.Messages[1].LatestReply)
+
+		replies, hasMore, _, err := api.GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{ChannelID: "C001", Timestamp: parent.Timestamp})
+		require.NoError(t, err)
+		require.Len(t, replies, 2)
+		assert.Equal(t, "older", replies[0].Text)
+		assert.Equal(t, "reply", replies[1].Text)
+		assert.False(t, hasMore)
+
+		_, _, _, err = api.GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{ChannelID: "C001", Timestamp: "1.000000"})
+		var response slack.SlackErrorResponse
+		require.ErrorAs(t, err, &response)
+		assert.Equal(t, "thread_not_found", response.Err)
+	})
+
+	t.Run("Posted messages are recorded and appear in history", func(t *testing.T) {
+		channelID, ts, err := api.PostMessageContext(ctx, "C001", slack.MsgOptionText("hello", false))
+		require.NoError(t, err)
+		assert.Equal(t, "C001", channelID)
+
+		posted := server.PostedMessages()
+		require.Len(t, posted, 1)
+		assert.Equal(t, PostedMessage{ChannelID: "C001", Text: "hello", Timestamp: ts}, posted[0])
+
+		history, err := api.GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{ChannelID: "C001", Limit: 1})
+		require.NoError(t, err)
+		assert.Equal(t, "hello", history.Messages[0].Text)
+		assert.Equal(t, BotUserID, history.Messages[0].User)
+		assert.True(t, history.HasMore)
+	})
+
+	t.Run("users.conversations lists a user's channels", func(t *testing.T) {
+		channels, _, err := api.GetConversationsForUserContext(ctx, &slack.GetConversationsForUserParameters{UserID: "U001"})
+		require.NoError(t, err)
+		require.Len(t, channels, 1)
+		assert.Equal(t, 2, channels[0].NumMembers)
+	})
+
+	t.Run("Thread replies can be posted and reacted to", func(t *testing.T) {
+		_, parentTS, err := api.PostMessageContext(ctx, "C001", slack.MsgOptionText("parent", false))
+		require.NoError(t, err)
+		_, replyTS, err := api.PostMessageContext(ctx, "C001", slack.MsgOptionText("reply", false), slack.MsgOptionTS(parentTS))
+		require.NoError(t, err)
+
+		server.AddReaction("C001", replyTS, "white_check_mark", "U001")
+		server.AddReaction("C001", replyTS, "white_check_mark", "U002")
+		reacted, err := api.GetReactionsContext(ctx, slack.NewRefToMessage("C001", replyTS), slack.GetReactionsParameters{Full: true})
+		require.NoError(t, err)
+		require.Len(t, reacted.Reactions, 1)
+		assert.Equal(t, []string{"U001", "U002"}, reacted.Reactions[0].Users)
+
+		replies, _, _, err := api.GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{ChannelID: "C001", Timestamp: parentTS})
+		require.NoError(t, err)
+		require.Len(t, replies, 2)
+		assert.Equal(t, "reply", replies[1].Text)
+
+		_, err = api.GetReactionsContext(ctx, slack.NewRefToMessage("C001", "1.000000"), slack.GetReactionsParameters{})
+		var response slack.SlackErrorResponse
+		require.ErrorAs(t, err, &response)
+		assert.Equal(t, "message_not_found", response.Err)
+	})
+
+	t.Run("Archive marks the channel archived", func(t *testing.T) {
+		require.NoError(t, api.ArchiveConversationContext(ctx, "C001"))
+		assert.Equal(t, []string{"C001"}, server.ArchivedChannels())
+
+		ch, ok := server.Channel("C001")
+		require.True(t, ok)
+		assert.True(t, ch.IsArchived)
+
+		err := api.ArchiveConversationContext(ctx, "C001")
+		var response slack.SlackErrorResponse
+		require.ErrorAs(t, err, &response)
+		assert.Equal(t, "already_archived", response.Err)
+	})
+
+	t.Run("Unarchive restores the channel without the bot", func(t *testing.T) {
+		require.NoError(t, api.UnArchiveConversationContext(ctx, "C001"))
+		assert.Equal(t, []string{"C001"}, server.UnarchivedChannels())
+
+		ch, ok := server.Channel("C001")
+		require.True(t, ok)
+		assert.False(t, ch.IsArchived)
+		assert.False(t, ch.IsMember)
+
+		err := api.UnArchiveConversationContext(ctx, "C001")
+		var response slack.SlackErrorResponse
+		require.ErrorAs(t, err, &response)
+		assert.Equal(t, "not_archived", response.Err)
+	})
+}
@@ -0,0 +1,99 @@
+package slack
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// HTTPConfig holds the transport settings for talking to the Slack API,
+// e.g. to go through a corporate egress proxy that re-signs TLS traffic.
+type HTTPConfig struct {
+	// ProxyURL is the proxy for all Slack API requests. When empty the
+	// standard HTTPS_PROXY / HTTP_PROXY / NO_PROXY variables apply.
+	ProxyURL string
+	// CAFile is a PEM bundle of extra certificate authorities to trust in
+	// addition to the system roots.
+	CAFile string
+	// Timeout bounds each HTTP request. Zero means no timeout.
+	Timeout time.Duration
+}
+
+// IsZero reports whether cfg leaves every setting at its default.
+func (cfg HTTPConfig) IsZero() bool {
+	return cfg == HTTPConfig{}
+}
+
+// NewHTTPClient builds an *http.Client from cfg, for use with
+// slack.OptionHTTPClient.
+func NewHTTPClient(cfg HTTPConfig) (*http.Client, error) {
+	if cfg.Timeout < 0 {
+		return nil, fmt.Errorf("invalid HTTP timeout %s: must not be negative", cfg.Timeout)
+	}
+
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("unexpected default HTTP transport type %T", http.DefaultTransport)
+	}
+	transport = transport.Clone()
+
+	if cfg.ProxyURL != "" {
+		proxyURL, err := parseProxyURL(cfg.ProxyURL)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if cfg.CAFile != "" {
+		rootCAs, err := loadCertPool(cfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			RootCAs:    rootCAs,
+		}
+	}
+
+	return &http.Client{Transport: transport, Timeout: cfg.Timeout}, nil
+}
+
+// parseProxyURL validates a proxy URL such as http://proxy.corp:3128.
+func parseProxyURL(raw string) (*url.URL, error) {
+	proxyURL, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid HTTP proxy URL: %w", err)
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("invalid HTTP proxy URL %q: scheme must be http, https or socks5", SanitizeForLogging(raw))
+	}
+	if proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid HTTP proxy URL %q: missing host", SanitizeForLogging(raw))
+	}
+	return proxyURL, nil
+}
+
+// loadCertPool returns the system roots plus the certificates in caFile.
+func loadCertPool(caFile string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(filepath.Clean(caFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no PEM certificates found in CA file %s", caFile)
+	}
+	return pool, nil
+}
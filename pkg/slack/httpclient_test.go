@@ -0,0 +1,99 @@
+package slack
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const authTestBody = `{"ok":true,"user":"butler","user_id":"UBOT","team":"Test","team_id":"T1","url":"https://test.slack.com/"}`
+
+func TestNewHTTPClient(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		client, err := NewHTTPClient(HTTPConfig{})
+		require.NoError(t, err)
+		assert.Zero(t, client.Timeout)
+		assert.True(t, HTTPConfig{}.IsZero())
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		client, err := NewHTTPClient(HTTPConfig{Timeout: 15 * time.Second})
+		require.NoError(t, err)
+		assert.Equal(t, 15*time.Second, client.Timeout)
+
+		_, err = NewHTTPClient(HTTPConfig{Timeout: -time.Second})
+		assert.ErrorContains(t, err, "must not be negative")
+	})
+
+	t.Run("Invalid proxy URLs", func(t *testing.T) {
+		for _, proxy := range []string{"ftp://proxy:21", "http://", "://bad"} {
+			_, err := NewHTTPClient(HTTPConfig{ProxyURL: proxy})
+			assert.Error(t, err, proxy)
+		}
+	})
+
+	t.Run("Invalid CA files", func(t *testing.T) {
+		_, err := NewHTTPClient(HTTPConfig{CAFile: filepath.Join(t.TempDir(), "missing.pem")})
+		assert.ErrorContains(t, err, "failed to read CA file")
+
+		notPEM := filepath.Join(t.TempDir(), "bad.pem")
+		require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0o600))
+		_, err = NewHTTPClient(HTTPConfig{CAFile: notPEM})
+		assert.ErrorContains(t, err, "no PEM certificates")
+	})
+}
+
+func TestHTTPClientProxy(t *testing.T) {
+	var proxiedURL string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedURL = r.URL.String()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(authTestBody)) //nolint:errcheck
+	}))
+	defer proxy.Close()
+
+	httpClient, err := NewHTTPClient(HTTPConfig{ProxyURL: proxy.URL})
+	require.NoError(t, err)
+
+	api := NewRealSlackAPI("xoxb-test", slack.OptionAPIURL("http://slack.invalid/api/"), slack.OptionHTTPClient(httpClient))
+	auth, err := api.AuthTest(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "UBOT", auth.UserID)
+	assert.Equal(t, "http://slack.invalid/api/auth.test", proxiedURL)
+}
+
+func TestHTTPClientCAFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(authTestBody)) //nolint:errcheck
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caFile, certPEM, 0o600))
+
+	t.Run("Untrusted without CA file", func(t *testing.T) {
+		httpClient, err := NewHTTPClient(HTTPConfig{})
+		require.NoError(t, err)
+		api := NewRealSlackAPI("xoxb-test", slack.OptionAPIURL(server.URL+"/api/"), slack.OptionHTTPClient(httpClient))
+		_, err = api.AuthTest(t.Context())
+		assert.Error(t, err)
+	})
+
+	t.Run("Trusted with CA file", func(t *testing.T) {
+		httpClient, err := NewHTTPClient(HTTPConfig{CAFile: caFile})
+		require.NoError(t, err)
+		api := NewRealSlackAPI("xoxb-test", slack.OptionAPIURL(server.URL+"/api/"), slack.OptionHTTPClient(httpClient))
+		_, err = api.AuthTest(t.Context())
+		assert.NoError(t, err)
+	})
+}
@@ -1,71 +1,114 @@
 package slack
 
 import (
+	"context"
+
 	"github.com/slack-go/slack"
 )
 
 // SlackAPI defines the interface for Slack API operations.
+// Every method takes a context so in-flight requests can be cancelled.
 type SlackAPI interface {
-	AuthTest() (*slack.AuthTestResponse, error)
-	GetConversations(params *slack.GetConversationsParameters) ([]slack.Channel, string, error)
-	GetConversationHistory(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error)
-	GetConversationInfo(input *slack.GetConversationInfoInput) (*slack.Channel, error)
-	GetConversationsForUser(params *slack.GetConversationsForUserParameters) ([]slack.Channel, string, error)
-	PostMessage(channelID string, options ...slack.MsgOption) (string, string, error)
-	ArchiveConversation(channelID string) error
-	JoinConversation(channelID string) (*slack.Channel, string, []string, error)
-	GetUsers() ([]slack.User, error)
-	GetTeamInfo() (*slack.TeamInfo, error)
-}
-
-// RealSlackAPI wraps the actual Slack API client.
+	AuthTest(ctx context.Context) (*slack.AuthTestResponse, error)
+	GetConversations(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error)
+	GetConversationHistory(ctx context.Context, params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error)
+	GetConversationInfo(ctx context.Context, input *slack.GetConversationInfoInput) (*slack.Channel, error)
+	GetConversationReplies(ctx context.Context, params *slack.GetConversationRepliesParameters) ([]slack.Message, string, error)
+	GetConversationsForUser(ctx context.Context, params *slack.GetConversationsForUserParameters) ([]slack.Channel, string, error)
+	PostMessage(ctx context.Context, channelID string, options ...slack.MsgOption) (string, string, error)
+	ArchiveConversation(ctx context.Context, channelID string) error
+	UnarchiveConversation(ctx context.Context, channelID string) error
+	JoinConversation(ctx context.Context, channelID string) (*slack.Channel, string, []string, error)
+	GetReactions(ctx context.Context, item slack.ItemRef, params slack.GetReactionsParameters) ([]slack.ItemReaction, error)
+	GetUsersPage(ctx context.Context, cursor string, limit int) ([]slack.User, string, error)
+	GetTeamInfo(ctx context.Context) (*slack.TeamInfo, error)
+}
+
+// RealSlackAPI wraps the actual Slack API client. Errors returned by Slack are
+// converted to the typed errors in errors.go.
 type RealSlackAPI struct {
 	client *slack.Client
 }
 
-// NewRealSlackAPI creates a new real Slack API wrapper.
-func NewRealSlackAPI(token string) *RealSlackAPI {
+// NewRealSlackAPI creates a new real Slack API wrapper. Options are passed
+// to slack.New.
+func NewRealSlackAPI(token string, options ...slack.Option) *RealSlackAPI {
 	return &RealSlackAPI{
-		client: slack.New(token),
+		client: slack.New(token, options...),
 	}
 }
 
-func (r *RealSlackAPI) AuthTest() (*slack.AuthTestResponse, error) {
-	return r.client.AuthTest()
+func (r *RealSlackAPI) AuthTest(ctx context.Context) (*slack.AuthTestResponse, error) {
+	resp, err := r.client.AuthTestContext(ctx)
+	return resp, classifyError(err)
 }
 
-func (r *RealSlackAPI) GetConversations(params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
-	return r.client.GetConversations(params)
+func (r *RealSlackAPI) GetConversations(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
+	channels, cursor, err := r.client.GetConversationsContext(ctx, params)
+	return channels, cursor, classifyError(err)
 }
 
-func (r *RealSlackAPI) GetConversationHistory(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
-	return r.client.GetConversationHistory(params)
+func (r *RealSlackAPI) GetConversationHistory(ctx context.Context, params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+	history, err := r.client.GetConversationHistoryContext(ctx, params)
+	return history, classifyError(err)
+}
+
+// GetConversationReplies fetches a single page of a thread, parent first,
+// and returns the cursor for the next page ("" when complete).
+func (r *RealSlackAPI) GetConversationReplies(ctx context.Context, params *slack.GetConversationRepliesParameters) ([]slack.Message, string, error) {
+	messages, hasMore, cursor, err := r.client.GetConversationRepliesContext(ctx, params)
+	if !hasMore {
+		cursor = ""
+	}
+	return messages, cursor, classifyError(err)
 }
 
-func (r *RealSlackAPI) PostMessage(channelID string, options ...slack.MsgOption) (string, string, error) {
-	return r.client.PostMessage(channelID, options...)
+func (r *RealSlackAPI) PostMessage(ctx context.Context, channelID string, options ...slack.MsgOption) (string, string, error) {
+	respChannel, timestamp, err := r.client.PostMessageContext(ctx, channelID, options...)
+	return respChannel, timestamp, classifyError(err)
 }
 
-func (r *RealSlackAPI) ArchiveConversation(channelID string) error {
-	return r.client.ArchiveConversation(channelID)
+func (r *RealSlackAPI) ArchiveConversation(ctx context.Context, channelID string) error {
+	return classifyError(r.client.ArchiveConversationContext(ctx, channelID))
 }
 
-func (r *RealSlackAPI) JoinConversation(channelID string) (*slack.Channel, string, []string, error) {
-	return r.client.JoinConversation(channelID)
+func (r *RealSlackAPI) UnarchiveConversation(ctx context.Context, channelID string) error {
+	return classifyError(r.client.UnArchiveConversationContext(ctx, channelID))
 }
 
-func (r *RealSlackAPI) GetUsers() ([]slack.User, error) {
-	return r.client.GetUsers()
+func (r *RealSlackAPI) JoinConversation(ctx context.Context, channelID string) (*slack.Channel, string, []string, error) {
+	channel, warning, warnings, err := r.client.JoinConversationContext(ctx, channelID)
+	return channel, warning, warnings, classifyError(err)
+}
+
+// GetReactions returns the reactions on an item, such as a message.
+func (r *RealSlackAPI) GetReactions(ctx context.Context, item slack.ItemRef, params slack.GetReactionsParameters) ([]slack.ItemReaction, error) {
+	reacted, err := r.client.GetReactionsContext(ctx, item, params)
+	return reacted.Reactions, classifyError(err)
+}
+
+// GetUsersPage fetches a single page of users.list starting at cursor and
+// returns the cursor for the next page ("" when complete).
+func (r *RealSlackAPI) GetUsersPage(ctx context.Context, cursor string, limit int) ([]slack.User, string, error) {
+	page := r.client.GetUsersPaginated(slack.GetUsersOptionLimit(limit), slack.GetUsersOptionCursor(cursor))
+	page, err := page.Next(ctx)
+	if err != nil {
+		return nil, "", classifyError(err)
+	}
+	return page.Users, page.Cursor, nil
 }
 
-func (r *RealSlackAPI) GetTeamInfo() (*slack.TeamInfo, error) {
-	return r.client.GetTeamInfo()
+func (r *RealSlackAPI) GetTeamInfo(ctx context.Context) (*slack.TeamInfo, error) {
+	info, err := r.client.GetTeamInfoContext(ctx)
+	return info, classifyError(err)
 }
 
-func (r *RealSlackAPI) GetConversationInfo(input *slack.GetConversationInfoInput) (*slack.Channel, error) {
-	return r.client.GetConversationInfo(input)
+func (r *RealSlackAPI) GetConversationInfo(ctx context.Context, input *slack.GetConversationInfoInput) (*slack.Channel, error) {
+	channel, err := r.client.GetConversationInfoContext(ctx, input)
+	return channel, classifyError(err)
 }
 
-func (r *RealSlackAPI) GetConversationsForUser(params *slack.GetConversationsForUserParameters) ([]slack.Channel, string, error) {
-	return r.client.GetConversationsForUser(params)
+func (r *RealSlackAPI) GetConversationsForUser(ctx context.Context, params *slack.GetConversationsForUserParameters) ([]slack.Channel, string, error) {
+	channels, cursor, err := r.client.GetConversationsForUserContext(ctx, params)
+	return channels, cursor, classifyError(err)
 }
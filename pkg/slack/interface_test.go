@@ -1,6 +1,7 @@
 package slack
 
 import (
+	"context"
 	"testing"
 
 	"github.com/slack-go/slack"
@@ -27,10 +28,13 @@ func TestRealSlackAPI(t *testing.T) {
 		assert.NotNil(t, api.AuthTest)
 		assert.NotNil(t, api.GetConversations)
 		assert.NotNil(t, api.GetConversationHistory)
+		assert.NotNil(t, api.GetConversationReplies)
 		assert.NotNil(t, api.PostMessage)
 		assert.NotNil(t, api.ArchiveConversation)
+		assert.NotNil(t, api.UnarchiveConversation)
 		assert.NotNil(t, api.JoinConversation)
-		assert.NotNil(t, api.GetUsers)
+		assert.NotNil(t, api.GetReactions)
+		assert.NotNil(t, api.GetUsersPage)
 		assert.NotNil(t, api.GetTeamInfo)
 	})
 
@@ -73,37 +77,37 @@ func TestRealSlackAPI(t *testing.T) {
 		api := NewRealSlackAPI(token)
 
 		// Test AuthTest delegation
-		_, err := api.AuthTest()
+		_, err := api.AuthTest(context.Background())
 		assert.Error(t, err, "AuthTest should fail with invalid token")
 
 		// Test GetConversations delegation
-		_, _, err = api.GetConversations(&slack.GetConversationsParameters{})
+		_, _, err = api.GetConversations(context.Background(), &slack.GetConversationsParameters{})
 		assert.Error(t, err, "GetConversations should fail with invalid token")
 
 		// Test GetConversationHistory delegation
-		_, err = api.GetConversationHistory(&slack.GetConversationHistoryParameters{
+		_, err = api.GetConversationHistory(context.Background(), &slack.GetConversationHistoryParameters{
 			ChannelID: "C123456",
 		})
 		assert.Error(t, err, "GetConversationHistory should fail with invalid token")
 
 		// Test PostMessage delegation
-		_, _, err = api.PostMessage("C123456")
+		_, _, err = api.PostMessage(context.Background(), "C123456")
 		assert.Error(t, err, "PostMessage should fail with invalid token")
 
 		// Test ArchiveConversation delegation
-		err = api.ArchiveConversation("C123456")
+		err = api.ArchiveConversation(context.Background(), "C123456")
 		assert.Error(t, err, "ArchiveConversation should fail with invalid token")
 
 		// Test JoinConversation delegation
-		_, _, _, err = api.JoinConversation("C123456")
+		_, _, _, err = api.JoinConversation(context.Background(), "C123456")
 		assert.Error(t, err, "JoinConversation should fail with invalid token")
 
-		// Test GetUsers delegation
-		_, err = api.GetUsers()
-		assert.Error(t, err, "GetUsers should fail with invalid token")
+		// Test GetUsersPage delegation
+		_, _, err = api.GetUsersPage(context.Background(), "", 1)
+		assert.Error(t, err, "GetUsersPage should fail with invalid token")
 
 		// Test GetTeamInfo delegation
-		_, err = api.GetTeamInfo()
+		_, err = api.GetTeamInfo(context.Background())
 		assert.Error(t, err, "GetTeamInfo should fail with invalid token")
 	})
 }
@@ -0,0 +1,48 @@
+package slack
+
+import (
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// DefaultKeepReaction is the emoji, without colons, members react to a
+// warning with to vote to keep the channel, unless SetKeepReaction says
+// otherwise. It is a custom emoji the workspace needs to add.
+const DefaultKeepReaction = "keep"
+
+// SetKeepReaction sets the emoji, with or without colons, that counts as a
+// vote to keep the channel when members react to a warning with it. An empty
+// name ignores reactions.
+func (c *Client) SetKeepReaction(name string) {
+	c.keepReaction = strings.Trim(strings.TrimSpace(name), ":")
+}
+
+// KeepReaction returns the emoji that counts as a vote to keep a channel, or
+// "" when reactions are ignored.
+func (c *Client) KeepReaction() string {
+	return c.keepReaction
+}
+
+// keepVoters returns the users, other than the bot, who reacted to the
+// warning msg with the keep reaction.
+func (c *Client) keepVoters(msg *slack.Message, botUserID string) []string {
+	keepReaction := c.KeepReaction()
+	if keepReaction == "" {
+		return nil
+	}
+	var voters []string
+	for _, reaction := range msg.Reactions {
+		// Skin tone variants are reported as e.g. thumbsup::skin-tone-2.
+		name, _, _ := strings.Cut(reaction.Name, "::")
+		if !strings.EqualFold(name, keepReaction) {
+			continue
+		}
+		for _, user := range reaction.Users {
+			if user != botUserID {
+				voters = append(voters, user)
+			}
+		}
+	}
+	return voters
+}
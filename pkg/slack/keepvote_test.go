@@ -0,0 +1,78 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeepVoters(t *testing.T) {
+	client, err := NewClientWithAPI(NewMockSlackAPI())
+	require.NoError(t, err)
+	assert.Equal(t, DefaultKeepReaction, client.KeepReaction())
+
+	msg := &slack.Message{Msg: slack.Msg{Reactions: []slack.ItemReaction{
+		{Name: "eyes", Users: []string{"U1"}},
+		{Name: "keep", Users: []string{"U2", "U0000000"}},
+		{Name: "keep::skin-tone-3", Users: []string{"U3"}},
+	}}}
+	assert.Equal(t, []string{"U2", "U3"}, client.keepVoters(msg, "U0000000"))
+
+	client.SetKeepReaction(":eyes:")
+	assert.Equal(t, "eyes", client.KeepReaction())
+	assert.Equal(t, []string{"U1"}, client.keepVoters(msg, "U0000000"))
+
+	client.SetKeepReaction("")
+	assert.Nil(t, client.keepVoters(msg, "U0000000"))
+}
+
+func TestKeepVoteInactivityAnalysis(t *testing.T) {
+	now := time.Now()
+	ts := func(t time.Time) string { return fmt.Sprintf("%d.000000", t.Unix()) }
+	analyze := func(t *testing.T, reactions []slack.ItemReaction, keepReaction string) ([]Channel, []Channel, *Client) {
+		mockAPI := NewMockSlackAPI()
+		mockAPI.AddUser("U1", "alice", "Alice")
+		mockAPI.AddChannel("C1", "quiet", now.Add(-200*24*time.Hour), "")
+		mockAPI.SetChannelHistory("C1", []MockHistoryMessage{
+			{Timestamp: ts(now.Add(-100 * 24 * time.Hour)), User: "U1", Text: "last real message"},
+			{Timestamp: ts(now.Add(-40 * 24 * time.Hour)), User: "U0000000", Text: "🚨 Inactive Channel Warning 🚨", Reactions: reactions},
+		})
+		client, err := NewClientWithAPI(mockAPI)
+		require.NoError(t, err)
+		client.SetKeepReaction(keepReaction)
+		userMap, err := client.GetUserMap(context.Background())
+		require.NoError(t, err)
+		toWarn, toArchive, _, err := client.GetInactiveChannelsWithDetailsAndExclusions(context.Background(), 45*secondsPerDay, 30*secondsPerDay, userMap, nil, nil, false, false, 0)
+		require.NoError(t, err)
+		return toWarn, toArchive, client
+	}
+	votes := []slack.ItemReaction{{Name: "keep", Count: 2, Users: []string{"U1", "U2"}}}
+
+	t.Run("Vote restarts the grace period", func(t *testing.T) {
+		toWarn, toArchive, client := analyze(t, votes, DefaultKeepReaction)
+		assert.Empty(t, toArchive)
+		require.Len(t, toWarn, 1)
+		assert.Equal(t, []string{"Alice", "U2"}, toWarn[0].KeepVoters)
+		message := client.FormatInactiveChannelWarning(toWarn[0], 45*secondsPerDay, 30*secondsPerDay, "")
+		assert.Contains(t, message, "Members voted to keep this channel")
+		assert.Contains(t, message, "React to this message with :keep:")
+	})
+
+	t.Run("No vote", func(t *testing.T) {
+		toWarn, toArchive, _ := analyze(t, []slack.ItemReaction{{Name: "eyes", Users: []string{"U1"}}}, DefaultKeepReaction)
+		assert.Empty(t, toWarn)
+		assert.Len(t, toArchive, 1)
+	})
+
+	t.Run("Reactions ignored", func(t *testing.T) {
+		toWarn, toArchive, client := analyze(t, votes, "")
+		assert.Empty(t, toWarn)
+		require.Len(t, toArchive, 1)
+		assert.NotContains(t, client.FormatInactiveChannelWarning(toArchive[0], 45*secondsPerDay, 30*secondsPerDay, ""), "React to this message")
+	})
+}
@@ -0,0 +1,67 @@
+package slack
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/astrostl/slack-butler/pkg/logger"
+
+	"github.com/slack-go/slack"
+)
+
+// KeepMarker in a channel's topic or purpose protects it from archival.
+const KeepMarker = "[butler:keep]"
+
+// markerPattern finds [butler:...] markers, e.g. [butler:keep] or
+// [butler:archive-after=120d].
+var markerPattern = regexp.MustCompile(`(?i)\[butler:([a-z-]+)(?:=([^\]]*))?\]`)
+
+// markerRule returns the policy rule set by the first valid marker in the
+// channel's topic or purpose, or nil. Channel owners use markers to opt
+// their channel out of archival ([butler:keep]) or to give it a longer
+// inactivity threshold ([butler:archive-after=120d]); see policyParams for
+// how markers combine with the archive policy. Invalid markers are ignored
+// with a warning, logged once per run, so owners notice a typo.
+func (c *Client) markerRule(ch slack.Channel) *PolicyRule {
+	for _, text := range []string{ch.Topic.Value, ch.Purpose.Value} {
+		for _, match := range markerPattern.FindAllStringSubmatch(text, -1) {
+			if rule := parseMarker(match[0], strings.ToLower(match[1]), match[2]); rule != nil {
+				return rule
+			}
+			c.warnAboutMarker(ch, match[0], "Ignoring invalid channel marker")
+		}
+	}
+	return nil
+}
+
+// warnAboutMarker logs a warning about a channel's marker, once per client,
+// since a channel's markers are read both to pre-filter and to analyze it.
+func (c *Client) warnAboutMarker(ch slack.Channel, marker, message string) {
+	if _, logged := c.markerWarnings.LoadOrStore(ch.ID+"\x00"+marker+"\x00"+message, true); logged {
+		return
+	}
+	logger.WithFields(logger.LogFields{
+		"channel": ch.Name,
+		"marker":  marker,
+	}).Warn(message)
+}
+
+// parseMarker returns the rule for a marker, or nil if it is invalid.
+func parseMarker(marker, name, value string) *PolicyRule {
+	switch name {
+	case "keep":
+		if value != "" {
+			return nil
+		}
+		return &PolicyRule{Name: marker, Never: true, fromMarker: true}
+	case "archive-after":
+		days, err := strconv.ParseFloat(strings.TrimSuffix(strings.ToLower(value), "d"), 64)
+		if err != nil || days <= 0 {
+			return nil
+		}
+		return &PolicyRule{Name: marker, WarnDays: days, fromMarker: true}
+	default:
+		return nil
+	}
+}
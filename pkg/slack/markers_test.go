@@ -0,0 +1,99 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkerRule(t *testing.T) {
+	client, err := NewClientWithAPI(NewMockSlackAPI())
+	require.NoError(t, err)
+	markerRule := client.markerRule
+	withTopic := func(topic, purpose string) slack.Channel {
+		ch := policyTestChannel("team-x", 5, false)
+		ch.Topic.Value = topic
+		ch.Purpose.Value = purpose
+		return ch
+	}
+
+	assert.Nil(t, markerRule(withTopic("Weekly sync notes", "")))
+
+	rule := markerRule(withTopic("Release planning [butler:keep]", ""))
+	require.NotNil(t, rule)
+	assert.True(t, rule.Never)
+	assert.Equal(t, "[butler:keep]", rule.Describe())
+
+	rule = markerRule(withTopic("", "Quarterly reviews [Butler:Archive-After=120d]"))
+	require.NotNil(t, rule)
+	assert.Equal(t, 120.0, rule.WarnDays)
+	assert.False(t, rule.Never)
+
+	rule = markerRule(withTopic("[butler:archive-after=soon]", "[butler:archive-after=7.5]"))
+	require.NotNil(t, rule, "invalid markers are skipped")
+	assert.Equal(t, 7.5, rule.WarnDays)
+
+	assert.Nil(t, markerRule(withTopic("[butler:keep=forever] [butler:snooze]", "[butler:archive-after=0d]")))
+}
+
+func TestMarkerInactivityAnalysis(t *testing.T) {
+	mockAPI := NewMockSlackAPI()
+	client, err := NewClientWithAPI(mockAPI)
+	require.NoError(t, err)
+
+	created := time.Now().Add(-200 * 24 * time.Hour)
+	markers := map[string]string{
+		"kept":       "Legal hold [butler:keep]",
+		"slow":       "Quarterly planning [butler:archive-after=90d]",
+		"misc":       "",
+		"hasty":      "Short-lived [butler:archive-after=0.01d]",
+		"legal-docs": "Done soon [butler:archive-after=1d]",
+	}
+	for i, name := range []string{"kept", "slow", "misc", "hasty", "legal-docs"} {
+		id := fmt.Sprintf("C%d", i+1)
+		mockAPI.AddChannel(id, name, created, markers[name])
+		ts := fmt.Sprintf("%d.000000", time.Now().Add(-60*24*time.Hour).Unix())
+		mockAPI.SetChannelHistory(id, []MockHistoryMessage{{Timestamp: ts, User: "U1", Text: "hello"}})
+	}
+
+	policy, err := NewArchivePolicy([]PolicyRule{
+		{Name: "legal", Glob: "legal-*", Never: true},
+		{Glob: "*", WarnDays: 30, ArchiveDays: 60},
+	})
+	require.NoError(t, err)
+	client.SetArchivePolicy(policy)
+
+	warnSeconds, archiveSeconds := 45*secondsPerDay, 30*secondsPerDay
+	toWarn, toArchive, _, err := client.GetInactiveChannelsWithDetailsAndExclusions(context.Background(), warnSeconds, archiveSeconds, map[string]string{}, nil, nil, false, false, 0)
+	require.NoError(t, err)
+	assert.Empty(t, toArchive)
+	// slow's marker lengthens the policy's threshold; hasty's can't shorten
+	// it and legal-docs' can't override the policy protecting it.
+	require.Equal(t, []string{"misc", "hasty"}, channelNames(toWarn))
+	assert.Equal(t, "glob *", toWarn[0].PolicyRule)
+	hasty := toWarn[1]
+	assert.Equal(t, "[butler:archive-after=0.01d]", hasty.Marker)
+	assert.Empty(t, hasty.PolicyRule)
+	assert.Equal(t, 30*secondsPerDay, hasty.WarnSeconds)
+	assert.Equal(t, 60*secondsPerDay, hasty.ArchiveSeconds)
+	assert.Contains(t, client.FormatInactiveChannelWarning(hasty, warnSeconds, archiveSeconds, ""), "more than 30 days")
+	assert.Contains(t, client.FormatInactiveChannelWarning(hasty, warnSeconds, archiveSeconds, ""), "Add [butler:keep] to the channel topic or purpose")
+
+	_, stats := client.preFilterChannelsWithExclusions(mockAPI.Channels, time.Now().Add(-time.Duration(warnSeconds)*time.Second), nil, nil)
+	assert.Equal(t, []string{"kept"}, stats.keptByMarker)
+	assert.Equal(t, 1, stats.skippedPolicy)
+
+	t.Run("Without a policy markers can't go below the command's threshold", func(t *testing.T) {
+		client.SetArchivePolicy(nil)
+		toWarn, _, _, err := client.GetInactiveChannelsWithDetailsAndExclusions(context.Background(), warnSeconds, archiveSeconds, map[string]string{}, nil, nil, false, false, 0)
+		require.NoError(t, err)
+		require.Equal(t, []string{"misc", "hasty", "legal-docs"}, channelNames(toWarn))
+		assert.Equal(t, 45*secondsPerDay, toWarn[1].WarnSeconds)
+		assert.Zero(t, toWarn[1].ArchiveSeconds)
+	})
+}
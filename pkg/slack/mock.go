@@ -1,8 +1,14 @@
 package slack
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/slack-go/slack"
@@ -21,27 +27,51 @@ type MockSlackAPI struct {
 	AuthTestError               error
 	GetConversationsError       error
 	GetConversationHistoryError error
+	GetConversationRepliesError error
 	PostMessageError            error
 	ArchiveConversationError    error
 	JoinConversationError       error
+	GetReactionsError           error
 	GetUsersError               error
 	GetTeamInfoError            error
 
 	// Map fields (8 bytes each on 64-bit) - grouped together
 	ConversationHistory       map[string][]slack.Message
 	ConversationHistoryErrors map[string]error
+	// ThreadReplies holds the replies to each thread, oldest first, keyed by
+	// channel ID and parent timestamp (see threadKey).
+	ThreadReplies             map[string][]slack.Message
 	ArchiveConversationErrors map[string]error
-	JoinConversationErrors    map[string]error
+	// UnarchiveConversationErrors holds errors returned when unarchiving the
+	// keyed channel.
+	UnarchiveConversationErrors map[string]error
+	JoinConversationErrors      map[string]error
+	// PageErrors holds one-shot errors returned by paginated list calls for
+	// the keyed cursor ("" is the first page); each is cleared once returned.
+	PageErrors map[string]error
 
 	// Pointer fields (8 bytes each on 64-bit) - at end to minimize padding
 	AuthTestResponse *slack.AuthTestResponse
 	TeamInfo         *slack.TeamInfo
 	// Slice fields (24 bytes each on 64-bit)
-	Channels         []slack.Channel
-	PostedMessages   []MockMessage
-	ArchivedChannels []string
-	JoinedChannels   []string
-	Users            []slack.User
+	Channels           []slack.Channel
+	PostedMessages     []MockMessage
+	ArchivedChannels   []string
+	UnarchivedChannels []string
+	JoinedChannels     []string
+	Users              []slack.User
+
+	// PageSize splits paginated list responses into pages of this many items
+	// when > 0; by default every list call returns a single page.
+	PageSize int
+	// GetConversationsCalls counts GetConversations invocations (one per page).
+	GetConversationsCalls int
+	// GetConversationRepliesCalls counts GetConversationReplies invocations.
+	GetConversationRepliesCalls int
+
+	// mu serializes the SlackAPI methods so the mock can back a Client used
+	// from several goroutines.
+	mu sync.Mutex
 }
 
 type MockMessage struct {
@@ -57,47 +87,129 @@ func NewMockSlackAPI() *MockSlackAPI {
 			UserID: "U0000000", // Bot's user ID for filtering
 			Team:   "Test Team",
 			TeamID: "T0000000",
+			Header: scopesHeader(KnownScopes),
 		},
 		TeamInfo: &slack.TeamInfo{
 			ID:     "T0000000",
 			Name:   "Test Team",
 			Domain: "testteam",
 		},
-		Channels:                  []slack.Channel{},
-		ConversationHistory:       make(map[string][]slack.Message),
-		ConversationHistoryErrors: make(map[string]error),
-		PostedMessages:            []MockMessage{},
-		ArchivedChannels:          []string{},
-		ArchiveConversationErrors: make(map[string]error),
-		JoinedChannels:            []string{},
-		JoinConversationErrors:    make(map[string]error),
-		Users:                     []slack.User{},
+		Channels:                    []slack.Channel{},
+		ConversationHistory:         make(map[string][]slack.Message),
+		ConversationHistoryErrors:   make(map[string]error),
+		ThreadReplies:               make(map[string][]slack.Message),
+		PostedMessages:              []MockMessage{},
+		ArchivedChannels:            []string{},
+		ArchiveConversationErrors:   make(map[string]error),
+		UnarchiveConversationErrors: make(map[string]error),
+		JoinedChannels:              []string{},
+		JoinConversationErrors:      make(map[string]error),
+		Users:                       []slack.User{},
+		PageErrors:                  make(map[string]error),
+	}
+}
+
+func (m *MockSlackAPI) AuthTest(ctx context.Context) (*slack.AuthTestResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
-}
-
-func (m *MockSlackAPI) AuthTest() (*slack.AuthTestResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if m.AuthTestError != nil {
-		return nil, m.AuthTestError
+		return nil, mockError(m.AuthTestError)
 	}
 	return m.AuthTestResponse, nil
 }
 
-func (m *MockSlackAPI) GetConversations(params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
+func (m *MockSlackAPI) GetConversations(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.GetConversationsCalls++
 	if m.GetConversationsError != nil {
-		return nil, "", m.GetConversationsError
+		return nil, "", mockError(m.GetConversationsError)
+	}
+	cursor := ""
+	if params != nil {
+		cursor = params.Cursor
+	}
+	if err := m.takePageError(cursor); err != nil {
+		return nil, "", mockError(err)
+	}
+	return mockPage(m.Channels, cursor, m.PageSize)
+}
+
+// mockError converts a configured error into the typed error RealSlackAPI
+// would return. Plain errors whose text looks like a Slack error code, such
+// as fmt.Errorf("missing_scope"), are treated as Slack error responses.
+func mockError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var response slack.SlackErrorResponse
+	if !errors.As(err, &response) && isSlackErrorCode(err.Error()) {
+		err = slack.SlackErrorResponse{Err: err.Error()}
+	}
+	return classifyError(err)
+}
+
+// isSlackErrorCode reports whether s has the form of a Slack error code.
+func isSlackErrorCode(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// takePageError returns and clears the one-shot error registered for cursor.
+func (m *MockSlackAPI) takePageError(cursor string) error {
+	err, exists := m.PageErrors[cursor]
+	if !exists {
+		return nil
+	}
+	delete(m.PageErrors, cursor)
+	return err
+}
+
+// mockPage slices items into the page starting at cursor (a decimal offset).
+// A pageSize of zero or less returns everything as a single page.
+func mockPage[T any](items []T, cursor string, pageSize int) ([]T, string, error) {
+	start := 0
+	if cursor != "" {
+		offset, err := strconv.Atoi(cursor)
+		if err != nil || offset < 0 || offset > len(items) {
+			return nil, "", fmt.Errorf("invalid_cursor")
+		}
+		start = offset
 	}
-	return m.Channels, "", nil
+	if pageSize <= 0 || start+pageSize >= len(items) {
+		return items[start:], "", nil
+	}
+	end := start + pageSize
+	return items[start:end], strconv.Itoa(end), nil
 }
 
-func (m *MockSlackAPI) GetConversationHistory(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+func (m *MockSlackAPI) GetConversationHistory(ctx context.Context, params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	// Check for channel-specific errors first
 	if err, exists := m.ConversationHistoryErrors[params.ChannelID]; exists && err != nil {
-		return nil, err
+		return nil, mockError(err)
 	}
 
 	// Check for global error
 	if m.GetConversationHistoryError != nil {
-		return nil, m.GetConversationHistoryError
+		return nil, mockError(m.GetConversationHistoryError)
 	}
 
 	messages, exists := m.ConversationHistory[params.ChannelID]
@@ -117,9 +229,45 @@ func (m *MockSlackAPI) GetConversationHistory(params *slack.GetConversationHisto
 	}, nil
 }
 
-func (m *MockSlackAPI) PostMessage(channelID string, options ...slack.MsgOption) (string, string, error) {
+// GetConversationReplies returns a thread's parent followed by its replies
+// newer than params.Oldest, as a single page.
+func (m *MockSlackAPI) GetConversationReplies(ctx context.Context, params *slack.GetConversationRepliesParameters) ([]slack.Message, string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.GetConversationRepliesCalls++
+	if m.GetConversationRepliesError != nil {
+		return nil, "", mockError(m.GetConversationRepliesError)
+	}
+
+	var messages []slack.Message
+	for _, msg := range m.ConversationHistory[params.ChannelID] {
+		if msg.Timestamp == params.Timestamp {
+			messages = append(messages, msg)
+		}
+	}
+	if len(messages) == 0 {
+		return nil, "", mockError(errors.New("thread_not_found"))
+	}
+	oldest, _ := strconv.ParseFloat(params.Oldest, 64)
+	for _, reply := range m.ThreadReplies[threadKey(params.ChannelID, params.Timestamp)] {
+		if ts, _ := strconv.ParseFloat(reply.Timestamp, 64); ts > oldest {
+			messages = append(messages, reply)
+		}
+	}
+	return messages, "", nil
+}
+
+func (m *MockSlackAPI) PostMessage(ctx context.Context, channelID string, options ...slack.MsgOption) (string, string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", "", err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if m.PostMessageError != nil {
-		return "", "", m.PostMessageError
+		return "", "", mockError(m.PostMessageError)
 	}
 
 	// For testing purposes, we'll just record that a message was posted
@@ -133,30 +281,96 @@ func (m *MockSlackAPI) PostMessage(channelID string, options ...slack.MsgOption)
 	return "mock-channel-id", "mock-timestamp", nil
 }
 
-func (m *MockSlackAPI) ArchiveConversation(channelID string) error {
+func (m *MockSlackAPI) ArchiveConversation(ctx context.Context, channelID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	// Check for channel-specific errors first
 	if err, exists := m.ArchiveConversationErrors[channelID]; exists && err != nil {
-		return err
+		return mockError(err)
 	}
 
 	// Check for global error
 	if m.ArchiveConversationError != nil {
-		return m.ArchiveConversationError
+		return mockError(m.ArchiveConversationError)
 	}
 
 	m.ArchivedChannels = append(m.ArchivedChannels, channelID)
+	m.setArchived(channelID, true)
 	return nil
 }
 
-func (m *MockSlackAPI) JoinConversation(channelID string) (*slack.Channel, string, []string, error) {
+// GetReactions returns the reactions on a message or thread reply in
+// ConversationHistory or ThreadReplies.
+func (m *MockSlackAPI) GetReactions(ctx context.Context, item slack.ItemRef, params slack.GetReactionsParameters) ([]slack.ItemReaction, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.GetReactionsError != nil {
+		return nil, mockError(m.GetReactionsError)
+	}
+
+	messages := slices.Clone(m.ConversationHistory[item.Channel])
+	for key, replies := range m.ThreadReplies {
+		if strings.HasPrefix(key, threadKey(item.Channel, "")) {
+			messages = append(messages, replies...)
+		}
+	}
+	for _, msg := range messages {
+		if msg.Timestamp == item.Timestamp {
+			return msg.Reactions, nil
+		}
+	}
+	return nil, mockError(errors.New("message_not_found"))
+}
+
+func (m *MockSlackAPI) UnarchiveConversation(ctx context.Context, channelID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err, exists := m.UnarchiveConversationErrors[channelID]; exists && err != nil {
+		return mockError(err)
+	}
+	for _, ch := range m.Channels {
+		if ch.ID == channelID && !ch.IsArchived {
+			return mockError(fmt.Errorf("not_archived"))
+		}
+	}
+
+	m.UnarchivedChannels = append(m.UnarchivedChannels, channelID)
+	m.setArchived(channelID, false)
+	return nil
+}
+
+// setArchived marks the mock channel with the given ID as archived or not.
+func (m *MockSlackAPI) setArchived(channelID string, archived bool) {
+	for i := range m.Channels {
+		if m.Channels[i].ID == channelID {
+			m.Channels[i].IsArchived = archived
+		}
+	}
+}
+
+func (m *MockSlackAPI) JoinConversation(ctx context.Context, channelID string) (*slack.Channel, string, []string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, "", nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	// Check for channel-specific errors first
 	if err, exists := m.JoinConversationErrors[channelID]; exists && err != nil {
-		return nil, "", nil, err
+		return nil, "", nil, mockError(err)
 	}
 
 	// Check for global error
 	if m.JoinConversationError != nil {
-		return nil, "", nil, m.JoinConversationError
+		return nil, "", nil, mockError(m.JoinConversationError)
 	}
 
 	m.JoinedChannels = append(m.JoinedChannels, channelID)
@@ -173,34 +387,61 @@ func (m *MockSlackAPI) JoinConversation(channelID string) (*slack.Channel, strin
 	return mockChannel, "", []string{}, nil
 }
 
-func (m *MockSlackAPI) GetUsers() ([]slack.User, error) {
+func (m *MockSlackAPI) GetUsersPage(ctx context.Context, cursor string, limit int) ([]slack.User, string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if m.GetUsersError != nil {
-		return nil, m.GetUsersError
+		return nil, "", mockError(m.GetUsersError)
+	}
+	if err := m.takePageError(cursor); err != nil {
+		return nil, "", mockError(err)
 	}
-	return m.Users, nil
+	return mockPage(m.Users, cursor, m.PageSize)
 }
 
-func (m *MockSlackAPI) GetTeamInfo() (*slack.TeamInfo, error) {
+func (m *MockSlackAPI) GetTeamInfo(ctx context.Context) (*slack.TeamInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if m.GetTeamInfoError != nil {
-		return nil, m.GetTeamInfoError
+		return nil, mockError(m.GetTeamInfoError)
 	}
 	return m.TeamInfo, nil
 }
 
-func (m *MockSlackAPI) GetConversationInfo(input *slack.GetConversationInfoInput) (*slack.Channel, error) {
+func (m *MockSlackAPI) GetConversationInfo(ctx context.Context, input *slack.GetConversationInfoInput) (*slack.Channel, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	// Find channel by ID in the mock channels list
 	for _, ch := range m.Channels {
 		if ch.ID == input.ChannelID {
 			return &ch, nil
 		}
 	}
-	return nil, fmt.Errorf("channel not found: %s", input.ChannelID)
+	return nil, mockError(fmt.Errorf("%s", channelNotFound))
 }
 
-func (m *MockSlackAPI) GetConversationsForUser(params *slack.GetConversationsForUserParameters) ([]slack.Channel, string, error) {
+func (m *MockSlackAPI) GetConversationsForUser(ctx context.Context, params *slack.GetConversationsForUserParameters) ([]slack.Channel, string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	// Return all channels for simplicity in tests
 	// In real implementation, this would filter by user membership
-	return m.Channels, "", nil
+	cursor := ""
+	if params != nil {
+		cursor = params.Cursor
+	}
+	return mockPage(m.Channels, cursor, m.PageSize)
 }
 
 // Helper methods for testing
@@ -246,6 +487,27 @@ func (m *MockSlackAPI) AddExtSharedChannel(id, name string, created time.Time, p
 	m.Channels = append(m.Channels, channel)
 }
 
+// AddPrivateChannel appends a private channel; isMember reports whether the
+// bot has been invited to it.
+func (m *MockSlackAPI) AddPrivateChannel(id, name string, created time.Time, purpose string, isMember bool) {
+	channel := slack.Channel{
+		GroupConversation: slack.GroupConversation{
+			Conversation: slack.Conversation{
+				ID:        id,
+				Created:   slack.JSONTime(created.Unix()),
+				IsPrivate: true,
+			},
+			Name: name,
+			Purpose: slack.Purpose{
+				Value: purpose,
+			},
+			Creator: "U1234567",
+		},
+		IsMember: isMember,
+	}
+	m.Channels = append(m.Channels, channel)
+}
+
 func (m *MockSlackAPI) SetAuthError(hasError bool) {
 	if hasError {
 		m.AuthTestError = fmt.Errorf("authentication failed")
@@ -471,6 +733,15 @@ type MockHistoryMessage struct {
 	User      string
 	Text      string
 	SubType   string
+	Reactions []slack.ItemReaction
+	// Replies makes the message a thread parent with these replies, oldest
+	// first.
+	Replies []MockHistoryMessage
+}
+
+// threadKey keys MockSlackAPI.ThreadReplies.
+func threadKey(channelID, threadTS string) string {
+	return channelID + "/" + threadTS
 }
 
 // SetChannelHistory sets up mock conversation history for a channel.
@@ -488,13 +759,73 @@ func (m *MockSlackAPI) SetChannelHistory(channelID string, messages []MockHistor
 				User:      msg.User,
 				Timestamp: msg.Timestamp,
 				SubType:   msg.SubType,
+				Reactions: msg.Reactions,
 			},
 		}
+		if len(msg.Replies) > 0 {
+			m.setThreadReplies(channelID, &slackMessages[i], msg.Replies)
+		}
 	}
 
 	m.ConversationHistory[channelID] = slackMessages
 }
 
+// setThreadReplies records replies to parent and sets the parent's thread
+// metadata the way conversations.history reports it.
+func (m *MockSlackAPI) setThreadReplies(channelID string, parent *slack.Message, replies []MockHistoryMessage) {
+	if m.ThreadReplies == nil {
+		m.ThreadReplies = make(map[string][]slack.Message)
+	}
+	threadReplies := make([]slack.Message, len(replies))
+	for i, reply := range replies {
+		threadReplies[i] = slack.Message{
+			Msg: slack.Msg{
+				Type:            "message",
+				Text:            reply.Text,
+				User:            reply.User,
+				Timestamp:       reply.Timestamp,
+				ThreadTimestamp: parent.Timestamp,
+				SubType:         reply.SubType,
+				Reactions:       reply.Reactions,
+			},
+		}
+	}
+	parent.ThreadTimestamp = parent.Timestamp
+	parent.ReplyCount = len(replies)
+	parent.LatestReply = replies[len(replies)-1].Timestamp
+	m.ThreadReplies[threadKey(channelID, parent.Timestamp)] = threadReplies
+}
+
+// SetGrantedScopes replaces the OAuth scopes reported in the X-OAuth-Scopes
+// header of auth.test. By default every scope in KnownScopes is granted.
+func (m *MockSlackAPI) SetGrantedScopes(scopes ...string) {
+	if m.AuthTestResponse == nil {
+		m.AuthTestResponse = &slack.AuthTestResponse{}
+	}
+	m.AuthTestResponse.Header = scopesHeader(scopes)
+}
+
+// RevokeScopes removes scopes from the granted OAuth scopes.
+func (m *MockSlackAPI) RevokeScopes(scopes ...string) {
+	var granted []string
+	if m.AuthTestResponse != nil {
+		for scope := range parseScopes(m.AuthTestResponse.Header) {
+			if !slices.Contains(scopes, scope) {
+				granted = append(granted, scope)
+			}
+		}
+	}
+	slices.Sort(granted)
+	m.SetGrantedScopes(granted...)
+}
+
+// scopesHeader builds response headers reporting scopes as granted.
+func scopesHeader(scopes []string) http.Header {
+	header := http.Header{}
+	header.Set(oauthScopesHeader, strings.Join(scopes, ","))
+	return header
+}
+
 // SetBotUserID sets the bot user ID for testing.
 func (m *MockSlackAPI) SetBotUserID(userID string) {
 	if m.AuthTestResponse == nil {
@@ -1,6 +1,7 @@
 package slack
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -22,7 +23,7 @@ func TestNewMockSlackAPI(t *testing.T) {
 func TestMockAuthTest(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mock := NewMockSlackAPI()
-		resp, err := mock.AuthTest()
+		resp, err := mock.AuthTest(context.Background())
 		assert.NoError(t, err)
 		assert.NotNil(t, resp)
 		assert.Equal(t, "test-bot", resp.User)
@@ -32,7 +33,7 @@ func TestMockAuthTest(t *testing.T) {
 		mock := NewMockSlackAPI()
 		mock.SetAuthError(true)
 
-		resp, err := mock.AuthTest()
+		resp, err := mock.AuthTest(context.Background())
 		assert.Error(t, err)
 		assert.Nil(t, resp)
 	})
@@ -46,7 +47,7 @@ func TestMockGetConversations(t *testing.T) {
 		createdTime := time.Now()
 		mock.AddChannel("C1234567890", "test-channel", createdTime, "Test purpose")
 
-		channels, cursor, err := mock.GetConversations(nil)
+		channels, cursor, err := mock.GetConversations(context.Background(), nil)
 		assert.NoError(t, err)
 		assert.Empty(t, cursor)
 		assert.Len(t, channels, 1)
@@ -58,7 +59,7 @@ func TestMockGetConversations(t *testing.T) {
 		mock := NewMockSlackAPI()
 		mock.SetGetConversationsError(true)
 
-		channels, cursor, err := mock.GetConversations(nil)
+		channels, cursor, err := mock.GetConversations(context.Background(), nil)
 		assert.Error(t, err)
 		assert.Empty(t, cursor)
 		assert.Nil(t, channels)
@@ -69,7 +70,7 @@ func TestMockPostMessage(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mock := NewMockSlackAPI()
 
-		channelID, timestamp, err := mock.PostMessage("general", nil)
+		channelID, timestamp, err := mock.PostMessage(context.Background(), "general", nil)
 		assert.NoError(t, err)
 		assert.Equal(t, "mock-channel-id", channelID)
 		assert.Equal(t, "mock-timestamp", timestamp)
@@ -83,7 +84,7 @@ func TestMockPostMessage(t *testing.T) {
 		mock := NewMockSlackAPI()
 		mock.SetPostMessageError("generic_error")
 
-		channelID, timestamp, err := mock.PostMessage("general", nil)
+		channelID, timestamp, err := mock.PostMessage(context.Background(), "general", nil)
 		assert.Error(t, err)
 		assert.Empty(t, channelID)
 		assert.Empty(t, timestamp)
@@ -106,7 +107,7 @@ func TestMockHelperMethods(t *testing.T) {
 		mock := NewMockSlackAPI()
 
 		// Post a message
-		_, _, err := mock.PostMessage("test", nil)
+		_, _, err := mock.PostMessage(context.Background(), "test", nil)
 		assert.NoError(t, err)
 		assert.Len(t, mock.GetPostedMessages(), 1)
 
@@ -1,9 +1,15 @@
 package slack
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/astrostl/slack-butler/pkg/slack/fakeslack"
+
+	"github.com/slack-go/slack"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewClient(t *testing.T) {
@@ -49,3 +55,65 @@ func TestNewClient(t *testing.T) {
 		assert.Contains(t, err.Error(), "invalid token")
 	})
 }
+
+func TestNewClientAgainstFakeSlack(t *testing.T) {
+	const token = "MOCK-BOT-TOKEN-FOR-TESTING-ONLY-NOT-REAL-TOKEN-AT-ALL"
+
+	server := fakeslack.New()
+	defer server.Close()
+	created := time.Now().Add(-time.Hour)
+	server.AddChannel("C001", "general", created, "General chat")
+	server.AddChannel("C002", "new-project", created, "")
+	server.AddChannel("C003", "old-project", time.Now().Add(-30*24*time.Hour), "")
+	server.AddMember("C001", fakeslack.BotUserID)
+
+	t.Run("Custom API URL authenticates against the server", func(t *testing.T) {
+		client, err := NewClient(token, slack.OptionAPIURL(server.URL()))
+		require.NoError(t, err)
+
+		auth, err := client.TestAuth(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, fakeslack.BotUserID, auth.UserID)
+		assert.Equal(t, fakeslack.TeamName, auth.Team)
+	})
+
+	t.Run("Detect and announce end to end", func(t *testing.T) {
+		client, err := NewClient(token, slack.OptionAPIURL(server.URL()))
+		require.NoError(t, err)
+
+		channels, err := client.GetNewChannels(context.Background(), time.Now().Add(-24*time.Hour))
+		require.NoError(t, err)
+		require.Len(t, channels, 2)
+		assert.Equal(t, "General chat", channels[0].Purpose)
+
+		require.NoError(t, client.PostMessage(context.Background(), "#general", client.FormatNewChannelAnnouncement(channels, time.Now().Add(-24*time.Hour))))
+		posted := server.PostedMessages()
+		require.Len(t, posted, 1)
+		assert.Equal(t, "C001", posted[0].ChannelID)
+		assert.Contains(t, posted[0].Text, "<#C002>")
+	})
+
+	t.Run("Slack error codes come back typed", func(t *testing.T) {
+		client, err := NewClient(token, slack.OptionAPIURL(server.URL()))
+		require.NoError(t, err)
+
+		err = client.PostMessage(context.Background(), "#new-project", "hello")
+		assert.ErrorIs(t, err, ErrNotInChannel)
+
+		server.FailWith("conversations.list", "invalid_auth")
+		defer server.FailWith("conversations.list", "")
+		_, err = client.GetNewChannels(context.Background(), time.Now().Add(-24*time.Hour))
+		assert.ErrorIs(t, err, ErrInvalidAuth)
+	})
+
+	t.Run("429 responses are retried by RateLimitedAPI", func(t *testing.T) {
+		server.RateLimit("users.list", 2, time.Second)
+		api := newTestRateLimitedAPI(NewRealSlackAPI(token, slack.OptionAPIURL(server.URL())), nil)
+		client, err := NewClientWithAPI(api)
+		require.NoError(t, err)
+
+		_, err = client.GetUserMap(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 3, server.Calls("users.list"))
+	})
+}
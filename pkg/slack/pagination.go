@@ -0,0 +1,109 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/astrostl/slack-butler/pkg/logger"
+
+	"github.com/slack-go/slack"
+)
+
+// Page sizes requested from cursor-paginated Slack list endpoints.
+// conversations.list accepts up to 1000 per page; users.list and
+// conversations.history recommend 200.
+const (
+	conversationsPageLimit = 1000
+	usersPageLimit         = 200
+	historyPageLimit       = 200
+)
+
+// pageFetcher fetches the page starting at cursor and returns its items along
+// with the cursor for the following page ("" when there are no more pages).
+type pageFetcher[T any] func(cursor string) ([]T, string, error)
+
+// paginate follows next_cursor until Slack reports no further pages and
+// returns every item collected. Any error, or cancellation of ctx, aborts the
+// walk; rate-limit retries are handled by RateLimitedAPI.
+func paginate[T any](ctx context.Context, operation string, fetch pageFetcher[T]) ([]T, error) {
+	var all []T
+	cursor := ""
+	pages := 0
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		items, nextCursor, err := fetch(cursor)
+		if err != nil {
+			return nil, err
+		}
+		pages++
+		all = append(all, items...)
+
+		if nextCursor == "" {
+			break
+		}
+		cursor = nextCursor
+	}
+
+	logger.WithFields(logger.LogFields{
+		"operation": operation,
+		"pages":     pages,
+		"items":     len(all),
+	}).Debug("Finished paginated Slack API call")
+	return all, nil
+}
+
+// getAllConversations lists every public channel (plus private channels the
+// bot belongs to when enabled), following pagination cursors.
+func (c *Client) getAllConversations(ctx context.Context, excludeArchived bool) ([]slack.Channel, error) {
+	channels, err := paginate(ctx, "conversations.list", func(cursor string) ([]slack.Channel, string, error) {
+		return c.api.GetConversations(ctx, &slack.GetConversationsParameters{
+			Types:           c.conversationTypes(),
+			Limit:           conversationsPageLimit,
+			ExcludeArchived: excludeArchived,
+			Cursor:          cursor,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return filterVisibleChannels(channels, c.includePrivate), nil
+}
+
+// filterVisibleChannels drops private channels unless includePrivate is set,
+// and always drops private channels the bot isn't a member of.
+func filterVisibleChannels(channels []slack.Channel, includePrivate bool) []slack.Channel {
+	visible := make([]slack.Channel, 0, len(channels))
+	for _, ch := range channels {
+		if ch.IsPrivate && (!includePrivate || !ch.IsMember) {
+			continue
+		}
+		visible = append(visible, ch)
+	}
+	return visible
+}
+
+// getAllUsers lists every workspace member, following pagination cursors.
+func (c *Client) getAllUsers(ctx context.Context) ([]slack.User, error) {
+	return paginate(ctx, "users.list", func(cursor string) ([]slack.User, string, error) {
+		return c.api.GetUsersPage(ctx, cursor, usersPageLimit)
+	})
+}
+
+// getAllConversationsForUser lists every public channel a user belongs to,
+// following pagination cursors.
+func (c *Client) getAllConversationsForUser(ctx context.Context, userID string) ([]slack.Channel, error) {
+	channels, err := paginate(ctx, "users.conversations", func(cursor string) ([]slack.Channel, string, error) {
+		return c.api.GetConversationsForUser(ctx, &slack.GetConversationsForUserParameters{
+			UserID: userID,
+			Types:  []string{"public_channel"},
+			Cursor: cursor,
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channels for user %s: %w", userID, err)
+	}
+	return channels, nil
+}
@@ -0,0 +1,149 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func addNumberedChannels(mock *MockSlackAPI, n int, created time.Time) {
+	for i := 0; i < n; i++ {
+		mock.AddChannel(fmt.Sprintf("C%04d", i), fmt.Sprintf("channel-%04d", i), created, "")
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	t.Run("Follows cursors until exhausted", func(t *testing.T) {
+		pages := map[string][]int{"": {1, 2}, "a": {3, 4}, "b": {5}}
+		next := map[string]string{"": "a", "a": "b", "b": ""}
+
+		items, err := paginate(context.Background(), "test", func(cursor string) ([]int, string, error) {
+			return pages[cursor], next[cursor], nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, items)
+	})
+
+	t.Run("Stops at the first error", func(t *testing.T) {
+		calls := 0
+		_, err := paginate(context.Background(), "test", func(cursor string) ([]int, string, error) {
+			calls++
+			if calls == 2 {
+				return nil, "", errors.New("rate_limited")
+			}
+			return []int{calls}, "next", nil
+		})
+		assert.EqualError(t, err, "rate_limited")
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("Does not retry errors itself", func(t *testing.T) {
+		calls := 0
+		_, err := paginate(context.Background(), "test", func(cursor string) ([]int, string, error) {
+			calls++
+			return nil, "", errors.New("invalid_auth")
+		})
+		assert.EqualError(t, err, "invalid_auth")
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestMultiPageConversations(t *testing.T) {
+	t.Run("GetNewChannels sees channels beyond the first page", func(t *testing.T) {
+		mock := NewMockSlackAPI()
+		mock.PageSize = 2
+		addNumberedChannels(mock, 5, time.Now().Add(-time.Hour))
+
+		client, err := NewClientWithAPI(mock)
+		require.NoError(t, err)
+
+		channels, err := client.GetNewChannels(context.Background(), time.Now().Add(-2*time.Hour))
+		require.NoError(t, err)
+		assert.Len(t, channels, 5)
+		assert.Equal(t, 3, mock.GetConversationsCalls)
+	})
+
+	t.Run("ResolveChannelNameToID finds channel on a later page", func(t *testing.T) {
+		mock := NewMockSlackAPI()
+		mock.PageSize = 2
+		addNumberedChannels(mock, 5, time.Now().Add(-time.Hour))
+
+		client, err := NewClientWithAPI(mock)
+		require.NoError(t, err)
+
+		id, err := client.ResolveChannelNameToID(context.Background(), "#channel-0004")
+		require.NoError(t, err)
+		assert.Equal(t, "C0004", id)
+	})
+
+	t.Run("Transient rate limit mid-walk is retried by RateLimitedAPI", func(t *testing.T) {
+		mock := NewMockSlackAPI()
+		mock.PageSize = 2
+		mock.PageErrors["2"] = &slack.RateLimitedError{RetryAfter: time.Second}
+		addNumberedChannels(mock, 5, time.Now().Add(-time.Hour))
+
+		client, err := NewClientWithAPI(newTestRateLimitedAPI(mock, nil))
+		require.NoError(t, err)
+
+		channels, err := client.GetRandomChannels(context.Background(), 10)
+		require.NoError(t, err)
+		assert.Len(t, channels, 5)
+		assert.Equal(t, 4, mock.GetConversationsCalls)
+	})
+
+	t.Run("GetChannelsWithMetadata and GetInactiveChannels paginate", func(t *testing.T) {
+		mock := NewMockSlackAPI()
+		mock.PageSize = 3
+		addNumberedChannels(mock, 7, time.Now().Add(-48*time.Hour))
+
+		client, err := NewClientWithAPI(mock)
+		require.NoError(t, err)
+
+		channels, err := client.GetChannelsWithMetadata(context.Background())
+		require.NoError(t, err)
+		assert.Len(t, channels, 7)
+
+		toWarn, _, err := client.GetInactiveChannels(context.Background(), 60, 60)
+		require.NoError(t, err)
+		assert.Len(t, toWarn, 7)
+	})
+}
+
+func TestMultiPageUsers(t *testing.T) {
+	mock := NewMockSlackAPI()
+	mock.PageSize = 2
+	for i := 0; i < 5; i++ {
+		mock.AddUser(fmt.Sprintf("U%04d", i), fmt.Sprintf("user%d", i), fmt.Sprintf("User %d", i))
+	}
+
+	client, err := NewClientWithAPI(mock)
+	require.NoError(t, err)
+
+	userMap, err := client.GetUserMap(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, userMap, 5)
+	assert.Equal(t, "User 4", userMap["U0004"])
+}
+
+func TestMockPage(t *testing.T) {
+	items := []slack.User{{ID: "U1"}, {ID: "U2"}, {ID: "U3"}}
+
+	page, next, err := mockPage(items, "", 2)
+	require.NoError(t, err)
+	assert.Len(t, page, 2)
+	assert.Equal(t, "2", next)
+
+	page, next, err = mockPage(items, next, 2)
+	require.NoError(t, err)
+	assert.Len(t, page, 1)
+	assert.Empty(t, next)
+
+	_, _, err = mockPage(items, "bogus", 2)
+	assert.Error(t, err)
+}
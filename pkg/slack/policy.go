@@ -0,0 +1,279 @@
+package slack
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// secondsPerDay converts policy thresholds, given in days, to seconds.
+const secondsPerDay = 24 * 60 * 60
+
+// PolicyRule gives the channels it matches their own inactivity thresholds,
+// or protects them from archival altogether. Every match criterion that is
+// set must hold; a rule with none matches every channel.
+type PolicyRule struct {
+	// MinMembers and MaxMembers bound the channel's member count, inclusive.
+	MinMembers *int `mapstructure:"min-members"`
+	MaxMembers *int `mapstructure:"max-members"`
+	// ExtShared matches externally shared (Slack Connect) channels when
+	// true and internal channels when false. Slack Connect channels are only
+	// considered at all with --include-ext-shared.
+	ExtShared *bool `mapstructure:"ext-shared"`
+	// Name identifies the rule in output; it defaults to its match criteria.
+	Name string `mapstructure:"name"`
+	// Glob matches channel names with path.Match syntax, e.g. tmp-*.
+	Glob string `mapstructure:"glob"`
+	// Regex matches channel names with Go regexp syntax.
+	Regex string `mapstructure:"regex"`
+	// Prefix matches channel names starting with it.
+	Prefix string `mapstructure:"prefix"`
+	// WarnDays and ArchiveDays replace --warn-days and --archive-days for
+	// matching channels; zero keeps the command's value.
+	WarnDays    float64 `mapstructure:"warn-days"`
+	ArchiveDays float64 `mapstructure:"archive-days"`
+	// Never protects matching channels from warnings and archival.
+	Never bool `mapstructure:"never"`
+
+	regex *regexp.Regexp
+	// fromMarker is set on rules read from a channel marker.
+	fromMarker bool
+}
+
+// ArchivePolicy assigns inactivity thresholds per channel. The first rule
+// matching a channel applies; channels no rule matches use the command's
+// --warn-days and --archive-days.
+type ArchivePolicy struct {
+	rules []PolicyRule
+}
+
+// NewArchivePolicy validates rules and compiles their patterns.
+func NewArchivePolicy(rules []PolicyRule) (*ArchivePolicy, error) {
+	policy := &ArchivePolicy{rules: make([]PolicyRule, len(rules))}
+	for i, rule := range rules {
+		if err := rule.compile(); err != nil {
+			return nil, fmt.Errorf("policy rule %d (%s): %w", i+1, rule.Describe(), err)
+		}
+		policy.rules[i] = rule
+	}
+	return policy, nil
+}
+
+// Rules returns the policy's rules in match order.
+func (p *ArchivePolicy) Rules() []PolicyRule {
+	if p == nil {
+		return nil
+	}
+	return p.rules
+}
+
+// Match returns the first rule matching ch, or nil.
+func (p *ArchivePolicy) Match(ch slack.Channel) *PolicyRule {
+	if p == nil {
+		return nil
+	}
+	for i := range p.rules {
+		if p.rules[i].matches(ch) {
+			return &p.rules[i]
+		}
+	}
+	return nil
+}
+
+// compile validates the rule and compiles its regex.
+func (r *PolicyRule) compile() error {
+	if err := r.validateThresholds(); err != nil {
+		return err
+	}
+	if r.MinMembers != nil && r.MaxMembers != nil && *r.MinMembers > *r.MaxMembers {
+		return errors.New("min-members is greater than max-members")
+	}
+	if r.Glob != "" {
+		if _, err := path.Match(r.Glob, ""); err != nil {
+			return fmt.Errorf("invalid glob %q: %w", r.Glob, err)
+		}
+	}
+	if r.Regex != "" {
+		re, err := regexp.Compile(r.Regex)
+		if err != nil {
+			return fmt.Errorf("invalid regex %q: %w", r.Regex, err)
+		}
+		r.regex = re
+	}
+	return nil
+}
+
+// validateThresholds checks the rule sets either thresholds or never.
+func (r *PolicyRule) validateThresholds() error {
+	if r.Never && (r.WarnDays != 0 || r.ArchiveDays != 0) {
+		return errors.New("never cannot be combined with warn-days or archive-days")
+	}
+	if r.WarnDays < 0 || r.ArchiveDays < 0 {
+		return errors.New("warn-days and archive-days must not be negative")
+	}
+	if !r.Never && r.WarnDays == 0 && r.ArchiveDays == 0 {
+		return errors.New("rule needs warn-days, archive-days or never")
+	}
+	return nil
+}
+
+// matches reports whether every criterion of the rule holds for ch.
+func (r *PolicyRule) matches(ch slack.Channel) bool {
+	if r.Glob != "" {
+		if ok, _ := path.Match(r.Glob, ch.Name); !ok { //nolint:errcheck // validated by compile
+			return false
+		}
+	}
+	if r.regex != nil && !r.regex.MatchString(ch.Name) {
+		return false
+	}
+	if r.Prefix != "" && !strings.HasPrefix(ch.Name, r.Prefix) {
+		return false
+	}
+	if r.MinMembers != nil && ch.NumMembers < *r.MinMembers {
+		return false
+	}
+	if r.MaxMembers != nil && ch.NumMembers > *r.MaxMembers {
+		return false
+	}
+	if r.ExtShared != nil && *r.ExtShared != (ch.IsExtShared || ch.IsPendingExtShared) {
+		return false
+	}
+	return true
+}
+
+// Describe returns the rule's name, or a summary of its match criteria.
+func (r *PolicyRule) Describe() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	var criteria []string
+	if r.Glob != "" {
+		criteria = append(criteria, "glob "+r.Glob)
+	}
+	if r.Regex != "" {
+		criteria = append(criteria, "regex "+r.Regex)
+	}
+	if r.Prefix != "" {
+		criteria = append(criteria, "prefix "+r.Prefix)
+	}
+	if r.MinMembers != nil {
+		criteria = append(criteria, fmt.Sprintf("members >= %d", *r.MinMembers))
+	}
+	if r.MaxMembers != nil {
+		criteria = append(criteria, fmt.Sprintf("members <= %d", *r.MaxMembers))
+	}
+	if r.ExtShared != nil {
+		criteria = append(criteria, fmt.Sprintf("ext-shared %t", *r.ExtShared))
+	}
+	if len(criteria) == 0 {
+		return "all channels"
+	}
+	return strings.Join(criteria, ", ")
+}
+
+// Thresholds returns the warn and archive thresholds, in seconds, the rule
+// applies given the command's own.
+func (r *PolicyRule) Thresholds(warnSeconds, archiveSeconds int) (int, int) {
+	if r.WarnDays > 0 {
+		warnSeconds = int(r.WarnDays * secondsPerDay)
+	}
+	if r.ArchiveDays > 0 {
+		archiveSeconds = int(r.ArchiveDays * secondsPerDay)
+	}
+	return warnSeconds, archiveSeconds
+}
+
+// SetArchivePolicy sets the policy deciding per-channel thresholds for
+// inactivity analysis. A nil policy applies the command's thresholds to
+// every channel.
+func (c *Client) SetArchivePolicy(policy *ArchivePolicy) {
+	c.policy = policy
+}
+
+// ArchivePolicy returns the configured archive policy, or nil.
+func (c *Client) ArchivePolicy() *ArchivePolicy {
+	return c.policy
+}
+
+// policyParams returns the analysis parameters for ch and the rule that
+// applies to it, if any: params with the rule's thresholds applied. It
+// returns nil parameters when the rule protects the channel from archival.
+//
+// Markers let channel owners opt out, never in: a policy rule protecting the
+// channel always wins, and [butler:archive-after] can only lengthen the
+// inactivity threshold the policy or the command would apply, keeping the
+// policy's grace period. See markerRule.
+func (c *Client) policyParams(ch slack.Channel, params channelAnalysisParams) (*channelAnalysisParams, *PolicyRule) {
+	rule := c.policy.Match(ch)
+	if rule == nil || !rule.Never {
+		if marker := c.markerRule(ch); marker != nil {
+			rule = c.limitMarker(ch, marker, rule, params)
+		}
+	}
+	if rule == nil {
+		return &params, nil
+	}
+	if rule.Never {
+		return nil, rule
+	}
+	warnSeconds, archiveSeconds := rule.Thresholds(0, params.archiveSeconds)
+	if warnSeconds > 0 {
+		params.warnCutoff = params.now.Add(-time.Duration(warnSeconds) * time.Second)
+	}
+	params.archiveSeconds = archiveSeconds
+	return &params, rule
+}
+
+// limitMarker returns the rule for a channel's marker given the policy rule
+// that matched it, if any. An archive-after marker shorter than the
+// threshold the policy or the command applies is raised to it.
+func (c *Client) limitMarker(ch slack.Channel, marker, policyRule *PolicyRule, params channelAnalysisParams) *PolicyRule {
+	if marker.Never {
+		return marker
+	}
+	limited := *marker
+	minDays := params.now.Sub(params.warnCutoff).Round(time.Second).Seconds() / secondsPerDay
+	if policyRule != nil {
+		limited.ArchiveDays = policyRule.ArchiveDays
+		if policyRule.WarnDays > 0 {
+			minDays = policyRule.WarnDays
+		}
+	}
+	if limited.WarnDays < minDays {
+		c.warnAboutMarker(ch, marker.Name, fmt.Sprintf("Channel marker is shorter than the %g-day inactivity threshold, which applies instead", minDays))
+		limited.WarnDays = minDays
+	}
+	return &limited
+}
+
+// applyPolicyRule records the rule or marker that applies to channel and its
+// thresholds.
+func applyPolicyRule(channel *Channel, rule *PolicyRule) {
+	if rule == nil {
+		return
+	}
+	if rule.fromMarker {
+		channel.Marker = rule.Name
+	} else {
+		channel.PolicyRule = rule.Describe()
+	}
+	channel.WarnSeconds, channel.ArchiveSeconds = rule.Thresholds(0, 0)
+}
+
+// Thresholds returns the warn and archive thresholds, in seconds, for the
+// channel: those set by its policy rule or marker, falling back to the given ones.
+func (ch Channel) Thresholds(warnSeconds, archiveSeconds int) (int, int) {
+	if ch.WarnSeconds > 0 {
+		warnSeconds = ch.WarnSeconds
+	}
+	if ch.ArchiveSeconds > 0 {
+		archiveSeconds = ch.ArchiveSeconds
+	}
+	return warnSeconds, archiveSeconds
+}
@@ -0,0 +1,147 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func policyTestChannel(name string, members int, extShared bool) slack.Channel {
+	ch := slack.Channel{GroupConversation: slack.GroupConversation{Name: name}}
+	ch.NumMembers = members
+	ch.IsExtShared = extShared
+	return ch
+}
+
+func TestNewArchivePolicy(t *testing.T) {
+	two, one := 2, 1
+	tests := []struct {
+		name    string
+		rule    PolicyRule
+		wantErr string
+	}{
+		{"Thresholds", PolicyRule{Glob: "tmp-*", WarnDays: 7, ArchiveDays: 3}, ""},
+		{"Never", PolicyRule{Prefix: "team-", Never: true}, ""},
+		{"Never with thresholds", PolicyRule{Prefix: "team-", Never: true, WarnDays: 7}, "never cannot be combined"},
+		{"Nothing to do", PolicyRule{Prefix: "team-"}, "needs warn-days, archive-days or never"},
+		{"Negative days", PolicyRule{Prefix: "team-", WarnDays: -1}, "must not be negative"},
+		{"Bad glob", PolicyRule{Glob: "tmp-[", Never: true}, "invalid glob"},
+		{"Bad regex", PolicyRule{Regex: "tmp-(", Never: true}, "invalid regex"},
+		{"Member bounds", PolicyRule{MinMembers: &two, MaxMembers: &one, Never: true}, "min-members is greater"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewArchivePolicy([]PolicyRule{tt.rule})
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestArchivePolicyMatch(t *testing.T) {
+	maxMembers := 2
+	extShared := true
+	policy, err := NewArchivePolicy([]PolicyRule{
+		{Name: "temporary", Glob: "tmp-*", WarnDays: 7, ArchiveDays: 3},
+		{Regex: `^inc-\d+$`, WarnDays: 14},
+		{Prefix: "team-", Never: true},
+		{ExtShared: &extShared, ArchiveDays: 60},
+		{MaxMembers: &maxMembers, WarnDays: 10},
+		{Glob: "*", ArchiveDays: 45},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		channel slack.Channel
+		want    string
+	}{
+		{policyTestChannel("tmp-spike", 50, false), "temporary"},
+		{policyTestChannel("inc-42", 50, false), `regex ^inc-\d+$`},
+		{policyTestChannel("inc-outage", 50, false), "glob *"},
+		{policyTestChannel("team-platform", 1, false), "prefix team-"},
+		{policyTestChannel("partners", 50, true), "ext-shared true"},
+		{policyTestChannel("tiny", 2, false), "members <= 2"},
+		{policyTestChannel("everything-else", 50, false), "glob *"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.channel.Name, func(t *testing.T) {
+			rule := policy.Match(tt.channel)
+			require.NotNil(t, rule)
+			assert.Equal(t, tt.want, rule.Describe())
+		})
+	}
+
+	t.Run("Nil policy matches nothing", func(t *testing.T) {
+		var none *ArchivePolicy
+		assert.Nil(t, none.Match(policyTestChannel("tmp-spike", 1, false)))
+		assert.Empty(t, none.Rules())
+	})
+
+	t.Run("Thresholds", func(t *testing.T) {
+		warn, archive := policy.Rules()[0].Thresholds(45*secondsPerDay, 30*secondsPerDay)
+		assert.Equal(t, 7*secondsPerDay, warn)
+		assert.Equal(t, 3*secondsPerDay, archive)
+
+		warn, archive = policy.Rules()[1].Thresholds(45*secondsPerDay, 30*secondsPerDay)
+		assert.Equal(t, 14*secondsPerDay, warn)
+		assert.Equal(t, 30*secondsPerDay, archive, "unset archive-days keeps the command's value")
+	})
+}
+
+func TestArchivePolicyInactivityAnalysis(t *testing.T) {
+	mockAPI := NewMockSlackAPI()
+	client, err := NewClientWithAPI(mockAPI)
+	require.NoError(t, err)
+
+	created := time.Now().Add(-200 * 24 * time.Hour)
+	lastPost := func(channelID string, daysAgo int) {
+		ts := fmt.Sprintf("%d.000000", time.Now().Add(-time.Duration(daysAgo)*24*time.Hour).Unix())
+		mockAPI.SetChannelHistory(channelID, []MockHistoryMessage{{Timestamp: ts, User: "U1", Text: "hello"}})
+	}
+	mockAPI.AddChannel("C1", "tmp-spike", created, "")
+	lastPost("C1", 10)
+	mockAPI.AddChannel("C2", "proj-apollo", created, "")
+	lastPost("C2", 60)
+	mockAPI.AddChannel("C3", "team-platform", created, "")
+	lastPost("C3", 150)
+	mockAPI.AddChannel("C4", "misc", created, "")
+	lastPost("C4", 60)
+
+	policy, err := NewArchivePolicy([]PolicyRule{
+		{Glob: "tmp-*", WarnDays: 7, ArchiveDays: 3},
+		{Glob: "proj-*", WarnDays: 90, ArchiveDays: 30},
+		{Glob: "team-*", Never: true},
+	})
+	require.NoError(t, err)
+	client.SetArchivePolicy(policy)
+
+	warnSeconds, archiveSeconds := 45*secondsPerDay, 30*secondsPerDay
+	toWarn, toArchive, _, err := client.GetInactiveChannelsWithDetailsAndExclusions(context.Background(), warnSeconds, archiveSeconds, map[string]string{}, nil, nil, false, false, 0)
+	require.NoError(t, err)
+	assert.Empty(t, toArchive)
+	require.Len(t, toWarn, 2)
+
+	assert.Equal(t, "tmp-spike", toWarn[0].Name)
+	assert.Equal(t, "glob tmp-*", toWarn[0].PolicyRule)
+	warn, archive := toWarn[0].Thresholds(warnSeconds, archiveSeconds)
+	assert.Equal(t, 7*secondsPerDay, warn)
+	assert.Equal(t, 3*secondsPerDay, archive)
+	assert.Contains(t, client.FormatInactiveChannelWarning(toWarn[0], warnSeconds, archiveSeconds, ""), "3 days")
+
+	assert.Equal(t, "misc", toWarn[1].Name)
+	assert.Empty(t, toWarn[1].PolicyRule)
+	warn, archive = toWarn[1].Thresholds(warnSeconds, archiveSeconds)
+	assert.Equal(t, warnSeconds, warn)
+	assert.Equal(t, archiveSeconds, archive)
+
+	_, stats := client.preFilterChannelsWithExclusions(mockAPI.Channels, time.Now().Add(-time.Duration(warnSeconds)*time.Second), nil, nil)
+	assert.Equal(t, 1, stats.skippedPolicy)
+}
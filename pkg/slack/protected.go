@@ -0,0 +1,124 @@
+package slack
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// DefaultProtectedNames are the name patterns protected from archival unless
+// SetProtectedNames says otherwise: any channel whose name contains one of
+// these words.
+var DefaultProtectedNames = []string{
+	"*general*",
+	"*random*",
+	"*announcements*",
+	"*admin*",
+	"*hr*",
+	"*security*",
+}
+
+// defaultProtectedNames is DefaultProtectedNames, compiled.
+var defaultProtectedNames = mustProtectedNames(DefaultProtectedNames)
+
+// ProtectedNames protects channels from archival by name. Each pattern is an
+// exact channel name, a glob using path.Match syntax (e.g. *general*) when it
+// contains *, ? or [, or a Go regexp when wrapped in slashes (e.g.
+// /^ops-\d+$/). Names are matched case-insensitively.
+type ProtectedNames struct {
+	patterns []namePattern
+}
+
+// namePattern is an exact channel name, a glob or a /regex/.
+type namePattern struct {
+	re  *regexp.Regexp
+	raw string
+}
+
+// NewProtectedNames compiles patterns. No patterns protects no channels.
+func NewProtectedNames(patterns []string) (*ProtectedNames, error) {
+	names := &ProtectedNames{}
+	for _, raw := range patterns {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		pattern, err := compileNamePattern(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid protected name: %w", err)
+		}
+		names.patterns = append(names.patterns, pattern)
+	}
+	return names, nil
+}
+
+func mustProtectedNames(patterns []string) *ProtectedNames {
+	names, err := NewProtectedNames(patterns)
+	if err != nil {
+		panic(err)
+	}
+	return names
+}
+
+// compileNamePattern validates raw and compiles it if it is a regexp.
+func compileNamePattern(raw string) (namePattern, error) {
+	if len(raw) > 2 && strings.HasPrefix(raw, "/") && strings.HasSuffix(raw, "/") {
+		re, err := regexp.Compile("(?i)" + raw[1:len(raw)-1])
+		if err != nil {
+			return namePattern{}, fmt.Errorf("regex %s: %w", raw, err)
+		}
+		return namePattern{raw: raw, re: re}, nil
+	}
+	raw = strings.ToLower(strings.TrimPrefix(raw, "#"))
+	if _, err := path.Match(raw, ""); err != nil {
+		return namePattern{}, fmt.Errorf("glob %s: %w", raw, err)
+	}
+	return namePattern{raw: raw}, nil
+}
+
+// matches reports whether the lowercased channel name matches the pattern.
+func (p namePattern) matches(lowerName string) bool {
+	if p.re != nil {
+		return p.re.MatchString(lowerName)
+	}
+	if strings.ContainsAny(p.raw, "*?[") {
+		ok, _ := path.Match(p.raw, lowerName) //nolint:errcheck // validated by compileNamePattern
+		return ok
+	}
+	return p.raw == lowerName
+}
+
+// Match returns the first pattern protecting channelName, if any.
+func (n *ProtectedNames) Match(channelName string) (string, bool) {
+	lowerName := strings.ToLower(channelName)
+	for _, pattern := range n.patterns {
+		if pattern.matches(lowerName) {
+			return pattern.raw, true
+		}
+	}
+	return "", false
+}
+
+// Patterns returns the patterns as given, in order.
+func (n *ProtectedNames) Patterns() []string {
+	patterns := make([]string, len(n.patterns))
+	for i, pattern := range n.patterns {
+		patterns[i] = pattern.raw
+	}
+	return patterns
+}
+
+// SetProtectedNames sets the channel names protected from archival. A nil
+// value restores DefaultProtectedNames.
+func (c *Client) SetProtectedNames(names *ProtectedNames) {
+	c.protectedNames = names
+}
+
+// ProtectedNames returns the channel names protected from archival.
+func (c *Client) ProtectedNames() *ProtectedNames {
+	if c.protectedNames == nil {
+		return defaultProtectedNames
+	}
+	return c.protectedNames
+}
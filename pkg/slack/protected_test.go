@@ -0,0 +1,87 @@
+package slack
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProtectedNames(t *testing.T) {
+	t.Run("Defaults match any name containing a protected word", func(t *testing.T) {
+		client, err := NewClientWithAPI(NewMockSlackAPI())
+		require.NoError(t, err)
+		names := client.ProtectedNames()
+		assert.Equal(t, DefaultProtectedNames, names.Patterns())
+
+		for _, name := range []string{"general", "Random-Stuff", "three-d-printing", "security-alerts"} {
+			assert.True(t, client.shouldSkipChannel(name), name)
+		}
+		assert.False(t, client.shouldSkipChannel("old-project"))
+	})
+
+	t.Run("Exact names, globs and regexes", func(t *testing.T) {
+		names, err := NewProtectedNames([]string{"general", " #random ", "team-*", `/^ops-\d+$/`, ""})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"general", "random", "team-*", `/^ops-\d+$/`}, names.Patterns())
+
+		tests := map[string]string{
+			"general":       "general",
+			"GENERAL":       "general",
+			"random":        "random",
+			"team-platform": "team-*",
+			"ops-42":        `/^ops-\d+$/`,
+		}
+		for name, want := range tests {
+			pattern, ok := names.Match(name)
+			assert.True(t, ok, name)
+			assert.Equal(t, want, pattern, name)
+		}
+		for _, name := range []string{"general-chat", "three-d-printing", "ops-oncall", "my-team-x"} {
+			_, ok := names.Match(name)
+			assert.False(t, ok, name)
+		}
+	})
+
+	t.Run("Invalid patterns", func(t *testing.T) {
+		_, err := NewProtectedNames([]string{"team-["})
+		assert.ErrorContains(t, err, "invalid protected name: glob")
+		_, err = NewProtectedNames([]string{"/ops-(/"})
+		assert.ErrorContains(t, err, "invalid protected name: regex")
+	})
+
+	t.Run("Custom list replaces defaults in analysis", func(t *testing.T) {
+		mockAPI := NewMockSlackAPI()
+		client, err := NewClientWithAPI(mockAPI)
+		require.NoError(t, err)
+
+		oldTime := time.Now().Add(-5 * time.Hour)
+		mockAPI.AddChannel("C1", "general", oldTime, "")
+		mockAPI.AddChannel("C2", "three-d-printing", oldTime, "")
+		mockAPI.AddChannel("C3", "old-project", oldTime, "")
+		for _, id := range []string{"C1", "C2", "C3"} {
+			mockAPI.SetChannelHistory(id, []MockHistoryMessage{{Timestamp: formatTimestamp(oldTime.Add(10 * time.Minute)), User: "U1234567", Text: "Old message"}})
+		}
+
+		names, err := NewProtectedNames([]string{"general"})
+		require.NoError(t, err)
+		client.SetProtectedNames(names)
+
+		warnCutoff := time.Now().Add(-3 * time.Hour)
+		candidates, stats := client.preFilterChannelsWithExclusions(mockAPI.Channels, warnCutoff, nil, nil)
+		assert.Equal(t, 1, stats.skippedProtected)
+		assert.Len(t, candidates, 2)
+
+		toWarn, _, err := client.GetInactiveChannels(context.Background(), 10800, 3600)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"three-d-printing", "old-project"}, []string{toWarn[0].Name, toWarn[1].Name})
+
+		none, err := NewProtectedNames(nil)
+		require.NoError(t, err)
+		client.SetProtectedNames(none)
+		_, stats = client.preFilterChannelsWithExclusions(mockAPI.Channels, warnCutoff, nil, nil)
+		assert.Zero(t, stats.skippedProtected)
+	})
+}
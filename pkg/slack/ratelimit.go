@@ -0,0 +1,312 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/astrostl/slack-butler/pkg/logger"
+
+	"github.com/slack-go/slack"
+)
+
+// Slack Web API rate-limit tiers, in requests per minute.
+// See https://api.slack.com/apis/rate-limits.
+const (
+	tier2PerMinute = 20
+	tier3PerMinute = 50
+	tier4PerMinute = 100
+	// chat.postMessage is a "special" tier of roughly one message per second.
+	postMessagePerMinute = 60
+)
+
+// defaultMaxRateLimitRetries is how many times a call rejected with HTTP 429
+// is retried before the rate-limit error is returned to the caller.
+const defaultMaxRateLimitRetries = 3
+
+// retryAfterBuffer is added to Slack's Retry-After so the retry doesn't land
+// exactly on the edge of the window.
+const retryAfterBuffer = time.Second
+
+// methodBudgets maps each Slack Web API method used by SlackAPI to its tier
+// budget. Slack enforces limits per method, so each gets its own bucket.
+var methodBudgets = map[string]int{
+	"auth.test":               tier4PerMinute,
+	"conversations.list":      tier2PerMinute,
+	"conversations.history":   tier3PerMinute,
+	"conversations.info":      tier3PerMinute,
+	"conversations.replies":   tier3PerMinute,
+	"users.conversations":     tier3PerMinute,
+	"chat.postMessage":        postMessagePerMinute,
+	"conversations.archive":   tier2PerMinute,
+	"conversations.unarchive": tier2PerMinute,
+	"conversations.join":      tier3PerMinute,
+	"reactions.get":           tier3PerMinute,
+	"users.list":              tier2PerMinute,
+	"team.info":               tier3PerMinute,
+}
+
+// tokenBucket is a per-method request budget. Tokens refill continuously at
+// the tier's per-minute rate, up to a small burst capacity.
+type tokenBucket struct {
+	last         time.Time
+	blockedUntil time.Time
+	capacity     float64
+	tokens       float64
+	perSecond    float64
+	mu           sync.Mutex
+}
+
+// newTokenBucket creates a full bucket for a budget of perMinute requests.
+// Bursts are capped at ten seconds' worth of budget (at least one request).
+func newTokenBucket(perMinute int, now time.Time) *tokenBucket {
+	capacity := float64(perMinute) / 6
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &tokenBucket{
+		last:      now,
+		capacity:  capacity,
+		tokens:    capacity,
+		perSecond: float64(perMinute) / 60,
+	}
+}
+
+// reserve takes one token and returns how long the caller must wait before
+// spending it. Reservations may drive the balance negative, so concurrent
+// callers queue up behind each other instead of all waking at once.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Seconds() * b.perSecond
+		if b.tokens > b.capacity {
+			b.tokens = b.capacity
+		}
+		b.last = now
+	}
+
+	b.tokens--
+	var wait time.Duration
+	if b.tokens < 0 {
+		wait = time.Duration(-b.tokens / b.perSecond * float64(time.Second))
+	}
+	if blocked := b.blockedUntil.Sub(now); blocked > wait {
+		wait = blocked
+	}
+	return wait
+}
+
+// pause blocks the bucket until the given time, e.g. after Slack returns 429.
+func (b *tokenBucket) pause(until time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if until.After(b.blockedUntil) {
+		b.blockedUntil = until
+	}
+}
+
+// RateLimitedAPI decorates a SlackAPI with per-method token buckets sized to
+// Slack's rate-limit tiers, and transparently retries calls that Slack rejects
+// with a *slack.RateLimitedError after waiting for its RetryAfter.
+type RateLimitedAPI struct {
+	next       SlackAPI
+	buckets    map[string]*tokenBucket
+	now        func() time.Time
+	sleep      func(ctx context.Context, d time.Duration) error
+	backoff    func(ctx context.Context, d time.Duration) error
+	maxRetries int
+	mu         sync.Mutex
+}
+
+// NewRateLimitedAPI wraps next with rate limiting and automatic retries.
+func NewRateLimitedAPI(next SlackAPI) *RateLimitedAPI {
+	return &RateLimitedAPI{
+		next:       next,
+		buckets:    make(map[string]*tokenBucket),
+		now:        time.Now,
+		sleep:      sleepContext,
+		backoff:    sleepContext,
+		maxRetries: defaultMaxRateLimitRetries,
+	}
+}
+
+// bucket returns the token bucket for a Slack method, creating it on first use.
+func (r *RateLimitedAPI) bucket(method string) *tokenBucket {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b, ok := r.buckets[method]
+	if !ok {
+		perMinute, known := methodBudgets[method]
+		if !known {
+			perMinute = tier2PerMinute
+		}
+		b = newTokenBucket(perMinute, r.now())
+		r.buckets[method] = b
+	}
+	return b
+}
+
+// do runs call within the method's budget, retrying when Slack rate limits it.
+func (r *RateLimitedAPI) do(ctx context.Context, method string, call func() error) error {
+	bucket := r.bucket(method)
+	for attempt := 1; ; attempt++ {
+		if wait := bucket.reserve(r.now()); wait > 0 {
+			logger.WithFields(logger.LogFields{
+				"method": method,
+				"wait":   wait.String(),
+			}).Debug("Waiting for Slack rate-limit budget")
+			if err := r.sleep(ctx, wait); err != nil {
+				return err
+			}
+		}
+
+		err := call()
+		var rateLimited *slack.RateLimitedError
+		if !errors.As(err, &rateLimited) || attempt > r.maxRetries {
+			return classifyError(err)
+		}
+
+		retryAfter := rateLimited.RetryAfter + retryAfterBuffer
+		bucket.pause(r.now().Add(retryAfter))
+		logger.WithFields(logger.LogFields{
+			"method":      method,
+			"attempt":     attempt,
+			"max_retries": r.maxRetries,
+			"retry_after": retryAfter.String(),
+		}).Warn("Rate limited by Slack API, retrying after Slack-specified delay")
+		if err := r.backoff(ctx, retryAfter); err != nil {
+			return err
+		}
+	}
+}
+
+func (r *RateLimitedAPI) AuthTest(ctx context.Context) (*slack.AuthTestResponse, error) {
+	var resp *slack.AuthTestResponse
+	err := r.do(ctx, "auth.test", func() error {
+		var err error
+		resp, err = r.next.AuthTest(ctx)
+		return err
+	})
+	return resp, err
+}
+
+func (r *RateLimitedAPI) GetConversations(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
+	var channels []slack.Channel
+	var cursor string
+	err := r.do(ctx, "conversations.list", func() error {
+		var err error
+		channels, cursor, err = r.next.GetConversations(ctx, params)
+		return err
+	})
+	return channels, cursor, err
+}
+
+func (r *RateLimitedAPI) GetConversationHistory(ctx context.Context, params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+	var history *slack.GetConversationHistoryResponse
+	err := r.do(ctx, "conversations.history", func() error {
+		var err error
+		history, err = r.next.GetConversationHistory(ctx, params)
+		return err
+	})
+	return history, err
+}
+
+func (r *RateLimitedAPI) GetConversationInfo(ctx context.Context, input *slack.GetConversationInfoInput) (*slack.Channel, error) {
+	var channel *slack.Channel
+	err := r.do(ctx, "conversations.info", func() error {
+		var err error
+		channel, err = r.next.GetConversationInfo(ctx, input)
+		return err
+	})
+	return channel, err
+}
+
+func (r *RateLimitedAPI) GetConversationReplies(ctx context.Context, params *slack.GetConversationRepliesParameters) ([]slack.Message, string, error) {
+	var messages []slack.Message
+	var cursor string
+	err := r.do(ctx, "conversations.replies", func() error {
+		var err error
+		messages, cursor, err = r.next.GetConversationReplies(ctx, params)
+		return err
+	})
+	return messages, cursor, err
+}
+
+func (r *RateLimitedAPI) GetConversationsForUser(ctx context.Context, params *slack.GetConversationsForUserParameters) ([]slack.Channel, string, error) {
+	var channels []slack.Channel
+	var cursor string
+	err := r.do(ctx, "users.conversations", func() error {
+		var err error
+		channels, cursor, err = r.next.GetConversationsForUser(ctx, params)
+		return err
+	})
+	return channels, cursor, err
+}
+
+func (r *RateLimitedAPI) PostMessage(ctx context.Context, channelID string, options ...slack.MsgOption) (string, string, error) {
+	var respChannel, timestamp string
+	err := r.do(ctx, "chat.postMessage", func() error {
+		var err error
+		respChannel, timestamp, err = r.next.PostMessage(ctx, channelID, options...)
+		return err
+	})
+	return respChannel, timestamp, err
+}
+
+func (r *RateLimitedAPI) ArchiveConversation(ctx context.Context, channelID string) error {
+	return r.do(ctx, "conversations.archive", func() error {
+		return r.next.ArchiveConversation(ctx, channelID)
+	})
+}
+
+func (r *RateLimitedAPI) UnarchiveConversation(ctx context.Context, channelID string) error {
+	return r.do(ctx, "conversations.unarchive", func() error {
+		return r.next.UnarchiveConversation(ctx, channelID)
+	})
+}
+
+func (r *RateLimitedAPI) JoinConversation(ctx context.Context, channelID string) (*slack.Channel, string, []string, error) {
+	var channel *slack.Channel
+	var warning string
+	var warnings []string
+	err := r.do(ctx, "conversations.join", func() error {
+		var err error
+		channel, warning, warnings, err = r.next.JoinConversation(ctx, channelID)
+		return err
+	})
+	return channel, warning, warnings, err
+}
+
+func (r *RateLimitedAPI) GetReactions(ctx context.Context, item slack.ItemRef, params slack.GetReactionsParameters) ([]slack.ItemReaction, error) {
+	var reactions []slack.ItemReaction
+	err := r.do(ctx, "reactions.get", func() error {
+		var err error
+		reactions, err = r.next.GetReactions(ctx, item, params)
+		return err
+	})
+	return reactions, err
+}
+
+func (r *RateLimitedAPI) GetUsersPage(ctx context.Context, cursor string, limit int) ([]slack.User, string, error) {
+	var users []slack.User
+	var nextCursor string
+	err := r.do(ctx, "users.list", func() error {
+		var err error
+		users, nextCursor, err = r.next.GetUsersPage(ctx, cursor, limit)
+		return err
+	})
+	return users, nextCursor, err
+}
+
+func (r *RateLimitedAPI) GetTeamInfo(ctx context.Context) (*slack.TeamInfo, error) {
+	var info *slack.TeamInfo
+	err := r.do(ctx, "team.info", func() error {
+		var err error
+		info, err = r.next.GetTeamInfo(ctx)
+		return err
+	})
+	return info, err
+}
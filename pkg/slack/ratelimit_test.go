@@ -0,0 +1,216 @@
+package slack
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock drives RateLimitedAPI in tests: sleeping advances time instantly
+// and every wait is recorded.
+type fakeClock struct {
+	now   time.Time
+	waits []time.Duration
+}
+
+func (f *fakeClock) Now() time.Time {
+	return f.now
+}
+
+func (f *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	f.waits = append(f.waits, d)
+	f.now = f.now.Add(d)
+	return nil
+}
+
+// newTestRateLimitedAPI wraps next with a RateLimitedAPI whose waits run on
+// clock (a fresh fakeClock when nil) instead of real time.
+func newTestRateLimitedAPI(next SlackAPI, clock *fakeClock) *RateLimitedAPI {
+	if clock == nil {
+		clock = &fakeClock{now: time.Unix(1700000000, 0)}
+	}
+	api := NewRateLimitedAPI(next)
+	api.now = clock.Now
+	api.sleep = clock.Sleep
+	api.backoff = clock.Sleep
+	return api
+}
+
+// rateLimitingAPI rejects the first `remaining` PostMessage and
+// ArchiveConversation calls with a *slack.RateLimitedError.
+type rateLimitingAPI struct {
+	*MockSlackAPI
+	remaining  int
+	retryAfter time.Duration
+	calls      int
+}
+
+func (a *rateLimitingAPI) rateLimited() error {
+	a.calls++
+	if a.remaining > 0 {
+		a.remaining--
+		return &slack.RateLimitedError{RetryAfter: a.retryAfter}
+	}
+	return nil
+}
+
+func (a *rateLimitingAPI) PostMessage(ctx context.Context, channelID string, options ...slack.MsgOption) (string, string, error) {
+	if err := a.rateLimited(); err != nil {
+		return "", "", err
+	}
+	return a.MockSlackAPI.PostMessage(ctx, channelID, options...)
+}
+
+func (a *rateLimitingAPI) ArchiveConversation(ctx context.Context, channelID string) error {
+	if err := a.rateLimited(); err != nil {
+		return err
+	}
+	return a.MockSlackAPI.ArchiveConversation(ctx, channelID)
+}
+
+func TestRateLimitedAPI(t *testing.T) {
+	var _ SlackAPI = (*RateLimitedAPI)(nil)
+
+	t.Run("PostMessage is retried after RetryAfter", func(t *testing.T) {
+		inner := &rateLimitingAPI{MockSlackAPI: NewMockSlackAPI(), remaining: 2, retryAfter: 5 * time.Second}
+		clock := &fakeClock{now: time.Unix(1700000000, 0)}
+		api := newTestRateLimitedAPI(inner, clock)
+
+		_, _, err := api.PostMessage(context.Background(), "C123", slack.MsgOptionText("hello", false))
+		require.NoError(t, err)
+		assert.Equal(t, 3, inner.calls)
+		assert.Len(t, inner.GetPostedMessages(), 1)
+		assert.Equal(t, []time.Duration{6 * time.Second, 6 * time.Second}, clock.waits)
+	})
+
+	t.Run("ArchiveConversation is retried", func(t *testing.T) {
+		inner := &rateLimitingAPI{MockSlackAPI: NewMockSlackAPI(), remaining: 1, retryAfter: time.Second}
+		api := newTestRateLimitedAPI(inner, nil)
+
+		require.NoError(t, api.ArchiveConversation(context.Background(), "C123"))
+		assert.Equal(t, 2, inner.calls)
+		assert.Equal(t, []string{"C123"}, inner.GetArchivedChannels())
+	})
+
+	t.Run("Gives up after max retries", func(t *testing.T) {
+		inner := &rateLimitingAPI{MockSlackAPI: NewMockSlackAPI(), remaining: 100, retryAfter: time.Second}
+		api := newTestRateLimitedAPI(inner, nil)
+
+		err := api.ArchiveConversation(context.Background(), "C123")
+		var rateLimited *slack.RateLimitedError
+		require.ErrorAs(t, err, &rateLimited)
+		assert.Equal(t, defaultMaxRateLimitRetries+1, inner.calls)
+	})
+
+	t.Run("Other errors are not retried", func(t *testing.T) {
+		inner := &rateLimitingAPI{MockSlackAPI: NewMockSlackAPI()}
+		inner.SetArchiveConversationError(missingScope)
+		api := newTestRateLimitedAPI(inner, nil)
+
+		err := api.ArchiveConversation(context.Background(), "C123")
+		assert.EqualError(t, err, missingScope)
+		assert.Equal(t, 1, inner.calls)
+	})
+
+	t.Run("Token bucket throttles bursts per method", func(t *testing.T) {
+		clock := &fakeClock{now: time.Unix(1700000000, 0)}
+		api := newTestRateLimitedAPI(NewMockSlackAPI(), clock)
+
+		// conversations.archive is Tier 2 (20/min): a burst of 3 passes, the 4th waits.
+		for i := 0; i < 3; i++ {
+			require.NoError(t, api.ArchiveConversation(context.Background(), "C123"))
+		}
+		assert.Empty(t, clock.waits)
+
+		require.NoError(t, api.ArchiveConversation(context.Background(), "C123"))
+		require.Len(t, clock.waits, 1)
+		assert.InDelta(t, 2*time.Second, clock.waits[0], float64(10*time.Millisecond))
+
+		// Other methods have their own budget.
+		_, _, err := api.PostMessage(context.Background(), "C123")
+		require.NoError(t, err)
+		assert.Len(t, clock.waits, 1)
+	})
+
+	t.Run("Rate-limit wait is cancellable", func(t *testing.T) {
+		inner := &rateLimitingAPI{MockSlackAPI: NewMockSlackAPI(), remaining: 1, retryAfter: 30 * time.Second}
+		api := NewRateLimitedAPI(inner)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		start := time.Now()
+		_, _, err := api.PostMessage(ctx, "C123")
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Less(t, time.Since(start), time.Second)
+		assert.Equal(t, 1, inner.calls)
+	})
+
+	t.Run("Delegates every method", func(t *testing.T) {
+		mock := NewMockSlackAPI()
+		mock.AddChannel("C1", "general", time.Now(), "")
+		mock.AddUser("U1", "alice", "Alice")
+		api := newTestRateLimitedAPI(mock, nil)
+		ctx := context.Background()
+
+		_, err := api.AuthTest(ctx)
+		assert.NoError(t, err)
+		channels, _, err := api.GetConversations(ctx, &slack.GetConversationsParameters{})
+		assert.NoError(t, err)
+		assert.Len(t, channels, 1)
+		_, err = api.GetConversationHistory(ctx, &slack.GetConversationHistoryParameters{ChannelID: "C1"})
+		assert.NoError(t, err)
+		_, err = api.GetConversationInfo(ctx, &slack.GetConversationInfoInput{ChannelID: "C1"})
+		assert.NoError(t, err)
+		_, _, err = api.GetConversationsForUser(ctx, &slack.GetConversationsForUserParameters{UserID: "U1"})
+		assert.NoError(t, err)
+		_, _, _, err = api.JoinConversation(ctx, "C1")
+		assert.NoError(t, err)
+		users, _, err := api.GetUsersPage(ctx, "", 10)
+		assert.NoError(t, err)
+		assert.Len(t, users, 1)
+		_, err = api.GetTeamInfo(ctx)
+		assert.NoError(t, err)
+	})
+}
+
+func TestTokenBucket(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+
+	t.Run("Refills at the per-minute rate", func(t *testing.T) {
+		b := newTokenBucket(60, start) // 1/s, burst of 10
+		for i := 0; i < 10; i++ {
+			assert.Zero(t, b.reserve(start))
+		}
+		assert.Equal(t, time.Second, b.reserve(start))
+		// Two seconds later one reservation is still outstanding.
+		assert.Zero(t, b.reserve(start.Add(2*time.Second)))
+	})
+
+	t.Run("Pause blocks until Retry-After", func(t *testing.T) {
+		b := newTokenBucket(60, start)
+		b.pause(start.Add(10 * time.Second))
+		assert.Equal(t, 10*time.Second, b.reserve(start))
+		assert.Zero(t, b.reserve(start.Add(10*time.Second)))
+	})
+
+	t.Run("Low budgets still allow one request", func(t *testing.T) {
+		b := newTokenBucket(1, start)
+		assert.Zero(t, b.reserve(start))
+		assert.Equal(t, time.Minute, b.reserve(start))
+	})
+
+	t.Run("Unknown methods get a Tier 2 budget", func(t *testing.T) {
+		api := NewRateLimitedAPI(NewMockSlackAPI())
+		b := api.bucket("some.method")
+		assert.InDelta(t, float64(tier2PerMinute)/60, b.perSecond, 1e-9)
+		assert.Same(t, b, api.bucket("some.method"))
+	})
+}
@@ -0,0 +1,455 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/astrostl/slack-butler/pkg/logger"
+
+	"github.com/slack-go/slack"
+)
+
+// sessionVersion is the format version written to recorded session files.
+const sessionVersion = 1
+
+// recordedSession is the on-disk format of a recorded Slack API session.
+type recordedSession struct {
+	RecordedAt time.Time      `json:"recorded_at"`
+	Calls      []recordedCall `json:"calls"`
+	Version    int            `json:"version"`
+}
+
+// recordedCall is one SlackAPI request and the response it got.
+type recordedCall struct {
+	Error    *recordedError  `json:"error,omitempty"`
+	Method   string          `json:"method"`
+	Request  json.RawMessage `json:"request,omitempty"`
+	Response json.RawMessage `json:"response,omitempty"`
+}
+
+// recordedError keeps enough of an error to rebuild its typed form on replay.
+type recordedError struct {
+	Message     string        `json:"message"`
+	Code        string        `json:"code,omitempty"`
+	RetryAfter  time.Duration `json:"retry_after,omitempty"`
+	RateLimited bool          `json:"rate_limited,omitempty"`
+}
+
+func newRecordedError(err error) *recordedError {
+	rec := &recordedError{Message: SanitizeForLogging(err.Error())}
+	var (
+		rateErr  *RateLimitError
+		scopeErr *MissingScopeError
+		apiErr   *APIError
+	)
+	switch {
+	case errors.As(err, &rateErr):
+		rec.RateLimited = true
+		rec.RetryAfter = rateErr.RetryAfter
+	case errors.As(err, &scopeErr):
+		rec.Code = "missing_scope"
+	case errors.As(err, &apiErr):
+		rec.Code = apiErr.Code
+	}
+	return rec
+}
+
+// err rebuilds the error as RealSlackAPI would have returned it.
+func (e *recordedError) err() error {
+	switch {
+	case e.RateLimited:
+		return classifyError(&slack.RateLimitedError{RetryAfter: e.RetryAfter})
+	case e.Code != "":
+		return classifyError(slack.SlackErrorResponse{Err: e.Code})
+	}
+	return errors.New(e.Message)
+}
+
+// Request and response shapes for methods that don't return a single value.
+type (
+	pageResponse[T any] struct {
+		Items      []T    `json:"items"`
+		NextCursor string `json:"next_cursor,omitempty"`
+	}
+	postMessageRequest struct {
+		Channel string `json:"channel"`
+		Text    string `json:"text,omitempty"`
+	}
+	postMessageResponse struct {
+		Channel   string `json:"channel"`
+		Timestamp string `json:"ts"`
+	}
+	joinResponse struct {
+		Channel  *slack.Channel `json:"channel"`
+		Warning  string         `json:"warning,omitempty"`
+		Warnings []string       `json:"warnings,omitempty"`
+	}
+	usersPageRequest struct {
+		Cursor string `json:"cursor,omitempty"`
+		Limit  int    `json:"limit"`
+	}
+	channelRequest struct {
+		Channel string `json:"channel"`
+	}
+	reactionsRequest struct {
+		Item slack.ItemRef `json:"item"`
+		Full bool          `json:"full,omitempty"`
+	}
+	// authTestResponse keeps the granted OAuth scopes, which slack-go only
+	// exposes as a response header.
+	authTestResponse struct {
+		*slack.AuthTestResponse
+		OAuthScopes []string `json:"oauth_scopes,omitempty"`
+	}
+)
+
+// encodeSanitized marshals v to JSON with tokens redacted.
+func encodeSanitized(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(SanitizeForLogging(string(data))), nil
+}
+
+// postMessageText extracts the message text from chat.postMessage options.
+func postMessageText(channelID string, options []slack.MsgOption) string {
+	_, values, err := slack.UnsafeApplyMsgOptions("", channelID, "", options...)
+	if err != nil {
+		return ""
+	}
+	return values.Get("text")
+}
+
+// RecordingAPI decorates a SlackAPI and records every request and response.
+// Close writes the session to a JSON file, with tokens redacted by
+// SanitizeForLogging, that ReplayAPI can serve back offline.
+type RecordingAPI struct {
+	next    SlackAPI
+	started time.Time
+	path    string
+	calls   []recordedCall
+	mu      sync.Mutex
+}
+
+// NewRecordingAPI wraps next, recording calls to be saved to path on Close.
+func NewRecordingAPI(next SlackAPI, path string) *RecordingAPI {
+	return &RecordingAPI{
+		next:    next,
+		path:    path,
+		started: time.Now().UTC(),
+	}
+}
+
+// record appends a call. Calls aborted by context cancellation are left out
+// since Slack never answered them.
+func (r *RecordingAPI) record(method string, request, response any, err error) {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return
+	}
+
+	call := recordedCall{Method: method}
+	var encodeErr error
+	if call.Request, encodeErr = encodeSanitized(request); encodeErr == nil && err == nil {
+		call.Response, encodeErr = encodeSanitized(response)
+	}
+	if encodeErr != nil {
+		logger.WithFields(logger.LogFields{
+			"method": method,
+			"error":  encodeErr.Error(),
+		}).Warn("Failed to record Slack API call")
+		return
+	}
+	if err != nil {
+		call.Error = newRecordedError(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, call)
+}
+
+// Close writes the recorded session to the file. The file may contain
+// channel names, messages and user names, so it is created owner-only.
+func (r *RecordingAPI) Close() error {
+	r.mu.Lock()
+	session := recordedSession{
+		Version:    sessionVersion,
+		RecordedAt: r.started,
+		Calls:      append([]recordedCall(nil), r.calls...),
+	}
+	r.mu.Unlock()
+
+	data, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode recorded session: %w", err)
+	}
+	if err := os.WriteFile(filepath.Clean(r.path), append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write recorded session: %w", err)
+	}
+	return nil
+}
+
+func (r *RecordingAPI) AuthTest(ctx context.Context) (*slack.AuthTestResponse, error) {
+	resp, err := r.next.AuthTest(ctx)
+	var recorded *authTestResponse
+	if resp != nil {
+		recorded = &authTestResponse{AuthTestResponse: resp, OAuthScopes: resp.Header.Values(oauthScopesHeader)}
+	}
+	r.record("auth.test", nil, recorded, err)
+	return resp, err
+}
+
+func (r *RecordingAPI) GetConversations(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
+	channels, cursor, err := r.next.GetConversations(ctx, params)
+	r.record("conversations.list", params, pageResponse[slack.Channel]{Items: channels, NextCursor: cursor}, err)
+	return channels, cursor, err
+}
+
+func (r *RecordingAPI) GetConversationHistory(ctx context.Context, params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+	history, err := r.next.GetConversationHistory(ctx, params)
+	r.record("conversations.history", params, history, err)
+	return history, err
+}
+
+func (r *RecordingAPI) GetConversationInfo(ctx context.Context, input *slack.GetConversationInfoInput) (*slack.Channel, error) {
+	channel, err := r.next.GetConversationInfo(ctx, input)
+	r.record("conversations.info", input, channel, err)
+	return channel, err
+}
+
+func (r *RecordingAPI) GetConversationReplies(ctx context.Context, params *slack.GetConversationRepliesParameters) ([]slack.Message, string, error) {
+	messages, cursor, err := r.next.GetConversationReplies(ctx, params)
+	r.record("conversations.replies", params, pageResponse[slack.Message]{Items: messages, NextCursor: cursor}, err)
+	return messages, cursor, err
+}
+
+func (r *RecordingAPI) GetConversationsForUser(ctx context.Context, params *slack.GetConversationsForUserParameters) ([]slack.Channel, string, error) {
+	channels, cursor, err := r.next.GetConversationsForUser(ctx, params)
+	r.record("users.conversations", params, pageResponse[slack.Channel]{Items: channels, NextCursor: cursor}, err)
+	return channels, cursor, err
+}
+
+func (r *RecordingAPI) PostMessage(ctx context.Context, channelID string, options ...slack.MsgOption) (string, string, error) {
+	respChannel, timestamp, err := r.next.PostMessage(ctx, channelID, options...)
+	request := postMessageRequest{Channel: channelID, Text: postMessageText(channelID, options)}
+	r.record("chat.postMessage", request, postMessageResponse{Channel: respChannel, Timestamp: timestamp}, err)
+	return respChannel, timestamp, err
+}
+
+func (r *RecordingAPI) ArchiveConversation(ctx context.Context, channelID string) error {
+	err := r.next.ArchiveConversation(ctx, channelID)
+	r.record("conversations.archive", channelRequest{Channel: channelID}, nil, err)
+	return err
+}
+
+func (r *RecordingAPI) UnarchiveConversation(ctx context.Context, channelID string) error {
+	err := r.next.UnarchiveConversation(ctx, channelID)
+	r.record("conversations.unarchive", channelRequest{Channel: channelID}, nil, err)
+	return err
+}
+
+func (r *RecordingAPI) JoinConversation(ctx context.Context, channelID string) (*slack.Channel, string, []string, error) {
+	channel, warning, warnings, err := r.next.JoinConversation(ctx, channelID)
+	r.record("conversations.join", channelRequest{Channel: channelID}, joinResponse{Channel: channel, Warning: warning, Warnings: warnings}, err)
+	return channel, warning, warnings, err
+}
+
+func (r *RecordingAPI) GetReactions(ctx context.Context, item slack.ItemRef, params slack.GetReactionsParameters) ([]slack.ItemReaction, error) {
+	reactions, err := r.next.GetReactions(ctx, item, params)
+	r.record("reactions.get", reactionsRequest{Item: item, Full: params.Full}, reactions, err)
+	return reactions, err
+}
+
+func (r *RecordingAPI) GetUsersPage(ctx context.Context, cursor string, limit int) ([]slack.User, string, error) {
+	users, nextCursor, err := r.next.GetUsersPage(ctx, cursor, limit)
+	r.record("users.list", usersPageRequest{Cursor: cursor, Limit: limit}, pageResponse[slack.User]{Items: users, NextCursor: nextCursor}, err)
+	return users, nextCursor, err
+}
+
+func (r *RecordingAPI) GetTeamInfo(ctx context.Context) (*slack.TeamInfo, error) {
+	info, err := r.next.GetTeamInfo(ctx)
+	r.record("team.info", nil, info, err)
+	return info, err
+}
+
+// ReplayAPI serves a session recorded by RecordingAPI. Each call is answered
+// with the next unused recorded response for the same method and request,
+// so a replayed run sees exactly what the recorded run saw. Message text is
+// not compared for chat.postMessage since it embeds the current date.
+type ReplayAPI struct {
+	calls      map[string][]recordedCall
+	recordedAt time.Time
+	mu         sync.Mutex
+}
+
+// LoadReplayAPI reads a session file written by RecordingAPI.
+func LoadReplayAPI(path string) (*ReplayAPI, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recorded session: %w", err)
+	}
+	var session recordedSession
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("failed to parse recorded session %s: %w", path, err)
+	}
+	if session.Version != sessionVersion {
+		return nil, fmt.Errorf("unsupported recorded session version %d (expected %d)", session.Version, sessionVersion)
+	}
+
+	r := &ReplayAPI{
+		calls:      make(map[string][]recordedCall),
+		recordedAt: session.RecordedAt,
+	}
+	for _, call := range session.Calls {
+		key := replayKey(call.Method, call.Request)
+		r.calls[key] = append(r.calls[key], call)
+	}
+	return r, nil
+}
+
+// RecordedAt returns when the session was recorded.
+func (r *ReplayAPI) RecordedAt() time.Time {
+	return r.recordedAt
+}
+
+// replayKey identifies the calls a request may be answered with.
+func replayKey(method string, request json.RawMessage) string {
+	if method == "chat.postMessage" {
+		var post postMessageRequest
+		if err := json.Unmarshal(request, &post); err == nil {
+			return method + " " + post.Channel
+		}
+	}
+	// The session file is indented; compare requests in compact form
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, request); err != nil {
+		return method + " " + string(request)
+	}
+	return method + " " + compact.String()
+}
+
+// take pops the next recorded call for method and request.
+func (r *ReplayAPI) take(ctx context.Context, method string, request any) (recordedCall, error) {
+	if err := ctx.Err(); err != nil {
+		return recordedCall{}, err
+	}
+	encoded, err := encodeSanitized(request)
+	if err != nil {
+		return recordedCall{}, err
+	}
+	key := replayKey(method, encoded)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	queue := r.calls[key]
+	if len(queue) == 0 {
+		return recordedCall{}, fmt.Errorf("replay: no recorded %s response for request %s", method, encoded)
+	}
+	r.calls[key] = queue[1:]
+	return queue[0], nil
+}
+
+// replay answers a call from the session, decoding the recorded response into T.
+func replay[T any](ctx context.Context, r *ReplayAPI, method string, request any) (T, error) {
+	var response T
+	call, err := r.take(ctx, method, request)
+	if err != nil {
+		return response, err
+	}
+	if call.Error != nil {
+		return response, call.Error.err()
+	}
+	if len(call.Response) > 0 {
+		if err := json.Unmarshal(call.Response, &response); err != nil {
+			return response, fmt.Errorf("replay: failed to decode recorded %s response: %w", method, err)
+		}
+	}
+	return response, nil
+}
+
+func (r *ReplayAPI) AuthTest(ctx context.Context) (*slack.AuthTestResponse, error) {
+	recorded, err := replay[*authTestResponse](ctx, r, "auth.test", nil)
+	if err != nil || recorded == nil {
+		return nil, err
+	}
+	resp := recorded.AuthTestResponse
+	if resp == nil {
+		resp = &slack.AuthTestResponse{}
+	}
+	for _, scopes := range recorded.OAuthScopes {
+		if resp.Header == nil {
+			resp.Header = http.Header{}
+		}
+		resp.Header.Add(oauthScopesHeader, scopes)
+	}
+	return resp, nil
+}
+
+func (r *ReplayAPI) GetConversations(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
+	page, err := replay[pageResponse[slack.Channel]](ctx, r, "conversations.list", params)
+	return page.Items, page.NextCursor, err
+}
+
+func (r *ReplayAPI) GetConversationHistory(ctx context.Context, params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+	return replay[*slack.GetConversationHistoryResponse](ctx, r, "conversations.history", params)
+}
+
+func (r *ReplayAPI) GetConversationInfo(ctx context.Context, input *slack.GetConversationInfoInput) (*slack.Channel, error) {
+	return replay[*slack.Channel](ctx, r, "conversations.info", input)
+}
+
+func (r *ReplayAPI) GetConversationReplies(ctx context.Context, params *slack.GetConversationRepliesParameters) ([]slack.Message, string, error) {
+	page, err := replay[pageResponse[slack.Message]](ctx, r, "conversations.replies", params)
+	return page.Items, page.NextCursor, err
+}
+
+func (r *ReplayAPI) GetConversationsForUser(ctx context.Context, params *slack.GetConversationsForUserParameters) ([]slack.Channel, string, error) {
+	page, err := replay[pageResponse[slack.Channel]](ctx, r, "users.conversations", params)
+	return page.Items, page.NextCursor, err
+}
+
+func (r *ReplayAPI) PostMessage(ctx context.Context, channelID string, options ...slack.MsgOption) (string, string, error) {
+	request := postMessageRequest{Channel: channelID, Text: postMessageText(channelID, options)}
+	resp, err := replay[postMessageResponse](ctx, r, "chat.postMessage", request)
+	return resp.Channel, resp.Timestamp, err
+}
+
+func (r *ReplayAPI) ArchiveConversation(ctx context.Context, channelID string) error {
+	_, err := replay[struct{}](ctx, r, "conversations.archive", channelRequest{Channel: channelID})
+	return err
+}
+
+func (r *ReplayAPI) UnarchiveConversation(ctx context.Context, channelID string) error {
+	_, err := replay[struct{}](ctx, r, "conversations.unarchive", channelRequest{Channel: channelID})
+	return err
+}
+
+func (r *ReplayAPI) JoinConversation(ctx context.Context, channelID string) (*slack.Channel, string, []string, error) {
+	resp, err := replay[joinResponse](ctx, r, "conversations.join", channelRequest{Channel: channelID})
+	return resp.Channel, resp.Warning, resp.Warnings, err
+}
+
+func (r *ReplayAPI) GetReactions(ctx context.Context, item slack.ItemRef, params slack.GetReactionsParameters) ([]slack.ItemReaction, error) {
+	return replay[[]slack.ItemReaction](ctx, r, "reactions.get", reactionsRequest{Item: item, Full: params.Full})
+}
+
+func (r *ReplayAPI) GetUsersPage(ctx context.Context, cursor string, limit int) ([]slack.User, string, error) {
+	page, err := replay[pageResponse[slack.User]](ctx, r, "users.list", usersPageRequest{Cursor: cursor, Limit: limit})
+	return page.Items, page.NextCursor, err
+}
+
+func (r *ReplayAPI) GetTeamInfo(ctx context.Context) (*slack.TeamInfo, error) {
+	return replay[*slack.TeamInfo](ctx, r, "team.info", nil)
+}
@@ -0,0 +1,185 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "session.json")
+
+	mockAPI := NewMockSlackAPI()
+	mockAPI.AddChannel("C1234567", "general", time.Now().Add(-48*time.Hour), "General chat")
+	mockAPI.AddChannel("C2345678", "random", time.Now().Add(-48*time.Hour), "Posted by MOCK-SECRET-TOKEN-123")
+	mockAPI.AddMessageToHistory("C1234567", "hello", "U1234567", fmt.Sprintf("%d.000100", time.Now().Add(-time.Hour).Unix()))
+	mockAPI.SetArchiveConversationErrorWithMessage("C2345678", true, "missing_scope")
+
+	recorder := NewRecordingAPI(mockAPI, path)
+	recordedChannels, _, err := recorder.GetConversations(ctx, &slack.GetConversationsParameters{Types: []string{"public_channel"}})
+	require.NoError(t, err)
+	recordedHistory, err := recorder.GetConversationHistory(ctx, &slack.GetConversationHistoryParameters{ChannelID: "C1234567", Limit: 10})
+	require.NoError(t, err)
+	_, ts, err := recorder.PostMessage(ctx, "C1234567", slack.MsgOptionText("warning sent at 10:00", false))
+	require.NoError(t, err)
+	require.Error(t, recorder.ArchiveConversation(ctx, "C2345678"))
+	require.NoError(t, recorder.Close())
+
+	t.Run("Session file is owner-only and redacted", func(t *testing.T) {
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "MOCK-SECRET-TOKEN-123")
+		assert.Contains(t, string(data), "[REDACTED]")
+		assert.Contains(t, string(data), "warning sent at 10:00")
+	})
+
+	replayAPI, err := LoadReplayAPI(path)
+	require.NoError(t, err)
+
+	t.Run("Responses are served back", func(t *testing.T) {
+		channels, cursor, err := replayAPI.GetConversations(ctx, &slack.GetConversationsParameters{Types: []string{"public_channel"}})
+		require.NoError(t, err)
+		assert.Empty(t, cursor)
+		require.Len(t, channels, len(recordedChannels))
+		assert.Equal(t, recordedChannels[0].Name, channels[0].Name)
+		assert.Equal(t, recordedChannels[0].Created, channels[0].Created)
+
+		history, err := replayAPI.GetConversationHistory(ctx, &slack.GetConversationHistoryParameters{ChannelID: "C1234567", Limit: 10})
+		require.NoError(t, err)
+		require.Len(t, history.Messages, len(recordedHistory.Messages))
+		assert.Equal(t, recordedHistory.Messages[0].Timestamp, history.Messages[0].Timestamp)
+	})
+
+	t.Run("Message text is not matched", func(t *testing.T) {
+		_, replayedTS, err := replayAPI.PostMessage(ctx, "C1234567", slack.MsgOptionText("warning sent at 11:00", false))
+		require.NoError(t, err)
+		assert.Equal(t, ts, replayedTS)
+	})
+
+	t.Run("Errors come back typed", func(t *testing.T) {
+		err := replayAPI.ArchiveConversation(ctx, "C2345678")
+		assert.ErrorIs(t, err, ErrMissingScope)
+	})
+
+	t.Run("Each recorded response is served once", func(t *testing.T) {
+		_, _, err := replayAPI.GetConversations(ctx, &slack.GetConversationsParameters{Types: []string{"public_channel"}})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "no recorded conversations.list response")
+	})
+
+	t.Run("Unrecorded requests fail", func(t *testing.T) {
+		_, err := replayAPI.GetConversationHistory(ctx, &slack.GetConversationHistoryParameters{ChannelID: "C9999999"})
+		assert.Error(t, err)
+	})
+
+	t.Run("Cancelled context", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := replayAPI.AuthTest(cancelled)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestReplayClient(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+
+	mockAPI := NewMockSlackAPI()
+	mockAPI.AddChannel("C1234567", "new-channel", time.Now().Add(-time.Hour), "")
+	recording, err := NewClientWithAPI(NewRecordingAPI(mockAPI, path))
+	require.NoError(t, err)
+	recorded, err := recording.GetNewChannels(context.Background(), time.Now().Add(-24*time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, recording.Close())
+
+	replaying, err := NewReplayClient(path)
+	require.NoError(t, err)
+	replayed, err := replaying.GetNewChannels(context.Background(), time.Now().Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, recorded, replayed)
+	assert.NoError(t, replaying.Close())
+
+	t.Run("Missing file", func(t *testing.T) {
+		_, err := NewReplayClient(filepath.Join(t.TempDir(), "missing.json"))
+		assert.Error(t, err)
+	})
+
+	t.Run("Unsupported version", func(t *testing.T) {
+		badPath := filepath.Join(t.TempDir(), "bad.json")
+		require.NoError(t, os.WriteFile(badPath, []byte(`{"version": 99, "calls": []}`), 0o600))
+		_, err := NewReplayClient(badPath)
+		assert.ErrorContains(t, err, "unsupported recorded session version")
+	})
+}
+
+func TestReplayIsPinnedToRecordingTime(t *testing.T) {
+	const day = 24 * time.Hour
+	path := filepath.Join(t.TempDir(), "session.json")
+	ts := func(at time.Time) string { return fmt.Sprintf("%d.000000", at.Unix()) }
+
+	// The session is recorded 60 days before it is replayed: quiet is due
+	// for a warning then, recent and warned only become due later.
+	recordedAt := time.Now().Add(-60 * day).UTC().Truncate(time.Second)
+	mockAPI := NewMockSlackAPI()
+	mockAPI.AddChannel("C1", "quiet", recordedAt.Add(-200*day), "")
+	mockAPI.AddChannel("C2", "recent", recordedAt.Add(-200*day), "")
+	mockAPI.AddChannel("C3", "warned", recordedAt.Add(-200*day), "")
+	mockAPI.SetChannelHistory("C1", []MockHistoryMessage{{Timestamp: ts(recordedAt.Add(-50 * day)), User: "U1", Text: "hello"}})
+	mockAPI.SetChannelHistory("C2", []MockHistoryMessage{{Timestamp: ts(recordedAt.Add(-10 * day)), User: "U1", Text: "hello"}})
+	recording, err := NewClientWithAPI(NewRecordingAPI(mockAPI, path))
+	require.NoError(t, err)
+	warning := recording.FormatInactiveChannelWarning(Channel{ID: "C3", Name: "warned"}, 45*secondsPerDay, 30*secondsPerDay, "")
+	mockAPI.SetChannelHistory("C3", []MockHistoryMessage{
+		{Timestamp: ts(recordedAt.Add(-100 * day)), User: "U1", Text: "hello"},
+		{Timestamp: ts(recordedAt.Add(-10 * day)), User: "U0000000", Text: warning},
+	})
+
+	analyze := func(client *Client) ([]string, []string) {
+		toWarn, toArchive, _, err := client.GetInactiveChannelsWithDetailsAndExclusions(context.Background(), 45*secondsPerDay, 30*secondsPerDay, map[string]string{}, nil, nil, false, false, 0)
+		require.NoError(t, err)
+		return channelNames(toWarn), channelNames(toArchive)
+	}
+	recording.SetClock(func() time.Time { return recordedAt })
+	recordedWarn, recordedArchive := analyze(recording)
+	require.NoError(t, recording.Close())
+	assert.Equal(t, []string{"quiet"}, recordedWarn)
+	assert.Empty(t, recordedArchive)
+
+	// Date the session to when the recorded run happened.
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var session recordedSession
+	require.NoError(t, json.Unmarshal(data, &session))
+	session.RecordedAt = recordedAt
+	data, err = json.Marshal(session)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	replaying, err := NewReplayClient(path)
+	require.NoError(t, err)
+	assert.True(t, replaying.Now().Equal(recordedAt))
+	replayedWarn, replayedArchive := analyze(replaying)
+	assert.Equal(t, recordedWarn, replayedWarn)
+	assert.Equal(t, recordedArchive, replayedArchive)
+
+	t.Run("On the wall clock the replay would classify channels differently", func(t *testing.T) {
+		replaying, err := NewReplayClient(path)
+		require.NoError(t, err)
+		replaying.SetClock(nil)
+		wallWarn, wallArchive := analyze(replaying)
+		assert.Equal(t, []string{"quiet", "recent"}, wallWarn)
+		assert.Equal(t, []string{"warned"}, wallArchive)
+	})
+}
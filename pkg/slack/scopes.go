@@ -0,0 +1,90 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/astrostl/slack-butler/pkg/logger"
+)
+
+// oauthScopesHeader is the response header in which Slack lists the OAuth
+// scopes granted to the calling token.
+const oauthScopesHeader = "X-OAuth-Scopes"
+
+// KnownScopes lists every OAuth scope slack-butler can use.
+var KnownScopes = []string{
+	"channels:read",
+	"channels:join",
+	"channels:history",
+	"channels:manage",
+	"chat:write",
+	"users:read",
+	"groups:read",
+	"groups:history",
+	"groups:write",
+	"reactions:read",
+}
+
+// GrantedScopes returns the OAuth scopes granted to the token, as reported
+// in the X-OAuth-Scopes header of auth.test.
+func (c *Client) GrantedScopes(ctx context.Context) (map[string]bool, error) {
+	auth, err := c.api.AuthTest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate: %w", err)
+	}
+	if len(auth.Header.Values(oauthScopesHeader)) == 0 {
+		return nil, fmt.Errorf("slack did not report the token's OAuth scopes (no %s header on auth.test)", oauthScopesHeader)
+	}
+	return parseScopes(auth.Header), nil
+}
+
+// CheckOAuthScopes reports, for every scope in KnownScopes, whether it has
+// been granted to the token.
+func (c *Client) CheckOAuthScopes(ctx context.Context) (map[string]bool, error) {
+	granted, err := c.GrantedScopes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	scopeResults := make(map[string]bool, len(KnownScopes))
+	for _, scope := range KnownScopes {
+		scopeResults[scope] = granted[scope]
+	}
+	return scopeResults, nil
+}
+
+// RequireScopes returns an error wrapping a *MissingScopeError for the first of scopes the
+// token has not been granted. If Slack doesn't report the granted scopes the
+// check is skipped, leaving missing scopes to surface from the calls that
+// need them.
+func (c *Client) RequireScopes(ctx context.Context, scopes ...string) error {
+	granted, err := c.GrantedScopes(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		logger.WithField("error", err.Error()).Debug("Skipping OAuth scope pre-flight check")
+		return nil
+	}
+	for _, scope := range scopes {
+		if !granted[scope] {
+			return describe(withScope(ErrMissingScope, scope), "missing required OAuth scope '%s'. Add it in your Slack app settings at https://api.slack.com/apps and reinstall the app", scope)
+		}
+	}
+	return nil
+}
+
+// parseScopes parses a comma-separated X-OAuth-Scopes header.
+func parseScopes(header http.Header) map[string]bool {
+	scopes := make(map[string]bool)
+	for _, value := range header.Values(oauthScopesHeader) {
+		for _, scope := range strings.Split(value, ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				scopes[scope] = true
+			}
+		}
+	}
+	return scopes
+}
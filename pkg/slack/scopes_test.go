@@ -0,0 +1,109 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrantedScopes(t *testing.T) {
+	t.Run("Every known scope is granted by default", func(t *testing.T) {
+		client, err := NewClientWithAPI(NewMockSlackAPI())
+		require.NoError(t, err)
+
+		scopes, err := client.CheckOAuthScopes(context.Background())
+		require.NoError(t, err)
+		assert.Len(t, scopes, len(KnownScopes))
+		for _, scope := range KnownScopes {
+			assert.True(t, scopes[scope], scope)
+		}
+	})
+
+	t.Run("Scopes that can't be probed are reported from the header", func(t *testing.T) {
+		mockAPI := NewMockSlackAPI()
+		mockAPI.RevokeScopes("channels:join", "chat:write", "channels:manage", "groups:write")
+		client, err := NewClientWithAPI(mockAPI)
+		require.NoError(t, err)
+
+		scopes, err := client.CheckOAuthScopes(context.Background())
+		require.NoError(t, err)
+		assert.False(t, scopes["channels:join"])
+		assert.False(t, scopes["chat:write"])
+		assert.False(t, scopes["channels:manage"])
+		assert.False(t, scopes["groups:write"])
+		assert.True(t, scopes["channels:read"])
+	})
+
+	t.Run("Unknown scopes are kept by GrantedScopes only", func(t *testing.T) {
+		mockAPI := NewMockSlackAPI()
+		mockAPI.SetGrantedScopes("channels:read", " identify ")
+		client, err := NewClientWithAPI(mockAPI)
+		require.NoError(t, err)
+
+		granted, err := client.GrantedScopes(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, map[string]bool{"channels:read": true, "identify": true}, granted)
+
+		scopes, err := client.CheckOAuthScopes(context.Background())
+		require.NoError(t, err)
+		assert.NotContains(t, scopes, "identify")
+	})
+
+	t.Run("Missing header", func(t *testing.T) {
+		mockAPI := NewMockSlackAPI()
+		mockAPI.AuthTestResponse.Header = http.Header{}
+		client, err := NewClientWithAPI(mockAPI)
+		require.NoError(t, err)
+
+		_, err = client.CheckOAuthScopes(context.Background())
+		assert.ErrorContains(t, err, "X-OAuth-Scopes")
+		assert.NoError(t, client.RequireScopes(context.Background(), "chat:write"), "pre-flight check is skipped")
+	})
+
+	t.Run("Auth failure", func(t *testing.T) {
+		mockAPI := NewMockSlackAPI()
+		client, err := NewClientWithAPI(mockAPI)
+		require.NoError(t, err)
+		mockAPI.SetAuthError(true)
+
+		_, err = client.CheckOAuthScopes(context.Background())
+		assert.ErrorContains(t, err, "failed to authenticate")
+	})
+}
+
+func TestRequireScopes(t *testing.T) {
+	mockAPI := NewMockSlackAPI()
+	mockAPI.RevokeScopes("channels:manage")
+	client, err := NewClientWithAPI(mockAPI)
+	require.NoError(t, err)
+
+	assert.NoError(t, client.RequireScopes(context.Background(), "channels:read", "chat:write"))
+
+	err = client.RequireScopes(context.Background(), "chat:write", "channels:manage")
+	require.ErrorIs(t, err, ErrMissingScope)
+	var scopeErr *MissingScopeError
+	require.ErrorAs(t, err, &scopeErr)
+	assert.Equal(t, "channels:manage", scopeErr.Scope)
+	assert.Contains(t, err.Error(), "channels:manage")
+}
+
+func TestRecordedScopesAreReplayed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	mockAPI := NewMockSlackAPI()
+	mockAPI.SetGrantedScopes("channels:read", "chat:write")
+
+	recorder := NewRecordingAPI(mockAPI, path)
+	_, err := recorder.AuthTest(t.Context())
+	require.NoError(t, err)
+	require.NoError(t, recorder.Close())
+
+	replayAPI, err := LoadReplayAPI(path)
+	require.NoError(t, err)
+	auth, err := replayAPI.AuthTest(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"channels:read": true, "chat:write": true}, parseScopes(auth.Header))
+}
@@ -0,0 +1,91 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/astrostl/slack-butler/pkg/logger"
+
+	"github.com/slack-go/slack"
+)
+
+// snoozePattern matches the machine-readable marker ending a snooze message,
+// e.g. [butler:snooze until=2026-11-15T12:00:00Z].
+var snoozePattern = regexp.MustCompile(`\[butler:snooze until=([^\]\s]+)\]`)
+
+// FormatSnoozeMessage formats the message recording that archival of a
+// channel is snoozed until the given time. The inactivity analysis reads the
+// snooze back from the message's marker, so no state is kept elsewhere.
+func FormatSnoozeMessage(until time.Time, reason string) string {
+	var builder strings.Builder
+
+	builder.WriteString("💤 Archival Snoozed 💤\n\n")
+	fmt.Fprintf(&builder, "This channel won't be warned or archived for inactivity before %s.\n\n", until.Format("Mon, 02 Jan 2006"))
+	if reason != "" {
+		fmt.Fprintf(&builder, "Reason: %s\n\n", reason)
+	}
+	fmt.Fprintf(&builder, "[butler:snooze until=%s]", until.UTC().Format(time.RFC3339))
+
+	return builder.String()
+}
+
+// SnoozeChannel postpones warnings and archival of the named channel until
+// the given time by posting a snooze message in it.
+func (c *Client) SnoozeChannel(ctx context.Context, channelName string, until time.Time, reason string) error {
+	ch, err := c.findChannelByName(ctx, channelName)
+	if err != nil {
+		return err
+	}
+	channel := c.createEnhancedChannel(*ch, time.Time{}, nil)
+	if err := c.ensureBotInChannel(ctx, channel); err != nil {
+		return fmt.Errorf("failed to join channel %s: %w", channel.Name, err)
+	}
+
+	logger.WithFields(logger.LogFields{
+		"channel": channel.Name,
+		"until":   until.Format(time.RFC3339),
+	}).Debug("Posting snooze message")
+
+	err = c.postMessageToChannelID(ctx, channel.ID, FormatSnoozeMessage(until, reason))
+	c.audit(AuditEvent{Action: AuditActionSnooze, ChannelID: channel.ID, Channel: channel.Name, SnoozedUntil: until}, err)
+	return err
+}
+
+// findChannelByName returns the unarchived channel named channelName, with
+// or without a # prefix.
+func (c *Client) findChannelByName(ctx context.Context, channelName string) (*slack.Channel, error) {
+	cleanName := strings.TrimPrefix(channelName, "#")
+	channels, err := c.getAllConversations(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channels: %w", err)
+	}
+	for i := range channels {
+		if channels[i].Name == cleanName {
+			return &channels[i], nil
+		}
+	}
+	return nil, fmt.Errorf("channel '%s': %w", channelName, ErrChannelNotFound)
+}
+
+// isSnoozeMessage reports whether msg is a snooze message from the bot.
+func isSnoozeMessage(msg slack.Message, botUserID string) bool {
+	return botUserID != "" && msg.User == botUserID && snoozePattern.MatchString(msg.Text)
+}
+
+// findSnooze returns the end of the most recent snooze posted by the bot in
+// messages, newest first, or the zero time.
+func findSnooze(messages []slack.Message, botUserID string) time.Time {
+	for _, msg := range messages {
+		if !isSnoozeMessage(msg, botUserID) {
+			continue
+		}
+		until, err := time.Parse(time.RFC3339, snoozePattern.FindStringSubmatch(msg.Text)[1])
+		if err == nil {
+			return until
+		}
+	}
+	return time.Time{}
+}
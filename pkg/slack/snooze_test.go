@@ -0,0 +1,72 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatSnoozeMessage(t *testing.T) {
+	until := time.Date(2026, 11, 15, 12, 0, 0, 0, time.UTC)
+	message := FormatSnoozeMessage(until, "quarterly planning resumes in November")
+	assert.Contains(t, message, "Reason: quarterly planning resumes in November")
+	assert.Contains(t, message, "[butler:snooze until=2026-11-15T12:00:00Z]")
+	assert.NotContains(t, message, "Reason:\n", "no reason line without a reason")
+	assert.NotContains(t, FormatSnoozeMessage(until, ""), "Reason:")
+}
+
+func TestSnoozeInactivityAnalysis(t *testing.T) {
+	botUserID := "U0000000"
+	ts := func(t time.Time) string { return fmt.Sprintf("%d.000000", t.Unix()) }
+	now := time.Now()
+
+	setup := func(snoozeUntil time.Time) *MockSlackAPI {
+		mockAPI := NewMockSlackAPI()
+		mockAPI.AddChannel("C1", "quiet", now.Add(-200*24*time.Hour), "")
+		mockAPI.SetChannelHistory("C1", []MockHistoryMessage{
+			{Timestamp: ts(now.Add(-100 * 24 * time.Hour)), User: "U1", Text: "last real message"},
+			{Timestamp: ts(now.Add(-40 * 24 * time.Hour)), User: botUserID, Text: "🚨 Inactive Channel Warning 🚨"},
+			{Timestamp: ts(now.Add(-1 * 24 * time.Hour)), User: botUserID, Text: FormatSnoozeMessage(snoozeUntil, "")},
+		})
+		return mockAPI
+	}
+	analyze := func(mockAPI *MockSlackAPI) ([]Channel, []Channel) {
+		client, err := NewClientWithAPI(mockAPI)
+		require.NoError(t, err)
+		toWarn, toArchive, _, err := client.GetInactiveChannelsWithDetailsAndExclusions(context.Background(), 45*secondsPerDay, 30*secondsPerDay, map[string]string{}, nil, nil, false, false, 0)
+		require.NoError(t, err)
+		return toWarn, toArchive
+	}
+
+	toWarn, toArchive := analyze(setup(now.Add(29 * 24 * time.Hour)))
+	assert.Empty(t, toWarn)
+	assert.Empty(t, toArchive, "a snoozed channel is not archived")
+
+	toWarn, toArchive = analyze(setup(now.Add(-time.Hour)))
+	assert.Empty(t, toWarn, "the snooze message is not activity, so the warning still stands once it ends")
+	assert.Empty(t, toArchive, "the grace period starts over when the snooze ends")
+
+	toWarn, toArchive = analyze(setup(now.Add(-31 * 24 * time.Hour)))
+	assert.Empty(t, toWarn)
+	require.Len(t, toArchive, 1, "archived once the grace period after the snooze has passed")
+	assert.Equal(t, "quiet", toArchive[0].Name)
+}
+
+func TestSnoozeChannel(t *testing.T) {
+	mockAPI := NewMockSlackAPI()
+	mockAPI.AddChannel("C1", "quiet", time.Now().Add(-200*24*time.Hour), "")
+	client, err := NewClientWithAPI(mockAPI)
+	require.NoError(t, err)
+
+	require.NoError(t, client.SnoozeChannel(context.Background(), "#quiet", time.Now().Add(30*24*time.Hour), "keep for planning"))
+	assert.Equal(t, []string{"C1"}, mockAPI.GetJoinedChannels())
+	require.Len(t, mockAPI.GetPostedMessages(), 1)
+	assert.Equal(t, "C1", mockAPI.GetPostedMessages()[0].ChannelID)
+
+	err = client.SnoozeChannel(context.Background(), "missing", time.Now(), "")
+	assert.ErrorIs(t, err, ErrChannelNotFound)
+}
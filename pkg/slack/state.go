@@ -0,0 +1,51 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// ChannelState is what the inactivity analysis sees of a channel right now,
+// used to check that a previously planned warning or archival still applies.
+type ChannelState struct {
+	LastActivity time.Time
+	WarnedAt     time.Time
+	SnoozedUntil time.Time
+	Name         string
+	// Marker is the [butler:...] marker in the channel's topic or purpose
+	// that sets its rule, if any.
+	Marker     string
+	KeepVoters []string
+	IsArchived bool
+}
+
+// GetChannelState fetches the current state of the channel with the given
+// ID: its name and archival status from conversations.info, and its last
+// activity, standing warning, snooze and keep votes as the inactivity
+// analysis would find them.
+func (c *Client) GetChannelState(ctx context.Context, channelID string) (*ChannelState, error) {
+	ch, err := c.api.GetConversationInfo(ctx, &slack.GetConversationInfoInput{ChannelID: channelID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channel info: %w", err)
+	}
+	state := &ChannelState{Name: ch.Name, IsArchived: ch.IsArchived}
+	if rule := c.markerRule(*ch); rule != nil {
+		state.Marker = rule.Name
+	}
+	if ch.IsArchived {
+		return state, nil
+	}
+
+	result := c.getChannelActivityResult(ctx, channelID)
+	if result.err != nil {
+		return nil, result.err
+	}
+	state.LastActivity = result.lastActivity
+	state.WarnedAt = result.warningTime
+	state.SnoozedUntil = result.snoozedUntil
+	state.KeepVoters = result.keepVoters
+	return state, nil
+}
@@ -0,0 +1,106 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/astrostl/slack-butler/pkg/logger"
+
+	"github.com/slack-go/slack"
+)
+
+// SetSkipThreads controls whether thread replies are ignored when working
+// out a channel's last activity. Checking threads costs a
+// conversations.replies call for each thread with replies newer than the
+// channel's last top-level message, so skipping them speeds up large runs.
+func (c *Client) SetSkipThreads(skip bool) {
+	c.skipThreads = skip
+}
+
+// SkipThreads reports whether thread replies are ignored.
+func (c *Client) SkipThreads() bool {
+	return c.skipThreads
+}
+
+// applyThreadActivity makes the newest reply in the threads started by
+// messages the channel's last activity when it is more recent than result's.
+// A reply, including one to the bot's warning, supersedes the warning.
+func (c *Client) applyThreadActivity(ctx context.Context, result *channelActivityResult, channelID string, messages []slack.Message, botUserID string) {
+	reply, replyTime, err := c.latestThreadReply(ctx, channelID, messages, result.lastActivity, botUserID)
+	if err != nil {
+		result.err = err
+		return
+	}
+	if reply == nil {
+		return
+	}
+
+	logger.WithFields(logger.LogFields{
+		"channel_id":    channelID,
+		"thread_reply":  replyTime.Format(time.RFC3339),
+		"last_toplevel": result.lastActivity.Format(time.RFC3339),
+	}).Debug("Thread reply is the channel's most recent activity")
+
+	result.lastActivity = replyTime
+	result.lastMessage = c.createMessageInfo(reply, replyTime, botUserID)
+	result.hasWarning = false
+	result.warningTime = time.Time{}
+	result.keepVoters = nil
+}
+
+// latestThreadReply returns the most recent real reply, newer than since,
+// to the threads started by messages, or nil. Threads are only fetched when
+// conversations.history reports a latest_reply newer than since.
+func (c *Client) latestThreadReply(ctx context.Context, channelID string, messages []slack.Message, since time.Time, botUserID string) (*slack.Message, time.Time, error) {
+	var latest *slack.Message
+	var latestTime time.Time
+	for _, msg := range messages {
+		if msg.ReplyCount == 0 || msg.LatestReply == "" {
+			continue
+		}
+		if replyTime, err := parseSlackTimestamp(msg.LatestReply); err != nil || !replyTime.After(since) || !replyTime.After(latestTime) {
+			continue
+		}
+		reply, replyTime, err := c.newestThreadReply(ctx, channelID, msg.Timestamp, since, botUserID)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		if reply != nil && replyTime.After(latestTime) {
+			latest, latestTime = reply, replyTime
+		}
+	}
+	return latest, latestTime, nil
+}
+
+// newestThreadReply fetches the replies to the thread at threadTS posted
+// after since and returns the newest real one not from the bot, or nil.
+func (c *Client) newestThreadReply(ctx context.Context, channelID, threadTS string, since time.Time, botUserID string) (*slack.Message, time.Time, error) {
+	replies, err := paginate(ctx, "conversations.replies", func(cursor string) ([]slack.Message, string, error) {
+		return c.api.GetConversationReplies(ctx, &slack.GetConversationRepliesParameters{
+			ChannelID: channelID,
+			Timestamp: threadTS,
+			Oldest:    strconv.FormatInt(since.Unix(), 10),
+			Cursor:    cursor,
+			Limit:     conversationsPageLimit,
+		})
+	})
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to get thread replies: %w", err)
+	}
+
+	// Replies come oldest first, after the thread's parent
+	for i := len(replies) - 1; i >= 0; i-- {
+		reply := replies[i]
+		if reply.Timestamp == threadTS || reply.User == botUserID || !isRealMessage(reply, botUserID) {
+			continue
+		}
+		replyTime, err := parseSlackTimestamp(reply.Timestamp)
+		if err != nil || !replyTime.After(since) {
+			continue
+		}
+		return &reply, replyTime, nil
+	}
+	return nil, time.Time{}, nil
+}
@@ -0,0 +1,99 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThreadActivity(t *testing.T) {
+	now := time.Now()
+	ts := func(t time.Time) string { return fmt.Sprintf("%d.000000", t.Unix()) }
+	daysAgo := func(days int) string { return ts(now.Add(-time.Duration(days) * 24 * time.Hour)) }
+	newClient := func(t *testing.T, messages []MockHistoryMessage) (*Client, *MockSlackAPI) {
+		mockAPI := NewMockSlackAPI()
+		mockAPI.AddChannel("C1", "threaded", now.Add(-200*24*time.Hour), "")
+		mockAPI.SetChannelHistory("C1", messages)
+		client, err := NewClientWithAPI(mockAPI)
+		require.NoError(t, err)
+		return client, mockAPI
+	}
+
+	t.Run("Reply to an old parent is the last activity", func(t *testing.T) {
+		client, mockAPI := newClient(t, []MockHistoryMessage{
+			{Timestamp: daysAgo(120), User: "U1", Text: "long-running thread", Replies: []MockHistoryMessage{
+				{Timestamp: daysAgo(100), User: "U2", Text: "old reply"},
+				{Timestamp: daysAgo(2), User: "U2", Text: "recent reply"},
+				{Timestamp: daysAgo(1), User: "U0000000", Text: "bot reply"},
+			}},
+			{Timestamp: daysAgo(90), User: "U1", Text: "last top-level message"},
+		})
+
+		lastActivity, _, _, lastMessage, err := client.GetChannelActivityWithMessage(context.Background(), "C1")
+		require.NoError(t, err)
+		assert.Equal(t, daysAgo(2), ts(lastActivity))
+		require.NotNil(t, lastMessage)
+		assert.Equal(t, "recent reply", lastMessage.Text)
+		assert.Equal(t, 1, mockAPI.GetConversationRepliesCalls)
+	})
+
+	t.Run("Reply to the warning supersedes it", func(t *testing.T) {
+		client, _ := newClient(t, []MockHistoryMessage{
+			{Timestamp: daysAgo(100), User: "U1", Text: "last real message"},
+			{Timestamp: daysAgo(40), User: "U0000000", Text: "🚨 Inactive Channel Warning 🚨", Replies: []MockHistoryMessage{
+				{Timestamp: daysAgo(10), User: "U1", Text: "we still use this"},
+			}},
+		})
+
+		lastActivity, hasWarning, _, _, err := client.GetChannelActivityWithMessage(context.Background(), "C1")
+		require.NoError(t, err)
+		assert.False(t, hasWarning)
+		assert.Equal(t, daysAgo(10), ts(lastActivity))
+	})
+
+	t.Run("Threads older than the last message aren't fetched", func(t *testing.T) {
+		client, mockAPI := newClient(t, []MockHistoryMessage{
+			{Timestamp: daysAgo(120), User: "U1", Text: "parent", Replies: []MockHistoryMessage{
+				{Timestamp: daysAgo(110), User: "U2", Text: "reply"},
+			}},
+			{Timestamp: daysAgo(90), User: "U1", Text: "last top-level message"},
+		})
+
+		lastActivity, _, _, _, err := client.GetChannelActivityWithMessage(context.Background(), "C1")
+		require.NoError(t, err)
+		assert.Equal(t, daysAgo(90), ts(lastActivity))
+		assert.Zero(t, mockAPI.GetConversationRepliesCalls)
+	})
+
+	t.Run("Skipped threads", func(t *testing.T) {
+		client, mockAPI := newClient(t, []MockHistoryMessage{
+			{Timestamp: daysAgo(120), User: "U1", Text: "parent", Replies: []MockHistoryMessage{
+				{Timestamp: daysAgo(2), User: "U2", Text: "reply"},
+			}},
+		})
+		client.SetSkipThreads(true)
+		assert.True(t, client.SkipThreads())
+
+		lastActivity, _, _, _, err := client.GetChannelActivityWithMessage(context.Background(), "C1")
+		require.NoError(t, err)
+		assert.Equal(t, daysAgo(120), ts(lastActivity))
+		assert.Zero(t, mockAPI.GetConversationRepliesCalls)
+	})
+
+	t.Run("Replies error", func(t *testing.T) {
+		client, mockAPI := newClient(t, []MockHistoryMessage{
+			{Timestamp: daysAgo(120), User: "U1", Text: "parent", Replies: []MockHistoryMessage{
+				{Timestamp: daysAgo(2), User: "U2", Text: "reply"},
+			}},
+		})
+		mockAPI.GetConversationRepliesError = errors.New("ratelimited")
+
+		_, _, _, _, err := client.GetChannelActivityWithMessage(context.Background(), "C1")
+		assert.ErrorContains(t, err, "failed to get thread replies")
+	})
+}
@@ -0,0 +1,95 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/astrostl/slack-butler/pkg/logger"
+)
+
+// FormatChannelRestoredMessage formats the notice posted in a channel once
+// it has been restored from the archive.
+func (c *Client) FormatChannelRestoredMessage(discussionChannelID string) string {
+	var builder strings.Builder
+
+	builder.WriteString("♻️ Channel Restored ♻️\n\n")
+	builder.WriteString("This channel was archived for inactivity and has now been restored.\n\n")
+	fmt.Fprintf(&builder, "Sorry for the disruption! Questions or concerns can go to %s.", c.discussionChannelLink(discussionChannelID))
+
+	return builder.String()
+}
+
+// FindChannelsByName returns the channels, archived or not, with the given
+// names, with or without a # prefix, in the order given.
+func (c *Client) FindChannelsByName(ctx context.Context, names []string) ([]Channel, error) {
+	all, err := c.getAllConversations(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channels: %w", err)
+	}
+	byName := make(map[string]Channel, len(all))
+	for _, ch := range all {
+		byName[ch.Name] = Channel{ID: ch.ID, Name: ch.Name, IsArchived: ch.IsArchived, IsPrivate: ch.IsPrivate}
+	}
+
+	channels := make([]Channel, 0, len(names))
+	for _, name := range names {
+		ch, ok := byName[strings.TrimPrefix(name, "#")]
+		if !ok {
+			return nil, fmt.Errorf("channel '%s': %w", name, ErrChannelNotFound)
+		}
+		channels = append(channels, ch)
+	}
+	return channels, nil
+}
+
+// UnarchiveChannel restores channel from the archive. Archiving removes the
+// bot from a channel, so it rejoins public channels afterwards. Channels
+// that aren't archived are reported with ErrNotArchived.
+func (c *Client) UnarchiveChannel(ctx context.Context, channel Channel) error {
+	logger.WithField("channel", channel.Name).Debug("Unarchiving channel")
+
+	err := c.api.UnarchiveConversation(ctx, channel.ID)
+	c.audit(AuditEvent{Action: AuditActionUnarchive, ChannelID: channel.ID, Channel: channel.Name}, err)
+	if err != nil {
+		logger.WithFields(logger.LogFields{
+			"channel":   channel.Name,
+			"error":     err.Error(),
+			"operation": "unarchive_conversation",
+		}).Error("Failed to unarchive channel")
+
+		switch {
+		case errors.Is(err, ErrNotArchived):
+			return fmt.Errorf("channel '%s': %w", channel.Name, err)
+		case errors.Is(err, ErrRateLimited):
+			return describe(err, "rate limited by Slack API after repeated retries. Please wait before running again")
+		case errors.Is(err, ErrMissingScope):
+			scope := "channels:manage"
+			if channel.IsPrivate {
+				scope = "groups:write"
+			}
+			return describe(withScope(err, scope), "missing required permission to unarchive channels. Your bot needs the '%s' OAuth scope.\nPlease add this scope in your Slack app settings at https://api.slack.com/apps", scope)
+		case errors.Is(err, ErrChannelNotFound):
+			return describe(err, "channel '%s' not found", channel.Name)
+		}
+		return fmt.Errorf("failed to unarchive channel %s: %w", channel.Name, err)
+	}
+
+	if err := c.ensureBotInChannel(ctx, channel); err != nil {
+		return fmt.Errorf("unarchived channel %s but failed to rejoin it: %w", channel.Name, err)
+	}
+	return nil
+}
+
+// PostRestoredNotice posts the restored notice in a channel restored with
+// UnarchiveChannel.
+func (c *Client) PostRestoredNotice(ctx context.Context, channel Channel) error {
+	discussionChannelID, err := c.ResolveChannelNameToID(ctx, c.DiscussionChannel())
+	if err != nil {
+		discussionChannelID = ""
+	}
+	err = c.postMessageToChannelID(ctx, channel.ID, c.FormatChannelRestoredMessage(discussionChannelID))
+	c.audit(AuditEvent{Action: AuditActionRestoredMessage, ChannelID: channel.ID, Channel: channel.Name}, err)
+	return err
+}
@@ -0,0 +1,60 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnarchiveChannel(t *testing.T) {
+	setup := func(t *testing.T) (*MockSlackAPI, *Client) {
+		mockAPI := NewMockSlackAPI()
+		mockAPI.AddChannel("C1", "stale", time.Now().Add(-200*24*time.Hour), "")
+		mockAPI.AddChannel("C2", "active", time.Now().Add(-200*24*time.Hour), "")
+		mockAPI.setArchived("C1", true)
+		client, err := NewClientWithAPI(mockAPI)
+		require.NoError(t, err)
+		return mockAPI, client
+	}
+
+	t.Run("Find archived and active channels by name", func(t *testing.T) {
+		_, client := setup(t)
+		channels, err := client.FindChannelsByName(context.Background(), []string{"#stale", "active"})
+		require.NoError(t, err)
+		assert.Equal(t, []Channel{{ID: "C1", Name: "stale", IsArchived: true}, {ID: "C2", Name: "active"}}, channels)
+
+		_, err = client.FindChannelsByName(context.Background(), []string{"#missing"})
+		assert.ErrorIs(t, err, ErrChannelNotFound)
+	})
+
+	t.Run("Restores and rejoins", func(t *testing.T) {
+		mockAPI, client := setup(t)
+		require.NoError(t, client.UnarchiveChannel(context.Background(), Channel{ID: "C1", Name: "stale"}))
+		assert.Equal(t, []string{"C1"}, mockAPI.UnarchivedChannels)
+		assert.Equal(t, []string{"C1"}, mockAPI.JoinedChannels)
+
+		require.NoError(t, client.PostRestoredNotice(context.Background(), Channel{ID: "C1", Name: "stale"}))
+		posted := mockAPI.GetPostedMessages()
+		require.Len(t, posted, 1)
+		assert.Equal(t, "C1", posted[0].ChannelID)
+	})
+
+	t.Run("Channel that isn't archived", func(t *testing.T) {
+		mockAPI, client := setup(t)
+		err := client.UnarchiveChannel(context.Background(), Channel{ID: "C2", Name: "active"})
+		assert.ErrorIs(t, err, ErrNotArchived)
+		assert.Empty(t, mockAPI.JoinedChannels)
+	})
+
+	t.Run("Missing scope", func(t *testing.T) {
+		mockAPI, client := setup(t)
+		mockAPI.UnarchiveConversationErrors["C1"] = errors.New("missing_scope")
+		err := client.UnarchiveChannel(context.Background(), Channel{ID: "C1", Name: "stale"})
+		assert.ErrorIs(t, err, ErrMissingScope)
+		assert.Contains(t, err.Error(), "channels:manage")
+	})
+}